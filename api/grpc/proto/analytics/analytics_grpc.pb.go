@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/grpc/proto/analytics/analytics.proto
+
+package analytics
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetRunsPerDay_FullMethodName  = "/analytics.AnalyticsService/GetRunsPerDay"
+	AnalyticsService_GetRunOutcomes_FullMethodName = "/analytics.AnalyticsService/GetRunOutcomes"
+	AnalyticsService_GetDurations_FullMethodName   = "/analytics.AnalyticsService/GetDurations"
+	AnalyticsService_GetTopErrors_FullMethodName   = "/analytics.AnalyticsService/GetTopErrors"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service Definition
+type AnalyticsServiceClient interface {
+	GetRunsPerDay(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RunsPerDayResponse, error)
+	GetRunOutcomes(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RunOutcomesResponse, error)
+	GetDurations(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*DurationsResponse, error)
+	GetTopErrors(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*TopErrorsResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetRunsPerDay(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RunsPerDayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunsPerDayResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRunsPerDay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetRunOutcomes(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*RunOutcomesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunOutcomesResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRunOutcomes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetDurations(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*DurationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DurationsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetDurations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetTopErrors(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*TopErrorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopErrorsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetTopErrors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// Service Definition
+type AnalyticsServiceServer interface {
+	GetRunsPerDay(context.Context, *AnalyticsRequest) (*RunsPerDayResponse, error)
+	GetRunOutcomes(context.Context, *AnalyticsRequest) (*RunOutcomesResponse, error)
+	GetDurations(context.Context, *AnalyticsRequest) (*DurationsResponse, error)
+	GetTopErrors(context.Context, *AnalyticsRequest) (*TopErrorsResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetRunsPerDay(context.Context, *AnalyticsRequest) (*RunsPerDayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunsPerDay not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetRunOutcomes(context.Context, *AnalyticsRequest) (*RunOutcomesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunOutcomes not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetDurations(context.Context, *AnalyticsRequest) (*DurationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDurations not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetTopErrors(context.Context, *AnalyticsRequest) (*TopErrorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopErrors not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetRunsPerDay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRunsPerDay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRunsPerDay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRunsPerDay(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetRunOutcomes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRunOutcomes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRunOutcomes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRunOutcomes(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetDurations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetDurations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetDurations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetDurations(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetTopErrors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetTopErrors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetTopErrors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetTopErrors(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "analytics.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRunsPerDay",
+			Handler:    _AnalyticsService_GetRunsPerDay_Handler,
+		},
+		{
+			MethodName: "GetRunOutcomes",
+			Handler:    _AnalyticsService_GetRunOutcomes_Handler,
+		},
+		{
+			MethodName: "GetDurations",
+			Handler:    _AnalyticsService_GetDurations_Handler,
+		},
+		{
+			MethodName: "GetTopErrors",
+			Handler:    _AnalyticsService_GetTopErrors_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/grpc/proto/analytics/analytics.proto",
+}
@@ -19,10 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PipelineService_CreatePipeline_FullMethodName    = "/proto.PipelineService/CreatePipeline"
-	PipelineService_StartPipeline_FullMethodName     = "/proto.PipelineService/StartPipeline"
-	PipelineService_GetPipelineStatus_FullMethodName = "/proto.PipelineService/GetPipelineStatus"
-	PipelineService_CancelPipeline_FullMethodName    = "/proto.PipelineService/CancelPipeline"
+	PipelineService_CreatePipeline_FullMethodName          = "/proto.PipelineService/CreatePipeline"
+	PipelineService_StartPipeline_FullMethodName           = "/proto.PipelineService/StartPipeline"
+	PipelineService_GetPipelineStatus_FullMethodName       = "/proto.PipelineService/GetPipelineStatus"
+	PipelineService_CancelPipeline_FullMethodName          = "/proto.PipelineService/CancelPipeline"
+	PipelineService_ReplayPipeline_FullMethodName          = "/proto.PipelineService/ReplayPipeline"
+	PipelineService_GetBottlenecks_FullMethodName          = "/proto.PipelineService/GetBottlenecks"
+	PipelineService_GetQueue_FullMethodName                = "/proto.PipelineService/GetQueue"
+	PipelineService_ListPipelines_FullMethodName           = "/proto.PipelineService/ListPipelines"
+	PipelineService_GetPipelineMetadata_FullMethodName     = "/proto.PipelineService/GetPipelineMetadata"
+	PipelineService_UpdatePipelineMetadata_FullMethodName  = "/proto.PipelineService/UpdatePipelineMetadata"
+	PipelineService_ListPipelineRuns_FullMethodName        = "/proto.PipelineService/ListPipelineRuns"
+	PipelineService_GetPipelineRun_FullMethodName          = "/proto.PipelineService/GetPipelineRun"
+	PipelineService_GetPipelineResult_FullMethodName       = "/proto.PipelineService/GetPipelineResult"
+	PipelineService_ListPipelineTransitions_FullMethodName = "/proto.PipelineService/ListPipelineTransitions"
 )
 
 // PipelineServiceClient is the client API for PipelineService service.
@@ -35,6 +45,16 @@ type PipelineServiceClient interface {
 	StartPipeline(ctx context.Context, in *StartPipelineRequest, opts ...grpc.CallOption) (*StartPipelineResponse, error)
 	GetPipelineStatus(ctx context.Context, in *GetPipelineStatusRequest, opts ...grpc.CallOption) (*GetPipelineStatusResponse, error)
 	CancelPipeline(ctx context.Context, in *CancelPipelineRequest, opts ...grpc.CallOption) (*CancelPipelineResponse, error)
+	ReplayPipeline(ctx context.Context, in *ReplayPipelineRequest, opts ...grpc.CallOption) (*ReplayPipelineResponse, error)
+	GetBottlenecks(ctx context.Context, in *GetBottlenecksRequest, opts ...grpc.CallOption) (*GetBottlenecksResponse, error)
+	GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error)
+	ListPipelines(ctx context.Context, in *ListPipelinesRequest, opts ...grpc.CallOption) (*ListPipelinesResponse, error)
+	GetPipelineMetadata(ctx context.Context, in *GetPipelineMetadataRequest, opts ...grpc.CallOption) (*PipelineMetadata, error)
+	UpdatePipelineMetadata(ctx context.Context, in *UpdatePipelineMetadataRequest, opts ...grpc.CallOption) (*PipelineMetadata, error)
+	ListPipelineRuns(ctx context.Context, in *ListPipelineRunsRequest, opts ...grpc.CallOption) (*ListPipelineRunsResponse, error)
+	GetPipelineRun(ctx context.Context, in *GetPipelineRunRequest, opts ...grpc.CallOption) (*PipelineRun, error)
+	GetPipelineResult(ctx context.Context, in *GetPipelineResultRequest, opts ...grpc.CallOption) (*GetPipelineResultResponse, error)
+	ListPipelineTransitions(ctx context.Context, in *ListPipelineTransitionsRequest, opts ...grpc.CallOption) (*ListPipelineTransitionsResponse, error)
 }
 
 type pipelineServiceClient struct {
@@ -85,6 +105,106 @@ func (c *pipelineServiceClient) CancelPipeline(ctx context.Context, in *CancelPi
 	return out, nil
 }
 
+func (c *pipelineServiceClient) ReplayPipeline(ctx context.Context, in *ReplayPipelineRequest, opts ...grpc.CallOption) (*ReplayPipelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayPipelineResponse)
+	err := c.cc.Invoke(ctx, PipelineService_ReplayPipeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetBottlenecks(ctx context.Context, in *GetBottlenecksRequest, opts ...grpc.CallOption) (*GetBottlenecksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBottlenecksResponse)
+	err := c.cc.Invoke(ctx, PipelineService_GetBottlenecks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQueueResponse)
+	err := c.cc.Invoke(ctx, PipelineService_GetQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) ListPipelines(ctx context.Context, in *ListPipelinesRequest, opts ...grpc.CallOption) (*ListPipelinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPipelinesResponse)
+	err := c.cc.Invoke(ctx, PipelineService_ListPipelines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineMetadata(ctx context.Context, in *GetPipelineMetadataRequest, opts ...grpc.CallOption) (*PipelineMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineMetadata)
+	err := c.cc.Invoke(ctx, PipelineService_GetPipelineMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) UpdatePipelineMetadata(ctx context.Context, in *UpdatePipelineMetadataRequest, opts ...grpc.CallOption) (*PipelineMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineMetadata)
+	err := c.cc.Invoke(ctx, PipelineService_UpdatePipelineMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) ListPipelineRuns(ctx context.Context, in *ListPipelineRunsRequest, opts ...grpc.CallOption) (*ListPipelineRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPipelineRunsResponse)
+	err := c.cc.Invoke(ctx, PipelineService_ListPipelineRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineRun(ctx context.Context, in *GetPipelineRunRequest, opts ...grpc.CallOption) (*PipelineRun, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PipelineRun)
+	err := c.cc.Invoke(ctx, PipelineService_GetPipelineRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineResult(ctx context.Context, in *GetPipelineResultRequest, opts ...grpc.CallOption) (*GetPipelineResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPipelineResultResponse)
+	err := c.cc.Invoke(ctx, PipelineService_GetPipelineResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) ListPipelineTransitions(ctx context.Context, in *ListPipelineTransitionsRequest, opts ...grpc.CallOption) (*ListPipelineTransitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPipelineTransitionsResponse)
+	err := c.cc.Invoke(ctx, PipelineService_ListPipelineTransitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PipelineServiceServer is the server API for PipelineService service.
 // All implementations must embed UnimplementedPipelineServiceServer
 // for forward compatibility.
@@ -95,6 +215,16 @@ type PipelineServiceServer interface {
 	StartPipeline(context.Context, *StartPipelineRequest) (*StartPipelineResponse, error)
 	GetPipelineStatus(context.Context, *GetPipelineStatusRequest) (*GetPipelineStatusResponse, error)
 	CancelPipeline(context.Context, *CancelPipelineRequest) (*CancelPipelineResponse, error)
+	ReplayPipeline(context.Context, *ReplayPipelineRequest) (*ReplayPipelineResponse, error)
+	GetBottlenecks(context.Context, *GetBottlenecksRequest) (*GetBottlenecksResponse, error)
+	GetQueue(context.Context, *GetQueueRequest) (*GetQueueResponse, error)
+	ListPipelines(context.Context, *ListPipelinesRequest) (*ListPipelinesResponse, error)
+	GetPipelineMetadata(context.Context, *GetPipelineMetadataRequest) (*PipelineMetadata, error)
+	UpdatePipelineMetadata(context.Context, *UpdatePipelineMetadataRequest) (*PipelineMetadata, error)
+	ListPipelineRuns(context.Context, *ListPipelineRunsRequest) (*ListPipelineRunsResponse, error)
+	GetPipelineRun(context.Context, *GetPipelineRunRequest) (*PipelineRun, error)
+	GetPipelineResult(context.Context, *GetPipelineResultRequest) (*GetPipelineResultResponse, error)
+	ListPipelineTransitions(context.Context, *ListPipelineTransitionsRequest) (*ListPipelineTransitionsResponse, error)
 	mustEmbedUnimplementedPipelineServiceServer()
 }
 
@@ -117,6 +247,36 @@ func (UnimplementedPipelineServiceServer) GetPipelineStatus(context.Context, *Ge
 func (UnimplementedPipelineServiceServer) CancelPipeline(context.Context, *CancelPipelineRequest) (*CancelPipelineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelPipeline not implemented")
 }
+func (UnimplementedPipelineServiceServer) ReplayPipeline(context.Context, *ReplayPipelineRequest) (*ReplayPipelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayPipeline not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetBottlenecks(context.Context, *GetBottlenecksRequest) (*GetBottlenecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBottlenecks not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetQueue(context.Context, *GetQueueRequest) (*GetQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueue not implemented")
+}
+func (UnimplementedPipelineServiceServer) ListPipelines(context.Context, *ListPipelinesRequest) (*ListPipelinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPipelines not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetPipelineMetadata(context.Context, *GetPipelineMetadataRequest) (*PipelineMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineMetadata not implemented")
+}
+func (UnimplementedPipelineServiceServer) UpdatePipelineMetadata(context.Context, *UpdatePipelineMetadataRequest) (*PipelineMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePipelineMetadata not implemented")
+}
+func (UnimplementedPipelineServiceServer) ListPipelineRuns(context.Context, *ListPipelineRunsRequest) (*ListPipelineRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPipelineRuns not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetPipelineRun(context.Context, *GetPipelineRunRequest) (*PipelineRun, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineRun not implemented")
+}
+func (UnimplementedPipelineServiceServer) GetPipelineResult(context.Context, *GetPipelineResultRequest) (*GetPipelineResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineResult not implemented")
+}
+func (UnimplementedPipelineServiceServer) ListPipelineTransitions(context.Context, *ListPipelineTransitionsRequest) (*ListPipelineTransitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPipelineTransitions not implemented")
+}
 func (UnimplementedPipelineServiceServer) mustEmbedUnimplementedPipelineServiceServer() {}
 func (UnimplementedPipelineServiceServer) testEmbeddedByValue()                         {}
 
@@ -210,6 +370,186 @@ func _PipelineService_CancelPipeline_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PipelineService_ReplayPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayPipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ReplayPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_ReplayPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ReplayPipeline(ctx, req.(*ReplayPipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetBottlenecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBottlenecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetBottlenecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_GetBottlenecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetBottlenecks(ctx, req.(*GetBottlenecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_GetQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetQueue(ctx, req.(*GetQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_ListPipelines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPipelinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ListPipelines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_ListPipelines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ListPipelines(ctx, req.(*ListPipelinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_GetPipelineMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineMetadata(ctx, req.(*GetPipelineMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_UpdatePipelineMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePipelineMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).UpdatePipelineMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_UpdatePipelineMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).UpdatePipelineMetadata(ctx, req.(*UpdatePipelineMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_ListPipelineRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPipelineRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ListPipelineRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_ListPipelineRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ListPipelineRuns(ctx, req.(*ListPipelineRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_GetPipelineRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineRun(ctx, req.(*GetPipelineRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_GetPipelineResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineResult(ctx, req.(*GetPipelineResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_ListPipelineTransitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPipelineTransitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ListPipelineTransitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PipelineService_ListPipelineTransitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ListPipelineTransitions(ctx, req.(*ListPipelineTransitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PipelineService_ServiceDesc is the grpc.ServiceDesc for PipelineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,6 +573,46 @@ var PipelineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelPipeline",
 			Handler:    _PipelineService_CancelPipeline_Handler,
 		},
+		{
+			MethodName: "ReplayPipeline",
+			Handler:    _PipelineService_ReplayPipeline_Handler,
+		},
+		{
+			MethodName: "GetBottlenecks",
+			Handler:    _PipelineService_GetBottlenecks_Handler,
+		},
+		{
+			MethodName: "GetQueue",
+			Handler:    _PipelineService_GetQueue_Handler,
+		},
+		{
+			MethodName: "ListPipelines",
+			Handler:    _PipelineService_ListPipelines_Handler,
+		},
+		{
+			MethodName: "GetPipelineMetadata",
+			Handler:    _PipelineService_GetPipelineMetadata_Handler,
+		},
+		{
+			MethodName: "UpdatePipelineMetadata",
+			Handler:    _PipelineService_UpdatePipelineMetadata_Handler,
+		},
+		{
+			MethodName: "ListPipelineRuns",
+			Handler:    _PipelineService_ListPipelineRuns_Handler,
+		},
+		{
+			MethodName: "GetPipelineRun",
+			Handler:    _PipelineService_GetPipelineRun_Handler,
+		},
+		{
+			MethodName: "GetPipelineResult",
+			Handler:    _PipelineService_GetPipelineResult_Handler,
+		},
+		{
+			MethodName: "ListPipelineTransitions",
+			Handler:    _PipelineService_ListPipelineTransitions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/grpc/proto/pipeline/pipeline.proto",
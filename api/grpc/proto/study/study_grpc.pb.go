@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/grpc/proto/study/study.proto
+
+package study
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StudyService_RunStudy_FullMethodName         = "/study.StudyService/RunStudy"
+	StudyService_GetStudy_FullMethodName         = "/study.StudyService/GetStudy"
+	StudyService_CompareScenarios_FullMethodName = "/study.StudyService/CompareScenarios"
+)
+
+// StudyServiceClient is the client API for StudyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service Definition
+type StudyServiceClient interface {
+	RunStudy(ctx context.Context, in *RunStudyRequest, opts ...grpc.CallOption) (*StudyResponse, error)
+	GetStudy(ctx context.Context, in *GetStudyRequest, opts ...grpc.CallOption) (*StudyResponse, error)
+	CompareScenarios(ctx context.Context, in *CompareScenariosRequest, opts ...grpc.CallOption) (*CompareScenariosResponse, error)
+}
+
+type studyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStudyServiceClient(cc grpc.ClientConnInterface) StudyServiceClient {
+	return &studyServiceClient{cc}
+}
+
+func (c *studyServiceClient) RunStudy(ctx context.Context, in *RunStudyRequest, opts ...grpc.CallOption) (*StudyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StudyResponse)
+	err := c.cc.Invoke(ctx, StudyService_RunStudy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studyServiceClient) GetStudy(ctx context.Context, in *GetStudyRequest, opts ...grpc.CallOption) (*StudyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StudyResponse)
+	err := c.cc.Invoke(ctx, StudyService_GetStudy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studyServiceClient) CompareScenarios(ctx context.Context, in *CompareScenariosRequest, opts ...grpc.CallOption) (*CompareScenariosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareScenariosResponse)
+	err := c.cc.Invoke(ctx, StudyService_CompareScenarios_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StudyServiceServer is the server API for StudyService service.
+// All implementations must embed UnimplementedStudyServiceServer
+// for forward compatibility.
+//
+// Service Definition
+type StudyServiceServer interface {
+	RunStudy(context.Context, *RunStudyRequest) (*StudyResponse, error)
+	GetStudy(context.Context, *GetStudyRequest) (*StudyResponse, error)
+	CompareScenarios(context.Context, *CompareScenariosRequest) (*CompareScenariosResponse, error)
+	mustEmbedUnimplementedStudyServiceServer()
+}
+
+// UnimplementedStudyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStudyServiceServer struct{}
+
+func (UnimplementedStudyServiceServer) RunStudy(context.Context, *RunStudyRequest) (*StudyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunStudy not implemented")
+}
+func (UnimplementedStudyServiceServer) GetStudy(context.Context, *GetStudyRequest) (*StudyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStudy not implemented")
+}
+func (UnimplementedStudyServiceServer) CompareScenarios(context.Context, *CompareScenariosRequest) (*CompareScenariosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareScenarios not implemented")
+}
+func (UnimplementedStudyServiceServer) mustEmbedUnimplementedStudyServiceServer() {}
+func (UnimplementedStudyServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeStudyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StudyServiceServer will
+// result in compilation errors.
+type UnsafeStudyServiceServer interface {
+	mustEmbedUnimplementedStudyServiceServer()
+}
+
+func RegisterStudyServiceServer(s grpc.ServiceRegistrar, srv StudyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStudyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StudyService_ServiceDesc, srv)
+}
+
+func _StudyService_RunStudy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunStudyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudyServiceServer).RunStudy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudyService_RunStudy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudyServiceServer).RunStudy(ctx, req.(*RunStudyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudyService_GetStudy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStudyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudyServiceServer).GetStudy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudyService_GetStudy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudyServiceServer).GetStudy(ctx, req.(*GetStudyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudyService_CompareScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareScenariosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudyServiceServer).CompareScenarios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudyService_CompareScenarios_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudyServiceServer).CompareScenarios(ctx, req.(*CompareScenariosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StudyService_ServiceDesc is the grpc.ServiceDesc for StudyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StudyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "study.StudyService",
+	HandlerType: (*StudyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RunStudy",
+			Handler:    _StudyService_RunStudy_Handler,
+		},
+		{
+			MethodName: "GetStudy",
+			Handler:    _StudyService_GetStudy_Handler,
+		},
+		{
+			MethodName: "CompareScenarios",
+			Handler:    _StudyService_CompareScenarios_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/grpc/proto/study/study.proto",
+}
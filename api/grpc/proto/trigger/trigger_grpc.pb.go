@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/grpc/proto/trigger/trigger.proto
+
+package trigger
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TriggerService_CreateTrigger_FullMethodName  = "/trigger.TriggerService/CreateTrigger"
+	TriggerService_ListTriggers_FullMethodName   = "/trigger.TriggerService/ListTriggers"
+	TriggerService_GetTrigger_FullMethodName     = "/trigger.TriggerService/GetTrigger"
+	TriggerService_EnableTrigger_FullMethodName  = "/trigger.TriggerService/EnableTrigger"
+	TriggerService_DisableTrigger_FullMethodName = "/trigger.TriggerService/DisableTrigger"
+	TriggerService_DeleteTrigger_FullMethodName  = "/trigger.TriggerService/DeleteTrigger"
+)
+
+// TriggerServiceClient is the client API for TriggerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Service Definition
+type TriggerServiceClient interface {
+	CreateTrigger(ctx context.Context, in *CreateTriggerRequest, opts ...grpc.CallOption) (*Trigger, error)
+	ListTriggers(ctx context.Context, in *ListTriggersRequest, opts ...grpc.CallOption) (*ListTriggersResponse, error)
+	GetTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error)
+	EnableTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error)
+	DisableTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error)
+	DeleteTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*DeleteTriggerResponse, error)
+}
+
+type triggerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTriggerServiceClient(cc grpc.ClientConnInterface) TriggerServiceClient {
+	return &triggerServiceClient{cc}
+}
+
+func (c *triggerServiceClient) CreateTrigger(ctx context.Context, in *CreateTriggerRequest, opts ...grpc.CallOption) (*Trigger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trigger)
+	err := c.cc.Invoke(ctx, TriggerService_CreateTrigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerServiceClient) ListTriggers(ctx context.Context, in *ListTriggersRequest, opts ...grpc.CallOption) (*ListTriggersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTriggersResponse)
+	err := c.cc.Invoke(ctx, TriggerService_ListTriggers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerServiceClient) GetTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trigger)
+	err := c.cc.Invoke(ctx, TriggerService_GetTrigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerServiceClient) EnableTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trigger)
+	err := c.cc.Invoke(ctx, TriggerService_EnableTrigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerServiceClient) DisableTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*Trigger, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trigger)
+	err := c.cc.Invoke(ctx, TriggerService_DisableTrigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerServiceClient) DeleteTrigger(ctx context.Context, in *TriggerIdRequest, opts ...grpc.CallOption) (*DeleteTriggerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTriggerResponse)
+	err := c.cc.Invoke(ctx, TriggerService_DeleteTrigger_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TriggerServiceServer is the server API for TriggerService service.
+// All implementations must embed UnimplementedTriggerServiceServer
+// for forward compatibility.
+//
+// Service Definition
+type TriggerServiceServer interface {
+	CreateTrigger(context.Context, *CreateTriggerRequest) (*Trigger, error)
+	ListTriggers(context.Context, *ListTriggersRequest) (*ListTriggersResponse, error)
+	GetTrigger(context.Context, *TriggerIdRequest) (*Trigger, error)
+	EnableTrigger(context.Context, *TriggerIdRequest) (*Trigger, error)
+	DisableTrigger(context.Context, *TriggerIdRequest) (*Trigger, error)
+	DeleteTrigger(context.Context, *TriggerIdRequest) (*DeleteTriggerResponse, error)
+	mustEmbedUnimplementedTriggerServiceServer()
+}
+
+// UnimplementedTriggerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTriggerServiceServer struct{}
+
+func (UnimplementedTriggerServiceServer) CreateTrigger(context.Context, *CreateTriggerRequest) (*Trigger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrigger not implemented")
+}
+func (UnimplementedTriggerServiceServer) ListTriggers(context.Context, *ListTriggersRequest) (*ListTriggersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTriggers not implemented")
+}
+func (UnimplementedTriggerServiceServer) GetTrigger(context.Context, *TriggerIdRequest) (*Trigger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrigger not implemented")
+}
+func (UnimplementedTriggerServiceServer) EnableTrigger(context.Context, *TriggerIdRequest) (*Trigger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTrigger not implemented")
+}
+func (UnimplementedTriggerServiceServer) DisableTrigger(context.Context, *TriggerIdRequest) (*Trigger, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTrigger not implemented")
+}
+func (UnimplementedTriggerServiceServer) DeleteTrigger(context.Context, *TriggerIdRequest) (*DeleteTriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrigger not implemented")
+}
+func (UnimplementedTriggerServiceServer) mustEmbedUnimplementedTriggerServiceServer() {}
+func (UnimplementedTriggerServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeTriggerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TriggerServiceServer will
+// result in compilation errors.
+type UnsafeTriggerServiceServer interface {
+	mustEmbedUnimplementedTriggerServiceServer()
+}
+
+func RegisterTriggerServiceServer(s grpc.ServiceRegistrar, srv TriggerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTriggerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TriggerService_ServiceDesc, srv)
+}
+
+func _TriggerService_CreateTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).CreateTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_CreateTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).CreateTrigger(ctx, req.(*CreateTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerService_ListTriggers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTriggersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).ListTriggers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_ListTriggers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).ListTriggers(ctx, req.(*ListTriggersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerService_GetTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).GetTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_GetTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).GetTrigger(ctx, req.(*TriggerIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerService_EnableTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).EnableTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_EnableTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).EnableTrigger(ctx, req.(*TriggerIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerService_DisableTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).DisableTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_DisableTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).DisableTrigger(ctx, req.(*TriggerIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerService_DeleteTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerServiceServer).DeleteTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TriggerService_DeleteTrigger_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerServiceServer).DeleteTrigger(ctx, req.(*TriggerIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TriggerService_ServiceDesc is the grpc.ServiceDesc for TriggerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TriggerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trigger.TriggerService",
+	HandlerType: (*TriggerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTrigger",
+			Handler:    _TriggerService_CreateTrigger_Handler,
+		},
+		{
+			MethodName: "ListTriggers",
+			Handler:    _TriggerService_ListTriggers_Handler,
+		},
+		{
+			MethodName: "GetTrigger",
+			Handler:    _TriggerService_GetTrigger_Handler,
+		},
+		{
+			MethodName: "EnableTrigger",
+			Handler:    _TriggerService_EnableTrigger_Handler,
+		},
+		{
+			MethodName: "DisableTrigger",
+			Handler:    _TriggerService_DisableTrigger_Handler,
+		},
+		{
+			MethodName: "DeleteTrigger",
+			Handler:    _TriggerService_DeleteTrigger_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/grpc/proto/trigger/trigger.proto",
+}
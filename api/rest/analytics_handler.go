@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type AnalyticsHandler struct {
+	Service *services.AnalyticsService
+}
+
+// analyticsRequest reads the user_id, from and to query parameters; every
+// run is aggregated when user_id is unset
+func analyticsRequest(c *gin.Context) (services.AnalyticsRequest, error) {
+	req := services.AnalyticsRequest{UserID: c.Query("user_id")}
+	var err error
+	if req.From, err = queryTime(c, "from"); err != nil {
+		return req, err
+	}
+	req.To, err = queryTime(c, "to")
+	return req, err
+}
+
+// respondAnalytics writes an aggregate, or a 400 for an invalid query
+func respondAnalytics(c *gin.Context, report interface{}, err error) {
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAnalyticsQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute analytics"})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// GetRunsPerDay counts the runs queued per day and status
+func (h *AnalyticsHandler) GetRunsPerDay(c *gin.Context) {
+	req, err := analyticsRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.Service.GetRunsPerDay(req)
+	respondAnalytics(c, report, err)
+}
+
+// GetRunOutcomes returns the success, failure and cancel rates of runs
+func (h *AnalyticsHandler) GetRunOutcomes(c *gin.Context) {
+	req, err := analyticsRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.Service.GetRunOutcomes(req)
+	respondAnalytics(c, report, err)
+}
+
+// GetDurations returns the mean and p95 durations per pipeline mode and stage
+func (h *AnalyticsHandler) GetDurations(c *gin.Context) {
+	req, err := analyticsRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	report, err := h.Service.GetDurations(req)
+	respondAnalytics(c, report, err)
+}
+
+// GetTopErrors returns the most frequent error messages of failed runs
+func (h *AnalyticsHandler) GetTopErrors(c *gin.Context) {
+	req, err := analyticsRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+	report, err := h.Service.GetTopErrors(req, limit)
+	respondAnalytics(c, report, err)
+}
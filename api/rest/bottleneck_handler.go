@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type BottleneckHandler struct {
+	Service *services.BottleneckService
+}
+
+// GetBottlenecks ranks the stages of a pipeline by how much they limit
+// throughput, using its last run or the study given by ?study_id
+func (h *BottleneckHandler) GetBottlenecks(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	var studyID *uuid.UUID
+	if raw := c.Query("study_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study ID"})
+			return
+		}
+		studyID = &id
+	}
+
+	report, err := h.Service.GetBottlenecks(pipelineID, studyID)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type CalendarHandler struct {
+	Service *services.CalendarService
+}
+
+// CalendarRequest carries a calendar's name and definition (timezone, shifts,
+// breaks, holidays and downtime)
+type CalendarRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+	domain.Calendar
+}
+
+type AttachCalendarRequest struct {
+	CalendarID *uuid.UUID `json:"calendar_id"` // null detaches the current calendar
+}
+
+// CreateCalendar stores a new shift calendar
+func (h *CalendarHandler) CreateCalendar(c *gin.Context) {
+	var req CalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	calendar, err := h.Service.CreateCalendar(req.UserID, req.Name, req.Calendar)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, calendar)
+}
+
+// UpdateCalendar replaces a calendar's name and definition
+func (h *CalendarHandler) UpdateCalendar(c *gin.Context) {
+	calendarID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	var req CalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	calendar, err := h.Service.UpdateCalendar(calendarID, req.Name, req.Calendar)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// DeleteCalendar removes a calendar
+func (h *CalendarHandler) DeleteCalendar(c *gin.Context) {
+	calendarID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	if err := h.Service.DeleteCalendar(calendarID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar deleted successfully"})
+}
+
+// GetCalendar fetches a calendar
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	calendarID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	calendar, err := h.Service.GetCalendar(calendarID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// GetUserCalendars lists the calendars of a user
+func (h *CalendarHandler) GetUserCalendars(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	calendars, err := h.Service.GetCalendarsByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch calendars"})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendars)
+}
+
+// GetWorkingWindows previews the working time of a calendar between ?from and
+// ?to (RFC 3339, defaulting to the next 7 days)
+func (h *CalendarHandler) GetWorkingWindows(c *gin.Context) {
+	calendarID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar ID"})
+		return
+	}
+
+	from := time.Now()
+	if raw := c.Query("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from time"})
+			return
+		}
+	}
+	to := from.Add(7 * 24 * time.Hour)
+	if raw := c.Query("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to time"})
+			return
+		}
+	}
+
+	windows, err := h.Service.GetWorkingWindows(calendarID, from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+// AttachPipelineCalendar sets or clears the calendar of a pipeline
+func (h *CalendarHandler) AttachPipelineCalendar(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	var req AttachCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.Service.AttachToPipeline(pipelineID, req.CalendarID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pipeline calendar updated", "calendar_id": req.CalendarID})
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+// IdempotencyKeyHeader carries the client's idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// responseRecorder keeps a copy of the response body next to writing it
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response to a request repeated with the same
+// Idempotency-Key header by the same user, and answers 409 when the key comes
+// with a different request. Successful responses are stored; after a failure
+// the key is free for a retry. Requests without the header pass through. It
+// runs after the auth middleware, which sets the user.
+func Idempotency(service *services.IdempotencyService, operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		scope := services.IdempotencyScope(operation, c.GetString("user_id"))
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := services.RequestFingerprint(c.Request.Method+" "+c.Request.URL.Path, body)
+		stored, err := service.Begin(scope, key, fingerprint)
+		switch {
+		case errors.Is(err, services.ErrInvalidIdempotencyKey):
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, services.ErrIdempotencyMismatch), errors.Is(err, services.ErrIdempotencyInProgress):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check idempotency key"})
+			return
+		case stored != nil:
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(stored.StatusCode, "application/json; charset=utf-8", stored.Body)
+			c.Abort()
+			return
+		}
+
+		// The key is also released if the handler panics
+		defer func() {
+			if stored == nil {
+				service.Release(scope, key)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if status := recorder.Status(); status >= 200 && status < 300 {
+			stored = &services.StoredResponse{StatusCode: status, Body: recorder.body.Bytes()}
+			service.Complete(scope, key, stored.StatusCode, stored.Body)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// Metrics counts and times every request by its route pattern, so
+// /pipelines/:id is one series however many pipelines there are. Requests no
+// route matched are recorded under "unmatched"; SSE streams are counted but
+// not timed, as they last as long as the client stays.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		utils.HTTPRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		if !strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "text/event-stream") {
+			utils.HTTPRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+		}
+	}
+}
+
+// MetricsHandler serves the metrics in the Prometheus text format
+func MetricsHandler() gin.HandlerFunc {
+	return gin.WrapH(utils.MetricsHandler())
+}
@@ -1,12 +1,18 @@
 package rest
 
 import (
-	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
 )
@@ -20,6 +26,9 @@ type CreatePipelineRequest struct {
 	Stages     int  `json:"stages"`
 	IsParallel bool `json:"is_parallel"`
 	UserID     uuid.UUID `json:"user_id"` // Extracted from the request
+	StageSpecs []domain.StageSpec `json:"stage_specs"` // Optional: simulated timing and yield per stage
+	Labels      map[string]string `json:"labels"`      // Optional: key/value tags to select the pipeline by
+	Annotations map[string]string `json:"annotations"` // Optional: free-form notes
 }
 
 // CreatePipeline handles pipeline creation
@@ -35,8 +44,13 @@ func (h *PipelineHandler) CreatePipeline(c *gin.Context) {
 		return
 	}
 
-	pipelineID, err := h.Service.CreatePipeline(req.UserID, req.Stages, req.IsParallel)
+	meta := domain.PipelineMetadata{Labels: req.Labels, Annotations: req.Annotations}
+	pipelineID, err := h.Service.CreatePipeline(req.UserID, req.Stages, req.IsParallel, req.StageSpecs, meta)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create pipeline"})
 		return
 	}
@@ -50,6 +64,9 @@ type StartPipelineRequest struct {
 	Input      interface{} `json:"input"`
 	IsParallel bool        `json:"is_parallel"`
 	UserID     uuid.UUID   `json:"user_id"`
+	Seed       *int64      `json:"seed"` // Optional: random seed, generated when omitted
+	Priority   int         `json:"priority"` // Optional: higher runs first under the priority rule
+	DueDate    *time.Time  `json:"due_date"` // Optional: used by the earliest-due-date rule
 }
 
 // StartPipeline handles pipeline execution
@@ -71,14 +88,45 @@ func (h *PipelineHandler) StartPipeline(c *gin.Context) {
 		return
 	}
 
-	go func() {
-		h.Service.StartPipeline(context.Background(), req.UserID, pipelineID, req.Input, req.IsParallel)
-
-		// 🔹 Notify clients about execution start
-		h.SSE.BroadcastUpdate("Pipeline " + pipelineID.String() + " has started execution.")
-	}()
+	seed := services.ResolveSeed(req.Seed)
+
+	// The pipeline waits in the work-order queue until a run slot is free
+	order := &domain.WorkOrder{
+		PipelineID: pipelineID,
+		UserID:     req.UserID,
+		IsParallel: req.IsParallel,
+		Input:      req.Input,
+		Seed:       seed,
+		Priority:   req.Priority,
+		DueDate:    req.DueDate,
+		OnDone: func(err error) {
+			// 🔹 Notify clients once the run is over
+			if errors.Is(err, services.ErrPipelineCancelled) {
+				h.SSE.BroadcastUpdate("Pipeline " + pipelineID.String() + " has been cancelled.")
+				return
+			}
+			if err != nil {
+				h.SSE.BroadcastUpdate("Pipeline " + pipelineID.String() + " has failed: " + err.Error())
+				return
+			}
+			h.SSE.BroadcastUpdate("Pipeline " + pipelineID.String() + " has finished execution.")
+		},
+	}
+	position, err := h.Service.EnqueuePipeline(order)
+	switch {
+	case errors.Is(err, services.ErrPipelineNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrStatusConflict), errors.Is(err, services.ErrLeaseHeld):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		log.Printf("Error queueing pipeline %s: %v", pipelineID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue pipeline"})
+		return
+	}
 
-	c.JSON(http.StatusAccepted, gin.H{"message": "Pipeline execution started", "pipeline_id": pipelineID})
+	c.JSON(http.StatusAccepted, gin.H{"message": "Pipeline queued for execution", "pipeline_id": pipelineID, "run_id": order.RunID, "seed": seed, "position": position})
 }
 
 type GetPipelineStatusRequest struct {
@@ -141,6 +189,14 @@ func (h *PipelineHandler) CancelPipeline(c *gin.Context) {
 	}
 
 	err = h.Service.CancelPipeline(pipelineID, req.UserID, req.IsParallel)
+	if errors.Is(err, services.ErrCancelForwarded) || errors.Is(err, services.ErrCancelRequested) {
+		c.JSON(http.StatusAccepted, gin.H{"message": err.Error(), "pipeline_id": pipelineID})
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrStatusConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		log.Printf("Error cancelling pipeline: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel pipeline"})
@@ -153,6 +209,12 @@ func (h *PipelineHandler) CancelPipeline(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Pipeline cancelled", "pipeline_id": pipelineID})
 }
 
+// NextCursorHeader carries the cursor of the next page of a listing; it is
+// absent on the last page
+const NextCursorHeader = "X-Next-Cursor"
+
+// GetUserPipelines lists a page of the pipelines of a user, filtered and sorted
+// by the query parameters
 func (h *PipelineHandler) GetUserPipelines(c *gin.Context) {
 	userID := c.Query("user_id") // Fetch user_id from query parameters
 	if userID == "" {
@@ -160,16 +222,129 @@ func (h *PipelineHandler) GetUserPipelines(c *gin.Context) {
 		return
 	}
 
-	pipelines, err := h.Service.GetPipelinesByUser(userID)
+	req := services.PipelineListRequest{
+		UserID: userID,
+		Mode:     c.Query("mode"),
+		Selector: c.Query("selector"),
+		SortBy:   c.Query("sort"),
+		Order:    c.Query("order"),
+		Cursor:   c.Query("cursor"),
+	}
+	for _, value := range c.QueryArray("status") {
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				req.Statuses = append(req.Statuses, s)
+			}
+		}
+	}
+	var err error
+	if req.CreatedAfter, err = queryTime(c, "created_after"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.CreatedBefore, err = queryTime(c, "created_before"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	page, err := h.Service.ListPipelines(req)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidListQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pipelines"})
 		return
 	}
 
-	c.JSON(http.StatusOK, pipelines)
+	if page.NextCursor != "" {
+		c.Header(NextCursorHeader, page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Pipelines)
+}
+
+// UpdatePipelineMetadataRequest patches labels and annotations: a key with a
+// string value is set, a key with null is removed, and other keys are kept
+type UpdatePipelineMetadataRequest struct {
+	Labels      map[string]*string `json:"labels"`
+	Annotations map[string]*string `json:"annotations"`
+}
+
+// GetPipelineMetadata returns the labels and annotations of a pipeline
+func (h *PipelineHandler) GetPipelineMetadata(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	meta, err := h.Service.GetPipelineMetadata(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, meta)
+}
+
+// UpdatePipelineMetadata sets and removes labels and annotations of a pipeline
+func (h *PipelineHandler) UpdatePipelineMetadata(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+	var req UpdatePipelineMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	meta, err := h.Service.UpdatePipelineMetadata(pipelineID, metadataPatch(req.Labels), metadataPatch(req.Annotations))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, meta)
+}
+
+func metadataPatch(values map[string]*string) ports.MetadataPatch {
+	patch := ports.MetadataPatch{Set: map[string]string{}}
+	for key, value := range values {
+		if value == nil {
+			patch.Remove = append(patch.Remove, key)
+		} else {
+			patch.Set[key] = *value
+		}
+	}
+	return patch
+}
+
+// queryTime parses an optional RFC 3339 query parameter
+func queryTime(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s, expected RFC 3339: %v", name, err)
+	}
+	return &t, nil
 }
 
-// GetPipelineStages fetches the stages of a pipeline
+// GetPipelineStages fetches the stage attempts of the latest run of a pipeline
 func (h *PipelineHandler) GetPipelineStages(c *gin.Context) {
 	pipelineID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -177,12 +352,135 @@ func (h *PipelineHandler) GetPipelineStages(c *gin.Context) {
 		return
 	}
 
-	stages, err := h.Service.GetPipelineStages(pipelineID)
+	history, err := h.Service.GetPipelineStages(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetPipelineRuns lists the runs of a pipeline, latest first
+func (h *PipelineHandler) GetPipelineRuns(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	runs, err := h.Service.GetPipelineRuns(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// GetPipelineTransitions returns the status history of a pipeline and its runs
+func (h *PipelineHandler) GetPipelineTransitions(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	transitions, err := h.Service.GetPipelineTransitions(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+// GetPipelineRun returns one run of a pipeline with its stage logs
+func (h *PipelineHandler) GetPipelineRun(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+	runID, err := uuid.Parse(c.Param("run_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run ID"})
+		return
+	}
+
+	run, err := h.Service.GetPipelineRun(pipelineID, runID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// GetPipelineResult returns the input and output of the latest run of a pipeline
+func (h *PipelineHandler) GetPipelineResult(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	result, err := h.Service.GetPipelineResult(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type ReplayPipelineRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// ReplayPipeline re-executes a past run with the same seed and configuration
+// and returns both event sequences with their differences
+func (h *PipelineHandler) ReplayPipeline(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	var req ReplayPipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	result, err := h.Service.ReplayPipeline(c.Request.Context(), req.UserID, pipelineID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pipeline stages"})
+		log.Printf("Error replaying pipeline: %v", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, stages)
+	c.JSON(http.StatusOK, result)
 }
 
+// GetPipelineEvents returns the recorded event sequence of a pipeline execution
+func (h *PipelineHandler) GetPipelineEvents(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	events, err := h.Service.GetExecutionEvents(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch pipeline events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
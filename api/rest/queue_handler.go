@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type QueueHandler struct {
+	Service *services.PipelineService
+	Users   *services.AuthService
+}
+
+type SetDispatchRuleRequest struct {
+	Rule string `json:"rule"` // fifo, edd, spt or priority
+}
+
+type UpdateWorkOrderRequest struct {
+	Priority *int       `json:"priority"`
+	DueDate  *time.Time `json:"due_date"`
+	Position *int       `json:"position"` // 1-based; moves the order explicitly
+}
+
+// caller returns the authenticated user and whether they are an operator,
+// answering the request itself when it cannot tell
+func (h *QueueHandler) caller(c *gin.Context) (uuid.UUID, bool, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unknown user"})
+		return uuid.Nil, false, false
+	}
+	operator, err := h.Users.IsOperator(userID)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "User not found"})
+		return uuid.Nil, false, false
+	}
+	return userID, operator, true
+}
+
+// GetQueue returns the work-order queue in dispatch order; operators see
+// every order, other users only their own
+func (h *QueueHandler) GetQueue(c *gin.Context) {
+	userID, operator, ok := h.caller(c)
+	if !ok {
+		return
+	}
+	if operator {
+		c.JSON(http.StatusOK, h.Service.GetQueue())
+		return
+	}
+	c.JSON(http.StatusOK, h.Service.GetUserQueue(userID))
+}
+
+// SetDispatchRule switches the rule that orders the queue; it applies to
+// every user, so only operators may change it
+func (h *QueueHandler) SetDispatchRule(c *gin.Context) {
+	_, operator, ok := h.caller(c)
+	if !ok {
+		return
+	}
+	if !operator {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only operators can change the dispatch rule"})
+		return
+	}
+
+	var req SetDispatchRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.Service.SetDispatchRule(req.Rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.Service.GetQueue())
+}
+
+// UpdateWorkOrder reprioritizes or moves a queued pipeline of the caller;
+// operators can change any order
+func (h *QueueHandler) UpdateWorkOrder(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+	userID, operator, ok := h.caller(c)
+	if !ok {
+		return
+	}
+	if !operator {
+		owned := false
+		for _, order := range h.Service.GetUserQueue(userID).Orders {
+			owned = owned || order.PipelineID == pipelineID
+		}
+		if !owned {
+			c.JSON(http.StatusNotFound, gin.H{"error": "pipeline is not queued"})
+			return
+		}
+	}
+
+	var req UpdateWorkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	order, err := h.Service.UpdateWorkOrder(pipelineID, services.WorkOrderUpdate{
+		Priority: req.Priority,
+		DueDate:  req.DueDate,
+		Position: req.Position,
+	})
+	if errors.Is(err, services.ErrLeaseHeld) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type StudyHandler struct {
+	Service *services.StudyService
+}
+
+type RunStudyRequest struct {
+	UserID       uuid.UUID          `json:"user_id"`
+	PipelineID   *uuid.UUID         `json:"pipeline_id"`
+	Name         string             `json:"name"`
+	Stages       int                `json:"stages"`
+	IsParallel   bool               `json:"is_parallel"`
+	StageSpecs   []domain.StageSpec `json:"stage_specs"`
+	Replications int                `json:"replications"`
+	Units        int                `json:"units"`
+	Seed         *int64             `json:"seed"`
+}
+
+// RunStudy runs a Monte Carlo study and returns its aggregated results
+func (h *StudyHandler) RunStudy(c *gin.Context) {
+	var req RunStudyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	study, err := h.Service.RunStudy(c.Request.Context(), services.StudyRequest{
+		UserID:       req.UserID,
+		PipelineID:   req.PipelineID,
+		Name:         req.Name,
+		Definition:   domain.PipelineDefinition{IsParallel: req.IsParallel, Stages: req.StageSpecs},
+		StageCount:   req.Stages,
+		Replications: req.Replications,
+		Units:        req.Units,
+		Seed:         req.Seed,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, study)
+}
+
+// GetStudy fetches a study with its aggregated and per-replication results
+func (h *StudyHandler) GetStudy(c *gin.Context) {
+	studyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study ID"})
+		return
+	}
+
+	study, err := h.Service.GetStudy(studyID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Study not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, study)
+}
+
+// GetUserStudies lists the studies of a user
+func (h *StudyHandler) GetUserStudies(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	studies, err := h.Service.GetStudiesByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch studies"})
+		return
+	}
+
+	c.JSON(http.StatusOK, studies)
+}
+
+type CompareScenarioRequest struct {
+	Name       string             `json:"name"`
+	StudyID    *uuid.UUID         `json:"study_id"`
+	PipelineID *uuid.UUID         `json:"pipeline_id"`
+	Stages     int                `json:"stages"`
+	IsParallel bool               `json:"is_parallel"`
+	StageSpecs []domain.StageSpec `json:"stage_specs"`
+}
+
+type CompareScenariosRequest struct {
+	UserID       uuid.UUID                `json:"user_id"`
+	Scenarios    []CompareScenarioRequest `json:"scenarios"`
+	Replications int                      `json:"replications"`
+	Units        int                      `json:"units"`
+	Seed         *int64                   `json:"seed"`
+}
+
+// CompareScenarios compares the KPIs of two or more scenarios against the first one
+func (h *StudyHandler) CompareScenarios(c *gin.Context) {
+	var req CompareScenariosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
+		return
+	}
+
+	compareReq := services.CompareRequest{
+		UserID:       req.UserID,
+		Replications: req.Replications,
+		Units:        req.Units,
+		Seed:         req.Seed,
+	}
+	for _, sc := range req.Scenarios {
+		compareReq.Scenarios = append(compareReq.Scenarios, services.ScenarioInput{
+			Name:       sc.Name,
+			StudyID:    sc.StudyID,
+			PipelineID: sc.PipelineID,
+			Definition: domain.PipelineDefinition{IsParallel: sc.IsParallel, Stages: sc.StageSpecs},
+			StageCount: sc.Stages,
+		})
+	}
+
+	comparison, err := h.Service.CompareScenarios(c.Request.Context(), compareReq)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
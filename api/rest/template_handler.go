@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type TemplateHandler struct {
+	Service *services.TemplateService
+}
+
+// TemplateRequest carries a template version's name, description and
+// definition (mode and stage specs)
+type TemplateRequest struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Publish     bool      `json:"publish"` // Publish right away instead of creating a draft
+	domain.PipelineDefinition
+}
+
+// InstantiateTemplateRequest creates a pipeline from a template with parameter overrides
+type InstantiateTemplateRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	domain.TemplateOverrides
+}
+
+// CreateTemplate stores the next version of a template
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	template, err := h.Service.CreateTemplate(req.UserID, req.Name, req.Description, req.PipelineDefinition, req.Publish)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// ListTemplates lists all template versions, or those of ?name
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.Service.ListTemplates(c.Query("name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetTemplate fetches name@version, or the latest published version of name
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	template, err := h.Service.GetTemplate(c.Param("ref"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate replaces the description and definition of a draft
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	template, err := h.Service.UpdateTemplate(c.Param("ref"), req.Description, req.PipelineDefinition)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// PublishTemplate makes a draft immutable and available for instantiation
+func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
+	template, err := h.Service.PublishTemplate(c.Param("ref"))
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeprecateTemplate hides a published version from name@latest
+func (h *TemplateHandler) DeprecateTemplate(c *gin.Context) {
+	template, err := h.Service.DeprecateTemplate(c.Param("ref"))
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// InstantiateTemplate creates a pipeline from a template version
+func (h *TemplateHandler) InstantiateTemplate(c *gin.Context) {
+	var req InstantiateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	instantiation, err := h.Service.InstantiateTemplate(req.UserID, c.Param("ref"), req.TemplateOverrides)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, instantiation)
+}
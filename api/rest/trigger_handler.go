@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+)
+
+type TriggerHandler struct {
+	Service *services.TriggerService
+}
+
+// TriggerRequest carries the target pipeline, the event and the input of a trigger
+type TriggerRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	domain.TriggerSpec
+}
+
+// CreateTrigger makes the runs of a pipeline start another pipeline when they end
+func (h *TriggerHandler) CreateTrigger(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	var req TriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	trigger, err := h.Service.CreateTrigger(req.UserID, pipelineID, req.TriggerSpec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, trigger)
+}
+
+// GetPipelineTriggers lists the triggers from and to a pipeline
+func (h *TriggerHandler) GetPipelineTriggers(c *gin.Context) {
+	pipelineID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pipeline ID"})
+		return
+	}
+
+	triggers, err := h.Service.ListTriggers(pipelineID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch triggers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, triggers)
+}
+
+// GetTrigger fetches a trigger with its last fire
+func (h *TriggerHandler) GetTrigger(c *gin.Context) {
+	triggerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trigger ID"})
+		return
+	}
+
+	trigger, err := h.Service.GetTrigger(triggerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trigger)
+}
+
+// EnableTrigger lets a trigger fire again
+func (h *TriggerHandler) EnableTrigger(c *gin.Context) {
+	h.setEnabled(c, true)
+}
+
+// DisableTrigger stops a trigger from firing
+func (h *TriggerHandler) DisableTrigger(c *gin.Context) {
+	h.setEnabled(c, false)
+}
+
+func (h *TriggerHandler) setEnabled(c *gin.Context, enabled bool) {
+	triggerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trigger ID"})
+		return
+	}
+
+	trigger, err := h.Service.SetTriggerEnabled(triggerID, enabled)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trigger)
+}
+
+// DeleteTrigger removes a trigger
+func (h *TriggerHandler) DeleteTrigger(c *gin.Context) {
+	triggerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid trigger ID"})
+		return
+	}
+
+	if err := h.Service.DeleteTrigger(triggerID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Trigger deleted"})
+}
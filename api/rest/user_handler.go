@@ -47,6 +47,19 @@ func (h *UserHandler) UpdateUserProfile(c *gin.Context) {
 		return
 	}
 
+	// Roles grant operator rights, so only operators may change them
+	if _, ok := updateData["role"]; ok {
+		callerID, err := uuid.Parse(c.GetString("user_id"))
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only operators can change roles"})
+			return
+		}
+		if operator, err := h.Service.IsOperator(callerID); err != nil || !operator {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only operators can change roles"})
+			return
+		}
+	}
+
 	if err := h.Service.UpdateUser(userID, updateData); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
 		return
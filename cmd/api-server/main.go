@@ -2,21 +2,25 @@ package main
 
 import (
 	"log"
+	"net"
 	"net/http"
+	"os"
 	"sync"
 	// "path/filepath"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/rest"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/cmd/middleware"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/adapters/primary"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/adapters/secondary"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
 
 	"github.com/gin-contrib/cors"
 )
 
-func startRESTServer(authService *services.AuthService, pipelineService *services.PipelineService, sseManager *utils.SSEManager, wg *sync.WaitGroup) {
+func startRESTServer(authService *services.AuthService, pipelineService *services.PipelineService, studyService *services.StudyService, bottleneckService *services.BottleneckService, calendarService *services.CalendarService, templateService *services.TemplateService, scheduleService *services.ScheduleService, triggerService *services.TriggerService, idempotencyService *services.IdempotencyService, analyticsService *services.AnalyticsService, sseManager *utils.SSEManager, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	authMiddleware := middleware.AuthMiddleware()
@@ -25,6 +29,14 @@ func startRESTServer(authService *services.AuthService, pipelineService *service
 	handler := &rest.PipelineHandler{Service: pipelineService, SSE: sseManager}
 	authHandler := &rest.AuthHandler{Service: authService}
 	userHandler := &rest.UserHandler{Service: authService}
+	studyHandler := &rest.StudyHandler{Service: studyService}
+	bottleneckHandler := &rest.BottleneckHandler{Service: bottleneckService}
+	calendarHandler := &rest.CalendarHandler{Service: calendarService}
+	queueHandler := &rest.QueueHandler{Service: pipelineService, Users: authService}
+	templateHandler := &rest.TemplateHandler{Service: templateService}
+	scheduleHandler := &rest.ScheduleHandler{Service: scheduleService}
+	triggerHandler := &rest.TriggerHandler{Service: triggerService}
+	analyticsHandler := &rest.AnalyticsHandler{Service: analyticsService}
 
 	// Setup Gin router
 	r := gin.Default()
@@ -32,10 +44,15 @@ func startRESTServer(authService *services.AuthService, pipelineService *service
 		// AllowOrigins:     []string{"http://localhost:3000"},
 		// AllowOrigins:     []string{"http://localhost:30080", "http://localhost:3000"},
 		AllowOrigins:     []string{"https://myapp.local:30080", "https://myapp.local:3000", "https://myapp.local:30081","https://172.29.20.150:30080","https://172.29.20.150:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", rest.IdempotencyKeyHeader},
+		ExposeHeaders:    []string{rest.NextCursorHeader},
 		AllowCredentials: true,
 	}))
+	r.Use(rest.Metrics())
+
+	// Prometheus scrapes this without credentials; it covers the gRPC server of this process too
+	r.GET("/metrics", rest.MetricsHandler())
 
 	// Public routes
 	r.POST("/register", gin.WrapF(authHandler.RegisterHandler))
@@ -48,10 +65,73 @@ func startRESTServer(authService *services.AuthService, pipelineService *service
 	r.GET("/pipelines", authMiddleware, handler.GetUserPipelines)
 	r.GET("/pipelines/:id/stages", authMiddleware, handler.GetPipelineStages)
 
-	r.POST("/createpipelines", authMiddleware, handler.CreatePipeline)
-	r.POST("/pipelines/:id/start", authMiddleware, handler.StartPipeline)
+	// Retries with the same Idempotency-Key get the first response back
+	r.POST("/createpipelines", authMiddleware, rest.Idempotency(idempotencyService, "create_pipeline"), handler.CreatePipeline)
+	r.POST("/pipelines/:id/start", authMiddleware, rest.Idempotency(idempotencyService, "start_pipeline"), handler.StartPipeline)
 	r.GET("/pipelines/:id/status", authMiddleware, handler.GetPipelineStatus)
 	r.POST("/pipelines/:id/cancel", authMiddleware, handler.CancelPipeline)
+	r.POST("/pipelines/:id/replay", authMiddleware, handler.ReplayPipeline)
+	r.GET("/pipelines/:id/events", authMiddleware, handler.GetPipelineEvents)
+	r.GET("/pipelines/:id/runs", authMiddleware, handler.GetPipelineRuns)
+	r.GET("/pipelines/:id/runs/:run_id", authMiddleware, handler.GetPipelineRun)
+	r.GET("/pipelines/:id/result", authMiddleware, handler.GetPipelineResult)
+	r.GET("/pipelines/:id/transitions", authMiddleware, handler.GetPipelineTransitions)
+	r.GET("/pipelines/:id/metadata", authMiddleware, handler.GetPipelineMetadata)
+	r.PATCH("/pipelines/:id/metadata", authMiddleware, handler.UpdatePipelineMetadata)
+	r.GET("/pipelines/:id/bottlenecks", authMiddleware, bottleneckHandler.GetBottlenecks)
+	r.PUT("/pipelines/:id/calendar", authMiddleware, calendarHandler.AttachPipelineCalendar)
+	r.POST("/pipelines/:id/schedules", authMiddleware, scheduleHandler.CreateSchedule)
+	r.GET("/pipelines/:id/schedules", authMiddleware, scheduleHandler.GetPipelineSchedules)
+	r.POST("/pipelines/:id/triggers", authMiddleware, triggerHandler.CreateTrigger)
+	r.GET("/pipelines/:id/triggers", authMiddleware, triggerHandler.GetPipelineTriggers)
+
+	// Monte Carlo studies
+	r.POST("/studies", authMiddleware, studyHandler.RunStudy)
+	r.POST("/studies/compare", authMiddleware, studyHandler.CompareScenarios)
+	r.GET("/studies", authMiddleware, studyHandler.GetUserStudies)
+	r.GET("/studies/:id", authMiddleware, studyHandler.GetStudy)
+
+	// Work-order queue
+	r.GET("/queue", authMiddleware, queueHandler.GetQueue)
+	r.PUT("/queue/rule", authMiddleware, queueHandler.SetDispatchRule)
+	r.PUT("/queue/:id", authMiddleware, queueHandler.UpdateWorkOrder)
+
+	// Shift calendars
+	r.POST("/calendars", authMiddleware, calendarHandler.CreateCalendar)
+	r.GET("/calendars", authMiddleware, calendarHandler.GetUserCalendars)
+	r.GET("/calendars/:id", authMiddleware, calendarHandler.GetCalendar)
+	r.PUT("/calendars/:id", authMiddleware, calendarHandler.UpdateCalendar)
+	r.DELETE("/calendars/:id", authMiddleware, calendarHandler.DeleteCalendar)
+	r.GET("/calendars/:id/windows", authMiddleware, calendarHandler.GetWorkingWindows)
+
+	// Pipeline templates, addressed as name@version or name for the latest published version
+	r.POST("/templates", authMiddleware, templateHandler.CreateTemplate)
+	r.GET("/templates", authMiddleware, templateHandler.ListTemplates)
+	r.GET("/templates/:ref", authMiddleware, templateHandler.GetTemplate)
+	r.PUT("/templates/:ref", authMiddleware, templateHandler.UpdateTemplate)
+	r.POST("/templates/:ref/publish", authMiddleware, templateHandler.PublishTemplate)
+	r.POST("/templates/:ref/deprecate", authMiddleware, templateHandler.DeprecateTemplate)
+	r.POST("/templates/:ref/instantiate", authMiddleware, templateHandler.InstantiateTemplate)
+
+	// Cron schedules that start pipeline runs
+	r.GET("/schedules", authMiddleware, scheduleHandler.GetUserSchedules)
+	r.GET("/schedules/:id", authMiddleware, scheduleHandler.GetSchedule)
+	r.PUT("/schedules/:id", authMiddleware, scheduleHandler.UpdateSchedule)
+	r.POST("/schedules/:id/pause", authMiddleware, scheduleHandler.PauseSchedule)
+	r.POST("/schedules/:id/resume", authMiddleware, scheduleHandler.ResumeSchedule)
+	r.DELETE("/schedules/:id", authMiddleware, scheduleHandler.DeleteSchedule)
+
+	// Triggers that chain pipelines
+	r.GET("/triggers/:id", authMiddleware, triggerHandler.GetTrigger)
+	r.POST("/triggers/:id/enable", authMiddleware, triggerHandler.EnableTrigger)
+	r.POST("/triggers/:id/disable", authMiddleware, triggerHandler.DisableTrigger)
+	r.DELETE("/triggers/:id", authMiddleware, triggerHandler.DeleteTrigger)
+
+	// Aggregates over pipeline runs, ?user_id=&from=&to= select the runs
+	r.GET("/analytics/runs-per-day", authMiddleware, analyticsHandler.GetRunsPerDay)
+	r.GET("/analytics/outcomes", authMiddleware, analyticsHandler.GetRunOutcomes)
+	r.GET("/analytics/durations", authMiddleware, analyticsHandler.GetDurations)
+	r.GET("/analytics/errors", authMiddleware, analyticsHandler.GetTopErrors)
 
 	// SSE Route
 	r.GET("/pipelines/:id/stream", authMiddleware, sseManager.RegisterClient)
@@ -75,6 +155,30 @@ func startRESTServer(authService *services.AuthService, pipelineService *service
 
 }
 
+// startGRPCServer serves the gRPC services on the same PipelineService as the
+// REST API, so democtl, remote workers and the dashboard share pipelines, the
+// work queue and the SSE stream
+func startGRPCServer(authService *services.AuthService, pipelineService *services.PipelineService, studyService *services.StudyService, bottleneckService *services.BottleneckService, templateService *services.TemplateService, scheduleService *services.ScheduleService, triggerService *services.TriggerService, idempotencyService *services.IdempotencyService, analyticsService *services.AnalyticsService, workerPool *services.WorkerPool, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = primary.DefaultGRPCPort
+	}
+
+	grpcServer := primary.NewGRPCServer(authService, pipelineService, studyService, bottleneckService, templateService, scheduleService, triggerService, idempotencyService, analyticsService, workerPool)
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("❌ Failed to listen on port %s: %v", port, err)
+	}
+
+	log.Printf("🚀 Starting gRPC server on port %s...", port)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("❌ Failed to start gRPC server: %v", err)
+	}
+}
+
 func main() {
 	// Initialize database
 	secondary.InitDatabase()
@@ -85,14 +189,49 @@ func main() {
 
 	// Initialize services
 	authService := services.NewAuthService(dbRepo)
-	pipelineService := services.NewPipelineService(dbRepo, sseManager)
+	pipelineService := services.NewPipelineService(dbRepo, dbRepo, sseManager)
+	pipelineService.Instance = services.InstanceName("rest-api")
+	studyService := services.NewStudyService(dbRepo, pipelineService)
+	bottleneckService := services.NewBottleneckService(pipelineService, dbRepo)
+	calendarService := services.NewCalendarService(dbRepo)
+	templateService := services.NewTemplateService(dbRepo, pipelineService)
+	scheduleService := services.NewScheduleService(dbRepo, pipelineService)
+	triggerService := services.NewTriggerService(dbRepo, pipelineService)
+	idempotencyService := services.NewIdempotencyService(dbRepo, services.IdempotencyTTLFromEnv())
+	analyticsService := services.NewAnalyticsService(dbRepo)
+
+	// Runs that end fire the triggers of their pipeline
+	pipelineService.OnRunEnded = triggerService.HandleRunEnded
+
+	// Pipelines by status are counted in the database on every scrape of /metrics
+	utils.RegisterPipelineStatus(pipelineService.CountPipelinesByStatus)
+
+	// Stages run in-process unless STAGE_EXECUTOR=remote hands them to worker processes
+	workerPool := services.NewWorkerPool(domain.LocalExecutor{}, sseManager)
+	if services.RemoteExecutionFromEnv() {
+		log.Println("🔹 Dispatching stages to remote workers")
+		pipelineService.Executor = workerPool
+	}
+
+	// Pick up the runs this server left behind when it stopped, then keep the
+	// leases of its runs alive and take over those of replicas that died
+	recoveryPolicy := services.RecoveryPolicyFromEnv()
+	if err := pipelineService.RecoverRuns(recoveryPolicy); err != nil {
+		log.Printf("❌ Failed to recover orphaned runs: %v", err)
+	}
+	go pipelineService.MaintainLeases(recoveryPolicy)
+
+	// Fire due pipeline schedules; replicas claim each fire time in the database
+	go scheduleService.RunScheduler()
+	go idempotencyService.PurgeExpiredKeys()
 
 	var wg sync.WaitGroup
-	wg.Add(1) // Only 1 (REST)
+	wg.Add(2) // REST and gRPC
 
-	// Start REST API server
-	go startRESTServer(authService, pipelineService, sseManager, &wg)
+	// Start REST API and gRPC servers on the same services
+	go startRESTServer(authService, pipelineService, studyService, bottleneckService, calendarService, templateService, scheduleService, triggerService, idempotencyService, analyticsService, sseManager, &wg)
+	go startGRPCServer(authService, pipelineService, studyService, bottleneckService, templateService, scheduleService, triggerService, idempotencyService, analyticsService, workerPool, &wg)
 
-	// Wait for server
+	// Wait for servers
 	wg.Wait()
 }
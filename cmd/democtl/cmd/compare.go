@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/study"
+	"github.com/spf13/cobra"
+)
+
+// ✅ Compare Scenarios Command
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the KPIs of two or more scenarios against a baseline",
+	Long: `Compare the KPIs of two or more scenarios. The first --scenario is the baseline.
+
+A scenario is written as [name=]study:<study-id>, [name=]pipeline:<pipeline-id>
+or [name=]stages:<count>[:parallel]. Pipelines and stage counts are run as new
+studies sharing one seed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		specs, _ := cmd.Flags().GetStringArray("scenario")
+		replications, _ := cmd.Flags().GetInt("replications")
+		units, _ := cmd.Flags().GetInt("units")
+
+		req := &proto.CompareScenariosRequest{
+			UserId:       userID,
+			Replications: int32(replications),
+			Units:        int32(units),
+		}
+		if cmd.Flags().Changed("seed") {
+			seed, _ := cmd.Flags().GetInt64("seed")
+			req.Seed = &seed
+		}
+		for _, spec := range specs {
+			scenario, err := parseScenario(spec)
+			if err != nil {
+				log.Fatalf("Invalid scenario %q: %v", spec, err)
+			}
+			req.Scenarios = append(req.Scenarios, scenario)
+		}
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewStudyServiceClient(conn)
+
+		resp, err := client.CompareScenarios(ctx, req)
+		if err != nil {
+			log.Fatalf("Comparison failed: %v", err)
+		}
+
+		printComparison(resp)
+	},
+}
+
+func parseScenario(spec string) (*proto.Scenario, error) {
+	scenario := &proto.Scenario{}
+	if name, rest, ok := strings.Cut(spec, "="); ok {
+		scenario.Name = name
+		spec = rest
+	}
+
+	parts := strings.Split(spec, ":")
+	switch {
+	case len(parts) == 2 && parts[0] == "study":
+		scenario.StudyId = parts[1]
+	case len(parts) == 2 && parts[0] == "pipeline":
+		scenario.PipelineId = parts[1]
+	case (len(parts) == 2 || len(parts) == 3) && parts[0] == "stages":
+		stages, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		scenario.Stages = int32(stages)
+		scenario.IsParallel = len(parts) == 3 && parts[2] == "parallel"
+	default:
+		return nil, fmt.Errorf("expected study:<id>, pipeline:<id> or stages:<count>[:parallel]")
+	}
+	return scenario, nil
+}
+
+func printComparison(resp *proto.CompareScenariosResponse) {
+	fmt.Printf("📊 Baseline: %s (%.0f%% confidence, * = significant)\n\n", resp.Baseline, resp.ConfidenceLevel*100)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"METRIC"}
+	for _, sc := range resp.Scenarios {
+		header = append(header, strings.ToUpper(sc.Name))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	if len(resp.Scenarios) > 0 {
+		for i, m := range resp.Scenarios[0].Metrics {
+			row := []string{m.Metric}
+			for j, sc := range resp.Scenarios {
+				if i >= len(sc.Metrics) {
+					row = append(row, "-")
+					continue
+				}
+				cmp := sc.Metrics[i]
+				if j == 0 {
+					row = append(row, fmt.Sprintf("%.4f ± %.4f", cmp.Scenario.Mean, cmp.Scenario.Mean-cmp.Scenario.CiLower))
+					continue
+				}
+				marker := ""
+				if cmp.Significant {
+					marker = "*"
+				}
+				row = append(row, fmt.Sprintf("%.4f (%+.2f%%, p=%.3f%s)", cmp.Scenario.Mean, cmp.DeltaPercent, cmp.PValue, marker))
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	}
+	w.Flush()
+}
+
+func init() {
+	compareCmd.Flags().String("user-id", "", "User ID")
+	compareCmd.Flags().StringArray("scenario", nil, "Scenario to compare (repeatable, first is the baseline)")
+	compareCmd.Flags().Int("replications", 30, "Replications for scenarios that have to be run")
+	compareCmd.Flags().Int("units", 100, "Units per replication for scenarios that have to be run")
+	compareCmd.Flags().Int64("seed", 0, "Seed shared by scenarios that have to be run (random if unset)")
+	compareCmd.MarkFlagRequired("user-id")
+	compareCmd.MarkFlagRequired("scenario")
+}
@@ -1,16 +1,24 @@
 package cmd
 
 import (
-	// "context"
+	"context"
 	"fmt"
 	"log"
-	// "time"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/pipeline"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/metadata"
 	// "google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Root pipeline command
@@ -28,6 +36,17 @@ var createPipelineCmd = &cobra.Command{
 		stages, _ := cmd.Flags().GetInt("stages")
 		isParallel, _ := cmd.Flags().GetBool("parallel")
 
+		// ✅ Simulated timing and yield apply to every stage when any of them is set
+		var specs []*proto.StageSpec
+		if cmd.Flags().Changed("mean") || cmd.Flags().Changed("stddev") || cmd.Flags().Changed("yield") {
+			mean, _ := cmd.Flags().GetFloat64("mean")
+			stddev, _ := cmd.Flags().GetFloat64("stddev")
+			yield, _ := cmd.Flags().GetFloat64("yield")
+			for i := 0; i < stages; i++ {
+				specs = append(specs, &proto.StageSpec{MeanSeconds: mean, StddevSeconds: stddev, Yield: yield})
+			}
+		}
+
 		// 🔹 Get gRPC connection
 		conn, ctx, cancel := GetGRPCConnection()
 		defer conn.Close()
@@ -37,10 +56,16 @@ var createPipelineCmd = &cobra.Command{
 		// ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		// defer cancel()
 
-		resp, err := client.CreatePipeline(ctx, &proto.CreatePipelineRequest{
+		labels, _ := cmd.Flags().GetStringToString("label")
+		annotations, _ := cmd.Flags().GetStringToString("annotation")
+
+		resp, err := client.CreatePipeline(withIdempotencyKey(cmd, ctx), &proto.CreatePipelineRequest{
 			UserId:    userID,
 			Stages:    int32(stages),
 			IsParallel: isParallel,
+			StageSpecs: specs,
+			Labels:      labels,
+			Annotations: annotations,
 		})
 		if err != nil {
 			log.Fatalf("Pipeline creation failed: %v", err)
@@ -78,17 +103,34 @@ var startPipelineCmd = &cobra.Command{
 			log.Fatalf("Failed to wrap input in Any: %v", err)
 		}
 
-		resp, err := client.StartPipeline(ctx, &proto.StartPipelineRequest{
+		req := &proto.StartPipelineRequest{
 			PipelineId: pipelineID,
 			UserId:     userID,
 			Input:      anyInput, // ✅ Correctly passing as Any
 			IsParallel: isParallel,
-		})
+		}
+		if cmd.Flags().Changed("seed") {
+			seed, _ := cmd.Flags().GetInt64("seed")
+			req.Seed = &seed
+		}
+		req.Priority, _ = cmd.Flags().GetInt32("priority")
+		if due, _ := cmd.Flags().GetString("due"); due != "" {
+			dueDate, err := time.Parse(time.RFC3339, due)
+			if err != nil {
+				log.Fatalf("Invalid --due %q, expected RFC 3339: %v", due, err)
+			}
+			req.DueDate = timestamppb.New(dueDate)
+		}
+
+		resp, err := client.StartPipeline(withIdempotencyKey(cmd, ctx), req)
 		if err != nil {
 			log.Fatalf("Failed to start pipeline: %v", err)
 		}
 
-		fmt.Printf("🚀 Pipeline execution started successfully! Message: %s\n", resp.Message)
+		fmt.Printf("🚀 Pipeline execution started successfully! Message: %s, Run ID: %s, Seed: %d\n", resp.Message, resp.RunId, resp.Seed)
+		if resp.Position > 0 {
+			fmt.Printf("⏳ Waiting in queue at position %d\n", resp.Position)
+		}
 	},
 }
 
@@ -154,17 +196,493 @@ var getPipelineStatusCmd = &cobra.Command{
 	},
 }
 
+// ✅ Replay Pipeline Command
+var replayPipelineCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-execute a past run with the same seed and diff the events",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		userID, _ := cmd.Flags().GetString("user-id")
+		showEvents, _ := cmd.Flags().GetBool("events")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		resp, err := client.ReplayPipeline(ctx, &proto.ReplayPipelineRequest{
+			PipelineId: pipelineID,
+			UserId:     userID,
+		})
+		if err != nil {
+			log.Fatalf("Failed to replay pipeline: %v", err)
+		}
+
+		fmt.Printf("🔁 Replayed %s as %s (seed %d): %s\n", resp.OriginalPipelineId, resp.ReplayPipelineId, resp.Seed, resp.Status)
+		if resp.Identical {
+			fmt.Printf("✅ Event sequences are identical (%d events)\n", len(resp.ReplayEvents))
+		} else {
+			fmt.Printf("❌ Event sequences differ at %d position(s)\n", len(resp.Diffs))
+		}
+
+		if !showEvents && resp.Identical {
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tSEQ\tORIGINAL\tREPLAY")
+		if showEvents {
+			n := len(resp.OriginalEvents)
+			if len(resp.ReplayEvents) > n {
+				n = len(resp.ReplayEvents)
+			}
+			differs := make(map[int32]bool)
+			for _, d := range resp.Diffs {
+				differs[d.Seq] = true
+			}
+			for i := 0; i < n; i++ {
+				var original, replay *proto.ExecutionEvent
+				if i < len(resp.OriginalEvents) {
+					original = resp.OriginalEvents[i]
+				}
+				if i < len(resp.ReplayEvents) {
+					replay = resp.ReplayEvents[i]
+				}
+				marker := ""
+				if differs[int32(i)] {
+					marker = "!"
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", marker, i, formatEvent(original), formatEvent(replay))
+			}
+		} else {
+			for _, d := range resp.Diffs {
+				fmt.Fprintf(w, "!\t%d\t%s\t%s\n", d.Seq, formatEvent(d.Original), formatEvent(d.Replay))
+			}
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Pipeline Bottlenecks Command
+var bottlenecksPipelineCmd = &cobra.Command{
+	Use:   "bottlenecks",
+	Short: "Rank the stages of a run or study by how much they limit throughput",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		studyID, _ := cmd.Flags().GetString("study-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		resp, err := client.GetBottlenecks(ctx, &proto.GetBottlenecksRequest{
+			PipelineId: pipelineID,
+			StudyId:    studyID,
+		})
+		if err != nil {
+			log.Fatalf("Failed to analyze bottlenecks: %v", err)
+		}
+
+		fmt.Printf("🔍 Bottlenecks of %s from %s (makespan %.1fs)\n\n", resp.PipelineId, resp.Source, resp.MakespanSeconds)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RANK\tSTAGE\tUTIL\tAVG QUEUE\tMAX QUEUE\tSTARVED(s)\tBLOCKED(s)\tSUGGESTION")
+		for _, b := range resp.Stages {
+			marker := ""
+			if b.Bottleneck {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%d%s\t%s\t%.0f%%\t%.2f\t%.0f\t%.1f\t%.1f\t%s\n", b.Rank, marker, b.Name,
+				b.Utilization*100, b.AvgQueueLength, b.MaxQueueLength, b.StarvedSeconds, b.BlockedSeconds, b.Suggestion)
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Pipeline Queue Command
+var queuePipelineCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Show the work orders waiting to run",
+	Run: func(cmd *cobra.Command, args []string) {
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		resp, err := client.GetQueue(ctx, &proto.GetQueueRequest{})
+		if err != nil {
+			log.Fatalf("Failed to get queue: %v", err)
+		}
+
+		fmt.Printf("📋 Rule: %s, running %d of %d\n\n", resp.Rule, resp.Running, resp.MaxConcurrency)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "POS\tPIPELINE\tPRIORITY\tDUE\tEXPECTED(s)\tENQUEUED")
+		for _, o := range resp.Orders {
+			due := "-"
+			if o.DueDate != nil {
+				due = o.DueDate.AsTime().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%.1f\t%s\n", o.Position, o.PipelineId, o.Priority, due,
+				o.ProcessingSeconds, o.EnqueuedAt.AsTime().Format(time.RFC3339))
+		}
+		w.Flush()
+	},
+}
+
+// ✅ List Pipelines Command
+var listPipelineCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the pipelines of a user, filtered, sorted and a page at a time",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		all, _ := cmd.Flags().GetBool("all")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		req := &proto.ListPipelinesRequest{UserId: userID}
+		req.Statuses, _ = cmd.Flags().GetStringSlice("status")
+		req.Mode, _ = cmd.Flags().GetString("mode")
+		req.Selector, _ = cmd.Flags().GetString("selector")
+		req.Sort, _ = cmd.Flags().GetString("sort")
+		req.Order, _ = cmd.Flags().GetString("order")
+		req.Limit, _ = cmd.Flags().GetInt32("limit")
+		req.Cursor, _ = cmd.Flags().GetString("cursor")
+		req.CreatedAfter = timestampFlag(cmd, "created-after")
+		req.CreatedBefore = timestampFlag(cmd, "created-before")
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PIPELINE\tSTATUS\tMODE\tCREATED\tUPDATED\tLABELS")
+		for {
+			resp, err := client.ListPipelines(ctx, req)
+			if err != nil {
+				log.Fatalf("Failed to list pipelines: %v", err)
+			}
+			for _, p := range resp.Pipelines {
+				mode := "sequential"
+				if p.IsParallel {
+					mode = "parallel"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.PipelineId, p.Status, mode,
+					p.CreatedAt.AsTime().Format(time.RFC3339), p.UpdatedAt.AsTime().Format(time.RFC3339), formatLabels(p.Labels))
+			}
+			req.Cursor = resp.NextCursor
+			if !all || req.Cursor == "" {
+				break
+			}
+		}
+		w.Flush()
+
+		if req.Cursor != "" {
+			fmt.Printf("\nMore pipelines: --cursor %s\n", req.Cursor)
+		}
+	},
+}
+
+// ✅ Pipeline Labels Command
+var labelPipelineCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Show, set or remove the labels and annotations of a pipeline",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		setLabels, _ := cmd.Flags().GetStringToString("label")
+		removeLabels, _ := cmd.Flags().GetStringSlice("remove-label")
+		setAnnotations, _ := cmd.Flags().GetStringToString("annotation")
+		removeAnnotations, _ := cmd.Flags().GetStringSlice("remove-annotation")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		var meta *proto.PipelineMetadata
+		var err error
+		if len(setLabels)+len(removeLabels)+len(setAnnotations)+len(removeAnnotations) == 0 {
+			meta, err = client.GetPipelineMetadata(ctx, &proto.GetPipelineMetadataRequest{PipelineId: pipelineID})
+		} else {
+			meta, err = client.UpdatePipelineMetadata(ctx, &proto.UpdatePipelineMetadataRequest{
+				PipelineId:        pipelineID,
+				SetLabels:         setLabels,
+				RemoveLabels:      removeLabels,
+				SetAnnotations:    setAnnotations,
+				RemoveAnnotations: removeAnnotations,
+			})
+		}
+		if err != nil {
+			log.Fatalf("Failed to update labels: %v", err)
+		}
+
+		fmt.Printf("🏷️  Pipeline %s\n", meta.PipelineId)
+		fmt.Printf("   Labels:      %s\n", formatLabels(meta.Labels))
+		for _, key := range sortedKeys(meta.Annotations) {
+			fmt.Printf("   Annotation %s: %s\n", key, meta.Annotations[key])
+		}
+	},
+}
+
+// ✅ List Pipeline Runs Command
+var runsPipelineCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List the runs of a pipeline, latest first",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		resp, err := client.ListPipelineRuns(ctx, &proto.ListPipelineRunsRequest{PipelineId: pipelineID})
+		if err != nil {
+			log.Fatalf("Failed to list runs: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tRUN\tSTATUS\tQUEUED\tSTARTED\tDURATION")
+		for _, r := range resp.Runs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.Number, r.RunId, r.Status,
+				r.CreatedAt.AsTime().Format(time.RFC3339), formatTimestamp(r.StartedAt), runDuration(r))
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Get Pipeline Run Command
+var runPipelineCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Show one run of a pipeline with its input, output and stage logs",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		runID, _ := cmd.Flags().GetString("run-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		r, err := client.GetPipelineRun(ctx, &proto.GetPipelineRunRequest{PipelineId: pipelineID, RunId: runID})
+		if err != nil {
+			log.Fatalf("Failed to get run: %v", err)
+		}
+
+		fmt.Printf("🏃 Run %d of pipeline %s: %s (seed %d)\n", r.Number, r.PipelineId, r.Status, r.Seed)
+		fmt.Printf("   Queued %s, started %s, finished %s (%s)\n", r.CreatedAt.AsTime().Format(time.RFC3339),
+			formatTimestamp(r.StartedAt), formatTimestamp(r.FinishedAt), runDuration(r))
+		fmt.Printf("   Input:  %s\n", formatValue(r.Input))
+		fmt.Printf("   Output: %s\n", formatValue(r.Output))
+		if r.Error != "" {
+			fmt.Printf("   Error:  %s\n", r.Error)
+		}
+		if r.TriggerId != "" {
+			fmt.Printf("   Triggered by run %s (trigger %s)\n", r.TriggeredByRunId, r.TriggerId)
+			for i, link := range r.TriggerChain {
+				fmt.Printf("   %d. run %s of pipeline %s\n", i+1, link.RunId, link.PipelineId)
+			}
+		}
+		fmt.Println()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tSTAGE\tATTEMPT\tSTATUS\tDURATION\tMESSAGE")
+		for _, l := range r.Logs {
+			stage, attempt, duration := "-", "-", "-"
+			if l.StageIndex >= 0 {
+				stage = fmt.Sprintf("%d. %s", l.StageIndex+1, l.StageName)
+				attempt = fmt.Sprint(l.Attempt)
+			}
+			if l.FinishedAt != nil {
+				duration = (time.Duration(l.DurationMs) * time.Millisecond).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", l.Timestamp.AsTime().Format(time.RFC3339), stage, attempt, l.Status, duration, l.Error)
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Get Pipeline Result Command
+var resultPipelineCmd = &cobra.Command{
+	Use:   "result",
+	Short: "Print the output of the latest run of a pipeline",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		r, err := client.GetPipelineResult(ctx, &proto.GetPipelineResultRequest{PipelineId: pipelineID})
+		if err != nil {
+			log.Fatalf("Failed to get result: %v", err)
+		}
+
+		fmt.Printf("🏁 Run %d of pipeline %s: %s (finished %s)\n", r.RunNumber, r.PipelineId, r.Status, formatTimestamp(r.FinishedAt))
+		fmt.Printf("   Input:  %s\n", formatValue(r.Input))
+		fmt.Printf("   Output: %s\n", formatValue(r.Output))
+		if r.Error != "" {
+			fmt.Printf("   Error:  %s\n", r.Error)
+		}
+	},
+}
+
+// ✅ Pipeline Status History Command
+var transitionsPipelineCmd = &cobra.Command{
+	Use:   "transitions",
+	Short: "Show the status history of a pipeline and its runs",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewPipelineServiceClient(conn)
+
+		resp, err := client.ListPipelineTransitions(ctx, &proto.ListPipelineTransitionsRequest{PipelineId: pipelineID})
+		if err != nil {
+			log.Fatalf("Failed to list transitions: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tRUN\tFROM\tTO\tACTOR\tREASON")
+		for _, t := range resp.Transitions {
+			run, from := t.RunId, t.FromStatus
+			if run == "" {
+				run = "pipeline"
+			}
+			if from == "" {
+				from = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", t.CreatedAt.AsTime().Format(time.RFC3339), run, from, t.ToStatus, t.Actor, t.Reason)
+		}
+		w.Flush()
+	},
+}
+
+// withIdempotencyKey sends --idempotency-key along, so the server answers a
+// retry with the response of the first attempt
+func withIdempotencyKey(cmd *cobra.Command, ctx context.Context) context.Context {
+	if key, _ := cmd.Flags().GetString("idempotency-key"); key != "" {
+		return metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+	}
+	return ctx
+}
+
+// formatLabels prints labels as a selector would match them, sorted by key
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	var pairs []string
+	for _, key := range sortedKeys(labels) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// timestampFlag parses an optional RFC 3339 flag
+func timestampFlag(cmd *cobra.Command, name string) *timestamppb.Timestamp {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Fatalf("Invalid --%s %q, expected RFC 3339: %v", name, value, err)
+	}
+	return timestamppb.New(t)
+}
+
+func formatTimestamp(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "-"
+	}
+	return t.AsTime().Format(time.RFC3339)
+}
+
+func runDuration(r *proto.PipelineRun) string {
+	if r.StartedAt == nil || r.FinishedAt == nil {
+		return "-"
+	}
+	return r.FinishedAt.AsTime().Sub(r.StartedAt.AsTime()).Round(time.Millisecond).String()
+}
+
+func formatValue(v *structpb.Value) string {
+	if v == nil {
+		return "-"
+	}
+	data, err := protojson.Marshal(v)
+	if err != nil {
+		return v.String()
+	}
+	return string(data)
+}
+
+func formatEvent(e *proto.ExecutionEvent) string {
+	if e == nil {
+		return "-"
+	}
+	if e.Type == "stage" {
+		return fmt.Sprintf("+%v stage %d %s", time.Duration(e.OffsetNs), e.StageIndex, e.Status)
+	}
+	return fmt.Sprintf("+%v pipeline %s", time.Duration(e.OffsetNs), e.Status)
+}
+
 func init() {
 	// Add all commands under `pipeline`
 	pipelineCmd.AddCommand(createPipelineCmd)
 	pipelineCmd.AddCommand(startPipelineCmd)
 	pipelineCmd.AddCommand(cancelPipelineCmd)
 	pipelineCmd.AddCommand(getPipelineStatusCmd)
+	pipelineCmd.AddCommand(replayPipelineCmd)
+	pipelineCmd.AddCommand(bottlenecksPipelineCmd)
+	pipelineCmd.AddCommand(queuePipelineCmd)
+	pipelineCmd.AddCommand(listPipelineCmd)
+	pipelineCmd.AddCommand(labelPipelineCmd)
+	pipelineCmd.AddCommand(runsPipelineCmd)
+	pipelineCmd.AddCommand(runPipelineCmd)
+	pipelineCmd.AddCommand(resultPipelineCmd)
+	pipelineCmd.AddCommand(transitionsPipelineCmd)
 
 	// Flags for create pipeline
 	createPipelineCmd.Flags().String("user", "", "User ID")
 	createPipelineCmd.Flags().Int("stages", 3, "Number of stages")
 	createPipelineCmd.Flags().Bool("parallel", false, "Parallel execution")
+	createPipelineCmd.Flags().Float64("mean", 0, "Mean stage processing time in seconds")
+	createPipelineCmd.Flags().Float64("stddev", 0, "Standard deviation of stage processing time in seconds")
+	createPipelineCmd.Flags().Float64("yield", 0, "Probability that a stage passes its quality check")
+	createPipelineCmd.Flags().String("idempotency-key", "", "Key that makes retrying this command safe")
+	createPipelineCmd.Flags().StringToString("label", nil, "Label to tag the pipeline with, as key=value (repeatable)")
+	createPipelineCmd.Flags().StringToString("annotation", nil, "Annotation to add, as key=value (repeatable)")
 	createPipelineCmd.MarkFlagRequired("user")
 
 	// Flags for start pipeline
@@ -172,6 +690,10 @@ func init() {
 	startPipelineCmd.Flags().String("user-id", "", "User ID")
 	startPipelineCmd.Flags().String("input", "", "Input for pipeline")
 	startPipelineCmd.Flags().Bool("parallel", false, "Run in parallel mode")
+	startPipelineCmd.Flags().Int64("seed", 0, "Random seed (generated if unset)")
+	startPipelineCmd.Flags().Int32("priority", 0, "Dispatch priority; higher runs first under the priority rule")
+	startPipelineCmd.Flags().String("due", "", "Due date in RFC 3339, used by the edd rule")
+	startPipelineCmd.Flags().String("idempotency-key", "", "Key that makes retrying this command safe")
 	startPipelineCmd.MarkFlagRequired("pipeline-id")
 	startPipelineCmd.MarkFlagRequired("user-id")
 
@@ -186,4 +708,53 @@ func init() {
 	getPipelineStatusCmd.Flags().String("pipeline-id", "", "Pipeline ID")
 	getPipelineStatusCmd.Flags().Bool("parallel", false, "Check parallel pipeline status")
 	getPipelineStatusCmd.MarkFlagRequired("pipeline-id")
+
+	// Flags for replay pipeline
+	replayPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID of the run to replay")
+	replayPipelineCmd.Flags().String("user-id", "", "User ID")
+	replayPipelineCmd.Flags().Bool("events", false, "Print both event sequences side by side")
+	replayPipelineCmd.MarkFlagRequired("pipeline-id")
+	replayPipelineCmd.MarkFlagRequired("user-id")
+
+	// Flags for pipeline bottlenecks
+	bottlenecksPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	bottlenecksPipelineCmd.Flags().String("study-id", "", "Analyze this study of the pipeline instead of its last run")
+	bottlenecksPipelineCmd.MarkFlagRequired("pipeline-id")
+
+	// Flags for pipeline runs
+	runsPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	runsPipelineCmd.MarkFlagRequired("pipeline-id")
+
+	// Flags for pipeline run
+	runPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	runPipelineCmd.Flags().String("run-id", "", "Run ID")
+	runPipelineCmd.MarkFlagRequired("pipeline-id")
+	runPipelineCmd.MarkFlagRequired("run-id")
+
+	listPipelineCmd.Flags().String("user-id", "", "User ID")
+	listPipelineCmd.Flags().StringSlice("status", nil, "Only pipelines in these statuses (comma-separated)")
+	listPipelineCmd.Flags().String("mode", "", "Only sequential or parallel pipelines")
+	listPipelineCmd.Flags().String("selector", "", "Label selector, e.g. \"line=a,team in (x,y),!legacy\"")
+	listPipelineCmd.Flags().String("created-after", "", "Only pipelines created at or after this time (RFC 3339)")
+	listPipelineCmd.Flags().String("created-before", "", "Only pipelines created before this time (RFC 3339)")
+	listPipelineCmd.Flags().String("sort", "created_at", "Sort by created_at or updated_at")
+	listPipelineCmd.Flags().String("order", "desc", "Sort order, asc or desc")
+	listPipelineCmd.Flags().Int32("limit", 0, "Pipelines per page (server default 50, at most 200)")
+	listPipelineCmd.Flags().String("cursor", "", "Cursor printed after the previous page")
+	listPipelineCmd.Flags().Bool("all", false, "Follow cursors and list every page")
+	listPipelineCmd.MarkFlagRequired("user-id")
+
+	labelPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	labelPipelineCmd.Flags().StringToString("label", nil, "Label to set, as key=value (repeatable)")
+	labelPipelineCmd.Flags().StringSlice("remove-label", nil, "Label keys to remove (comma-separated)")
+	labelPipelineCmd.Flags().StringToString("annotation", nil, "Annotation to set, as key=value (repeatable)")
+	labelPipelineCmd.Flags().StringSlice("remove-annotation", nil, "Annotation keys to remove (comma-separated)")
+	labelPipelineCmd.MarkFlagRequired("pipeline-id")
+
+	resultPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	resultPipelineCmd.MarkFlagRequired("pipeline-id")
+
+	// Flags for pipeline transitions
+	transitionsPipelineCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	transitionsPipelineCmd.MarkFlagRequired("pipeline-id")
 }
@@ -24,4 +24,10 @@ func init() {
 	rootCmd.AddCommand(registerCmd)
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(pipelineCmd)
+	rootCmd.AddCommand(studyCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(workerCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(triggerCmd)
 }
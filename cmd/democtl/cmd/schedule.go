@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/schedule"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Root schedule command
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage cron schedules that start pipeline runs",
+}
+
+// ✅ Create Schedule Command
+var createScheduleCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Schedule recurring runs of a pipeline",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		resp, err := client.CreateSchedule(ctx, &proto.CreateScheduleRequest{
+			UserId:     userID,
+			PipelineId: pipelineID,
+			Spec:       scheduleSpecFlags(cmd, &proto.ScheduleSpec{}),
+		})
+		if err != nil {
+			log.Fatalf("Schedule creation failed: %v", err)
+		}
+
+		fmt.Printf("✅ Schedule %s created, next run at %s\n", resp.ScheduleId, formatTimestamp(resp.NextFireAt))
+	},
+}
+
+// ✅ List Schedules Command
+var listSchedulesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the schedules of a user or of a pipeline",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		if userID == "" && pipelineID == "" {
+			log.Fatalf("Either --user-id or --pipeline-id is required")
+		}
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		resp, err := client.ListSchedules(ctx, &proto.ListSchedulesRequest{UserId: userID, PipelineId: pipelineID})
+		if err != nil {
+			log.Fatalf("Failed to list schedules: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SCHEDULE\tPIPELINE\tCRON\tTIMEZONE\tMISSED\tNEXT RUN\tLAST RUN")
+		for _, s := range resp.Schedules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", s.ScheduleId, s.PipelineId, s.Spec.Cron, s.Spec.Timezone, s.Spec.MissedPolicy, nextRun(s), formatTimestamp(s.LastFireAt))
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Get Schedule Command
+var getScheduleCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a schedule with its next and last run",
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleID, _ := cmd.Flags().GetString("schedule-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		resp, err := client.GetSchedule(ctx, &proto.ScheduleIdRequest{ScheduleId: scheduleID})
+		if err != nil {
+			log.Fatalf("Failed to get schedule: %v", err)
+		}
+
+		printSchedule(resp)
+	},
+}
+
+// ✅ Update Schedule Command
+var updateScheduleCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Change the settings of a schedule; flags not given keep their value",
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleID, _ := cmd.Flags().GetString("schedule-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		current, err := client.GetSchedule(ctx, &proto.ScheduleIdRequest{ScheduleId: scheduleID})
+		if err != nil {
+			log.Fatalf("Failed to get schedule: %v", err)
+		}
+		resp, err := client.UpdateSchedule(ctx, &proto.UpdateScheduleRequest{
+			ScheduleId: scheduleID,
+			Spec:       scheduleSpecFlags(cmd, current.Spec),
+		})
+		if err != nil {
+			log.Fatalf("Schedule update failed: %v", err)
+		}
+
+		printSchedule(resp)
+	},
+}
+
+// ✅ Pause Schedule Command
+var pauseScheduleCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Stop a schedule from firing",
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleID, _ := cmd.Flags().GetString("schedule-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		if _, err := client.PauseSchedule(ctx, &proto.ScheduleIdRequest{ScheduleId: scheduleID}); err != nil {
+			log.Fatalf("Failed to pause schedule: %v", err)
+		}
+
+		fmt.Printf("✅ Schedule %s paused\n", scheduleID)
+	},
+}
+
+// ✅ Resume Schedule Command
+var resumeScheduleCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Let a paused schedule fire again",
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleID, _ := cmd.Flags().GetString("schedule-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		resp, err := client.ResumeSchedule(ctx, &proto.ScheduleIdRequest{ScheduleId: scheduleID})
+		if err != nil {
+			log.Fatalf("Failed to resume schedule: %v", err)
+		}
+
+		fmt.Printf("✅ Schedule %s resumed, next run at %s\n", scheduleID, formatTimestamp(resp.NextFireAt))
+	},
+}
+
+// ✅ Delete Schedule Command
+var deleteScheduleCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a schedule; runs it started are kept",
+	Run: func(cmd *cobra.Command, args []string) {
+		scheduleID, _ := cmd.Flags().GetString("schedule-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewScheduleServiceClient(conn)
+
+		resp, err := client.DeleteSchedule(ctx, &proto.ScheduleIdRequest{ScheduleId: scheduleID})
+		if err != nil {
+			log.Fatalf("Failed to delete schedule: %v", err)
+		}
+
+		fmt.Println("✅", resp.Message)
+	},
+}
+
+// scheduleSpecFlags applies the schedule flags that were given to spec
+func scheduleSpecFlags(cmd *cobra.Command, spec *proto.ScheduleSpec) *proto.ScheduleSpec {
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		spec.Name, _ = flags.GetString("name")
+	}
+	if flags.Changed("cron") {
+		spec.Cron, _ = flags.GetString("cron")
+	}
+	if flags.Changed("timezone") {
+		spec.Timezone, _ = flags.GetString("timezone")
+	}
+	if flags.Changed("missed") {
+		spec.MissedPolicy, _ = flags.GetString("missed")
+	}
+	if flags.Changed("input") {
+		input, _ := flags.GetString("input")
+		spec.Input = structpb.NewStringValue(input)
+	}
+	if flags.Changed("priority") {
+		priority, _ := flags.GetInt32("priority")
+		spec.Priority = priority
+	}
+	return spec
+}
+
+func printSchedule(s *proto.Schedule) {
+	fmt.Printf("Schedule:     %s %s\n", s.ScheduleId, s.Spec.Name)
+	fmt.Printf("Pipeline:     %s\n", s.PipelineId)
+	fmt.Printf("Cron:         %s (%s)\n", s.Spec.Cron, s.Spec.Timezone)
+	fmt.Printf("Missed fires: %s, %d so far\n", s.Spec.MissedPolicy, s.MissedFires)
+	fmt.Printf("Input:        %s\n", formatValue(s.Spec.Input))
+	fmt.Printf("Priority:     %d\n", s.Spec.Priority)
+	fmt.Printf("Next run:     %s\n", nextRun(s))
+	fmt.Printf("Last run:     %s", formatTimestamp(s.LastFireAt))
+	if s.LastRunId != "" {
+		fmt.Printf(" (run %s)", s.LastRunId)
+	}
+	fmt.Println()
+	if s.LastError != "" {
+		fmt.Printf("Last error:   %s\n", s.LastError)
+	}
+}
+
+func nextRun(s *proto.Schedule) string {
+	if !s.Enabled {
+		return "paused"
+	}
+	return formatTimestamp(s.NextFireAt)
+}
+
+func init() {
+	scheduleCmd.AddCommand(createScheduleCmd)
+	scheduleCmd.AddCommand(listSchedulesCmd)
+	scheduleCmd.AddCommand(getScheduleCmd)
+	scheduleCmd.AddCommand(updateScheduleCmd)
+	scheduleCmd.AddCommand(pauseScheduleCmd)
+	scheduleCmd.AddCommand(resumeScheduleCmd)
+	scheduleCmd.AddCommand(deleteScheduleCmd)
+
+	// Flags for create and update schedule
+	for _, c := range []*cobra.Command{createScheduleCmd, updateScheduleCmd} {
+		c.Flags().String("name", "", "Schedule name")
+		c.Flags().String("cron", "", "Cron expression (minute hour day-of-month month day-of-week) or a macro such as @daily")
+		c.Flags().String("timezone", "", "IANA time zone the cron expression is read in (default UTC)")
+		c.Flags().String("missed", "", "What to do about missed fires: skip (default), run_once or catch_up")
+		c.Flags().String("input", "", "Input of every run the schedule starts")
+		c.Flags().Int32("priority", 0, "Work-order priority of the runs")
+	}
+	createScheduleCmd.Flags().String("user-id", "", "User ID")
+	createScheduleCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	createScheduleCmd.MarkFlagRequired("user-id")
+	createScheduleCmd.MarkFlagRequired("pipeline-id")
+	createScheduleCmd.MarkFlagRequired("cron")
+
+	// Flags for list schedules
+	listSchedulesCmd.Flags().String("user-id", "", "List the schedules of this user")
+	listSchedulesCmd.Flags().String("pipeline-id", "", "List the schedules of this pipeline")
+
+	// Flags for get, update, pause, resume and delete schedule
+	for _, c := range []*cobra.Command{getScheduleCmd, updateScheduleCmd, pauseScheduleCmd, resumeScheduleCmd, deleteScheduleCmd} {
+		c.Flags().String("schedule-id", "", "Schedule ID")
+		c.MarkFlagRequired("schedule-id")
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/study"
+	"github.com/spf13/cobra"
+)
+
+// Root study command
+var studyCmd = &cobra.Command{
+	Use:   "study",
+	Short: "Run and inspect Monte Carlo studies",
+}
+
+// ✅ Run Study Command
+var runStudyCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Replicate a pipeline definition with different seeds",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+		name, _ := cmd.Flags().GetString("name")
+		stages, _ := cmd.Flags().GetInt("stages")
+		isParallel, _ := cmd.Flags().GetBool("parallel")
+		replications, _ := cmd.Flags().GetInt("replications")
+		units, _ := cmd.Flags().GetInt("units")
+
+		req := &proto.RunStudyRequest{
+			UserId:       userID,
+			PipelineId:   pipelineID,
+			Name:         name,
+			Stages:       int32(stages),
+			IsParallel:   isParallel,
+			Replications: int32(replications),
+			Units:        int32(units),
+		}
+		if cmd.Flags().Changed("seed") {
+			seed, _ := cmd.Flags().GetInt64("seed")
+			req.Seed = &seed
+		}
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewStudyServiceClient(conn)
+
+		resp, err := client.RunStudy(ctx, req)
+		if err != nil {
+			log.Fatalf("Study failed: %v", err)
+		}
+
+		printStudy(resp)
+	},
+}
+
+// ✅ Get Study Command
+var getStudyCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the results of a study",
+	Run: func(cmd *cobra.Command, args []string) {
+		studyID, _ := cmd.Flags().GetString("study-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewStudyServiceClient(conn)
+
+		resp, err := client.GetStudy(ctx, &proto.GetStudyRequest{StudyId: studyID})
+		if err != nil {
+			log.Fatalf("Failed to get study: %v", err)
+		}
+
+		printStudy(resp)
+	},
+}
+
+func printStudy(resp *proto.StudyResponse) {
+	fmt.Printf("📊 Study %s (%s): %d replications x %d units, seed %d\n\n",
+		resp.StudyId, resp.Status, resp.Replications, resp.Units, resp.Seed)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "METRIC\tMEAN\tSTDDEV\tP5\tP50\tP95\t95% CI")
+	for _, m := range resp.Metrics {
+		fmt.Fprintf(w, "%s\t%.4f\t%.4f\t%.4f\t%.4f\t%.4f\t[%.4f, %.4f]\n",
+			m.Metric, m.Mean, m.Stddev, m.P5, m.P50, m.P95, m.CiLower, m.CiUpper)
+	}
+	w.Flush()
+}
+
+func init() {
+	studyCmd.AddCommand(runStudyCmd)
+	studyCmd.AddCommand(getStudyCmd)
+
+	// Flags for run study
+	runStudyCmd.Flags().String("user-id", "", "User ID")
+	runStudyCmd.Flags().String("pipeline-id", "", "Use the definition of an existing pipeline")
+	runStudyCmd.Flags().String("name", "", "Study name")
+	runStudyCmd.Flags().Int("stages", 3, "Number of stages")
+	runStudyCmd.Flags().Bool("parallel", false, "Parallel execution")
+	runStudyCmd.Flags().Int("replications", 30, "Number of replications")
+	runStudyCmd.Flags().Int("units", 100, "Units processed per replication")
+	runStudyCmd.Flags().Int64("seed", 0, "Seed of the first replication (random if unset)")
+	runStudyCmd.MarkFlagRequired("user-id")
+
+	// Flags for get study
+	getStudyCmd.Flags().String("study-id", "", "Study ID")
+	getStudyCmd.MarkFlagRequired("study-id")
+}
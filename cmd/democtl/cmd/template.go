@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/template"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+// Root template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage versioned pipeline templates",
+}
+
+// ✅ Create Template Command
+var createTemplateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create the next version of a template from a JSON definition",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		name, _ := cmd.Flags().GetString("name")
+		description, _ := cmd.Flags().GetString("description")
+		file, _ := cmd.Flags().GetString("file")
+		publish, _ := cmd.Flags().GetBool("publish")
+
+		// ✅ The file holds {"is_parallel": false, "stages": [{"name": "...", "mean_seconds": 30, ...}]}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", file, err)
+		}
+		var def domain.PipelineDefinition
+		if err := json.Unmarshal(data, &def); err != nil {
+			log.Fatalf("Invalid definition in %s: %v", file, err)
+		}
+
+		req := &proto.CreateTemplateRequest{
+			UserId:      userID,
+			Name:        name,
+			Description: description,
+			IsParallel:  def.IsParallel,
+			Publish:     publish,
+		}
+		for _, spec := range def.Stages {
+			req.Stages = append(req.Stages, &proto.StageSpec{
+				Name:          spec.Name,
+				MeanSeconds:   spec.MeanSeconds,
+				StddevSeconds: spec.StdDevSeconds,
+				Yield:         spec.Yield,
+				StageType:     spec.Type,
+			})
+		}
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.CreateTemplate(ctx, req)
+		if err != nil {
+			log.Fatalf("Template creation failed: %v", err)
+		}
+
+		fmt.Printf("✅ Template %s@%d created (%s)\n", resp.Name, resp.Version, resp.Status)
+	},
+}
+
+// ✅ List Templates Command
+var listTemplatesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List template versions",
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.ListTemplates(ctx, &proto.ListTemplatesRequest{Name: name})
+		if err != nil {
+			log.Fatalf("Failed to list templates: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TEMPLATE\tSTATUS\tMODE\tSTAGES\tDESCRIPTION")
+		for _, t := range resp.Templates {
+			fmt.Fprintf(w, "%s@%d\t%s\t%s\t%d\t%s\n", t.Name, t.Version, t.Status, templateMode(t.IsParallel), len(t.Stages), t.Description)
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Get Template Command
+var getTemplateCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show a template version and its stages",
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, _ := cmd.Flags().GetString("template")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.GetTemplate(ctx, &proto.TemplateRefRequest{Ref: ref})
+		if err != nil {
+			log.Fatalf("Failed to get template: %v", err)
+		}
+
+		printTemplate(resp)
+	},
+}
+
+// ✅ Publish Template Command
+var publishTemplateCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish a draft; published versions are immutable",
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, _ := cmd.Flags().GetString("template")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.PublishTemplate(ctx, &proto.TemplateRefRequest{Ref: ref})
+		if err != nil {
+			log.Fatalf("Failed to publish template: %v", err)
+		}
+
+		fmt.Printf("✅ Template %s@%d published\n", resp.Name, resp.Version)
+	},
+}
+
+// ✅ Deprecate Template Command
+var deprecateTemplateCmd = &cobra.Command{
+	Use:   "deprecate",
+	Short: "Deprecate a published version",
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, _ := cmd.Flags().GetString("template")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.DeprecateTemplate(ctx, &proto.TemplateRefRequest{Ref: ref})
+		if err != nil {
+			log.Fatalf("Failed to deprecate template: %v", err)
+		}
+
+		fmt.Printf("✅ Template %s@%d deprecated\n", resp.Name, resp.Version)
+	},
+}
+
+// ✅ Instantiate Template Command
+var instantiateTemplateCmd = &cobra.Command{
+	Use:   "instantiate",
+	Short: "Create a pipeline from a template version",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		ref, _ := cmd.Flags().GetString("template")
+		sets, _ := cmd.Flags().GetStringArray("set")
+
+		req := &proto.InstantiateTemplateRequest{UserId: userID, Ref: ref}
+		if cmd.Flags().Changed("parallel") {
+			isParallel, _ := cmd.Flags().GetBool("parallel")
+			req.IsParallel = &isParallel
+		}
+		overrides, err := parseStageOverrides(sets)
+		if err != nil {
+			log.Fatalf("Invalid --set: %v", err)
+		}
+		req.Overrides = overrides
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTemplateServiceClient(conn)
+
+		resp, err := client.InstantiateTemplate(ctx, req)
+		if err != nil {
+			log.Fatalf("Failed to instantiate template: %v", err)
+		}
+
+		if resp.Deprecated {
+			fmt.Printf("⚠️ Template %s is deprecated\n", resp.Template)
+		}
+		fmt.Printf("✅ Pipeline created from %s! Pipeline ID: %s\n", resp.Template, resp.PipelineId)
+	},
+}
+
+// parseStageOverrides turns stage.field=value pairs into overrides, one per stage
+func parseStageOverrides(sets []string) ([]*proto.StageOverride, error) {
+	byStage := make(map[string]*proto.StageOverride)
+	var overrides []*proto.StageOverride
+	for _, set := range sets {
+		key, value, ok := strings.Cut(set, "=")
+		dot := strings.LastIndex(key, ".")
+		if !ok || dot <= 0 {
+			return nil, fmt.Errorf("%q is not stage.field=value", set)
+		}
+		stage, field := key[:dot], key[dot+1:]
+
+		o, ok := byStage[stage]
+		if !ok {
+			o = &proto.StageOverride{Stage: stage}
+			byStage[stage] = o
+			overrides = append(overrides, o)
+		}
+		if field == "type" {
+			o.StageType = &value
+			continue
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %v", set, err)
+		}
+		switch field {
+		case "mean":
+			o.MeanSeconds = &n
+		case "stddev":
+			o.StddevSeconds = &n
+		case "yield":
+			o.Yield = &n
+		default:
+			return nil, fmt.Errorf("%q: unknown field %q, use mean, stddev, yield or type", set, field)
+		}
+	}
+	return overrides, nil
+}
+
+func printTemplate(t *proto.Template) {
+	fmt.Printf("📐 Template %s@%d (%s, %s): %s\n\n", t.Name, t.Version, t.Status, templateMode(t.IsParallel), t.Description)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STAGE\tTYPE\tMEAN (s)\tSTDDEV (s)\tYIELD")
+	for _, s := range t.Stages {
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.2f\t%.4f\n", s.Name, s.StageType, s.MeanSeconds, s.StddevSeconds, s.Yield)
+	}
+	w.Flush()
+}
+
+func templateMode(isParallel bool) string {
+	if isParallel {
+		return "parallel"
+	}
+	return "sequential"
+}
+
+func init() {
+	templateCmd.AddCommand(createTemplateCmd)
+	templateCmd.AddCommand(listTemplatesCmd)
+	templateCmd.AddCommand(getTemplateCmd)
+	templateCmd.AddCommand(publishTemplateCmd)
+	templateCmd.AddCommand(deprecateTemplateCmd)
+	templateCmd.AddCommand(instantiateTemplateCmd)
+
+	// Flags for create template
+	createTemplateCmd.Flags().String("user-id", "", "User ID")
+	createTemplateCmd.Flags().String("name", "", "Template name")
+	createTemplateCmd.Flags().String("description", "", "Template description")
+	createTemplateCmd.Flags().String("file", "", "JSON file with is_parallel and stages")
+	createTemplateCmd.Flags().Bool("publish", false, "Publish right away instead of creating a draft")
+	createTemplateCmd.MarkFlagRequired("user-id")
+	createTemplateCmd.MarkFlagRequired("name")
+	createTemplateCmd.MarkFlagRequired("file")
+
+	// Flags for list templates
+	listTemplatesCmd.Flags().String("name", "", "Only list the versions of this template")
+
+	// Flags for get, publish and deprecate template
+	for _, c := range []*cobra.Command{getTemplateCmd, publishTemplateCmd, deprecateTemplateCmd} {
+		c.Flags().String("template", "", "Template as name@version, or name for the latest published version")
+		c.MarkFlagRequired("template")
+	}
+
+	// Flags for instantiate template
+	instantiateTemplateCmd.Flags().String("user-id", "", "User ID")
+	instantiateTemplateCmd.Flags().String("template", "", "Template as name@version, or name for the latest published version")
+	instantiateTemplateCmd.Flags().Bool("parallel", false, "Override the execution mode")
+	instantiateTemplateCmd.Flags().StringArray("set", nil, "Override a stage as stage.field=value (field: mean, stddev, yield or type); repeatable")
+	instantiateTemplateCmd.MarkFlagRequired("user-id")
+	instantiateTemplateCmd.MarkFlagRequired("template")
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/trigger"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Root trigger command
+var triggerCmd = &cobra.Command{
+	Use:   "trigger",
+	Short: "Chain pipelines with triggers on run completion or failure",
+}
+
+// ✅ Create Trigger Command
+var createTriggerCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Start a pipeline whenever a run of another one ends",
+	Run: func(cmd *cobra.Command, args []string) {
+		userID, _ := cmd.Flags().GetString("user-id")
+		sourceID, _ := cmd.Flags().GetString("source")
+		targetID, _ := cmd.Flags().GetString("target")
+		on, _ := cmd.Flags().GetString("on")
+		passOutput, _ := cmd.Flags().GetBool("pass-output")
+
+		req := &proto.CreateTriggerRequest{
+			UserId:           userID,
+			SourcePipelineId: sourceID,
+			TargetPipelineId: targetID,
+			On:               on,
+			PassOutput:       passOutput,
+		}
+		if cmd.Flags().Changed("input") {
+			input, _ := cmd.Flags().GetString("input")
+			req.Input = structpb.NewStringValue(input)
+		}
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTriggerServiceClient(conn)
+
+		resp, err := client.CreateTrigger(ctx, req)
+		if err != nil {
+			log.Fatalf("Trigger creation failed: %v", err)
+		}
+
+		fmt.Printf("✅ Trigger %s created: %s starts %s when %s\n", resp.TriggerId, resp.SourcePipelineId, resp.TargetPipelineId, resp.On)
+	},
+}
+
+// ✅ List Triggers Command
+var listTriggersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the triggers from and to a pipeline",
+	Run: func(cmd *cobra.Command, args []string) {
+		pipelineID, _ := cmd.Flags().GetString("pipeline-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTriggerServiceClient(conn)
+
+		resp, err := client.ListTriggers(ctx, &proto.ListTriggersRequest{PipelineId: pipelineID})
+		if err != nil {
+			log.Fatalf("Failed to list triggers: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TRIGGER\tSOURCE\tON\tTARGET\tPASS OUTPUT\tENABLED\tLAST FIRED\tLAST ERROR")
+		for _, t := range resp.Triggers {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%t\t%s\t%s\n", t.TriggerId, t.SourcePipelineId, t.On, t.TargetPipelineId,
+				t.PassOutput, t.Enabled, formatTimestamp(t.LastFiredAt), t.LastError)
+		}
+		w.Flush()
+	},
+}
+
+// ✅ Enable Trigger Command
+var enableTriggerCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Let a trigger fire again",
+	Run: func(cmd *cobra.Command, args []string) {
+		triggerID, _ := cmd.Flags().GetString("trigger-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTriggerServiceClient(conn)
+
+		if _, err := client.EnableTrigger(ctx, &proto.TriggerIdRequest{TriggerId: triggerID}); err != nil {
+			log.Fatalf("Failed to enable trigger: %v", err)
+		}
+
+		fmt.Printf("✅ Trigger %s enabled\n", triggerID)
+	},
+}
+
+// ✅ Disable Trigger Command
+var disableTriggerCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Stop a trigger from firing",
+	Run: func(cmd *cobra.Command, args []string) {
+		triggerID, _ := cmd.Flags().GetString("trigger-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTriggerServiceClient(conn)
+
+		if _, err := client.DisableTrigger(ctx, &proto.TriggerIdRequest{TriggerId: triggerID}); err != nil {
+			log.Fatalf("Failed to disable trigger: %v", err)
+		}
+
+		fmt.Printf("✅ Trigger %s disabled\n", triggerID)
+	},
+}
+
+// ✅ Delete Trigger Command
+var deleteTriggerCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a trigger; runs it started are kept",
+	Run: func(cmd *cobra.Command, args []string) {
+		triggerID, _ := cmd.Flags().GetString("trigger-id")
+
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewTriggerServiceClient(conn)
+
+		resp, err := client.DeleteTrigger(ctx, &proto.TriggerIdRequest{TriggerId: triggerID})
+		if err != nil {
+			log.Fatalf("Failed to delete trigger: %v", err)
+		}
+
+		fmt.Println("✅", resp.Message)
+	},
+}
+
+func init() {
+	triggerCmd.AddCommand(createTriggerCmd)
+	triggerCmd.AddCommand(listTriggersCmd)
+	triggerCmd.AddCommand(enableTriggerCmd)
+	triggerCmd.AddCommand(disableTriggerCmd)
+	triggerCmd.AddCommand(deleteTriggerCmd)
+
+	// Flags for create trigger
+	createTriggerCmd.Flags().String("user-id", "", "User ID")
+	createTriggerCmd.Flags().String("source", "", "Pipeline whose runs fire the trigger")
+	createTriggerCmd.Flags().String("target", "", "Pipeline the trigger starts")
+	createTriggerCmd.Flags().String("on", "completed", "Event to fire on: completed, failed, cancelled or finished")
+	createTriggerCmd.Flags().Bool("pass-output", false, "Pass the output of the source run as input; needs --on completed")
+	createTriggerCmd.Flags().String("input", "", "Input of the target run unless --pass-output is set")
+	createTriggerCmd.MarkFlagRequired("user-id")
+	createTriggerCmd.MarkFlagRequired("source")
+	createTriggerCmd.MarkFlagRequired("target")
+
+	// Flags for list triggers
+	listTriggersCmd.Flags().String("pipeline-id", "", "Pipeline ID")
+	listTriggersCmd.MarkFlagRequired("pipeline-id")
+
+	// Flags for enable, disable and delete trigger
+	for _, c := range []*cobra.Command{enableTriggerCmd, disableTriggerCmd, deleteTriggerCmd} {
+		c.Flags().String("trigger-id", "", "Trigger ID")
+		c.MarkFlagRequired("trigger-id")
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/worker"
+	"github.com/spf13/cobra"
+)
+
+// Root worker command
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "Inspect remote stage workers",
+}
+
+// ✅ List Workers Command
+var listWorkersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the workers registered with the server",
+	Run: func(cmd *cobra.Command, args []string) {
+		// 🔹 Get gRPC connection
+		conn, ctx, cancel := GetGRPCConnection()
+		defer conn.Close()
+		defer cancel()
+
+		client := proto.NewWorkerServiceClient(conn)
+
+		resp, err := client.ListWorkers(ctx, &proto.ListWorkersRequest{})
+		if err != nil {
+			log.Fatalf("Failed to list workers: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "WORKER\tNAME\tSTAGE TYPES\tRUNNING\tLAST HEARTBEAT")
+		for _, wk := range resp.Workers {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s ago\n", wk.WorkerId, wk.Name, strings.Join(wk.StageTypes, ","),
+				wk.Running, wk.Capacity, time.Since(wk.LastHeartbeat.AsTime()).Round(time.Second))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	workerCmd.AddCommand(listWorkersCmd)
+}
@@ -3,44 +3,55 @@ package main
 import (
 	"log"
 	"net"
+	"os"
 	"sync"
 
-	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/auth"
-	pipeline_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/pipeline"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/adapters/primary"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/adapters/secondary"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/reflection"
 )
 
-func startGRPCServer(authService *services.AuthService, pipelineService *services.PipelineService, wg *sync.WaitGroup) {
+func startGRPCServer(authService *services.AuthService, pipelineService *services.PipelineService, studyService *services.StudyService, bottleneckService *services.BottleneckService, templateService *services.TemplateService, scheduleService *services.ScheduleService, triggerService *services.TriggerService, idempotencyService *services.IdempotencyService, analyticsService *services.AnalyticsService, workerPool *services.WorkerPool, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// Create gRPC server
-	grpcServer := grpc.NewServer()
-	authServer := &primary.AuthServer{AuthService: authService}
-	pipelineServer := &primary.PipelineServer{Service: pipelineService}
-
-	// Register gRPC services
-	proto.RegisterAuthServiceServer(grpcServer, authServer)
-	pipeline_proto.RegisterPipelineServiceServer(grpcServer, pipelineServer)
-	reflection.Register(grpcServer)
+	grpcServer := primary.NewGRPCServer(authService, pipelineService, studyService, bottleneckService, templateService, scheduleService, triggerService, idempotencyService, analyticsService, workerPool)
 
 	// Start gRPC server
-	listener, err := net.Listen("tcp", ":50051")
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = primary.DefaultGRPCPort
+	}
+	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
-		log.Fatalf("❌ Failed to listen on port 50051: %v", err)
+		log.Fatalf("❌ Failed to listen on port %s: %v", port, err)
 	}
 
-	log.Println("🚀 Starting gRPC server on port 50051...")
+	// gRPC cannot serve /metrics itself, so it gets a plain HTTP listener
+	metricsPort := os.Getenv("METRICS_PORT")
+	if metricsPort == "" {
+		metricsPort = primary.DefaultMetricsPort
+	}
+	go func() {
+		log.Printf("🚀 Serving metrics on port %s...", metricsPort)
+		if err := primary.ServeMetrics(metricsPort); err != nil {
+			log.Printf("❌ Failed to serve metrics: %v", err)
+		}
+	}()
+
+	log.Printf("🚀 Starting gRPC server on port %s...", port)
 	if err := grpcServer.Serve(listener); err != nil {
 		log.Fatalf("❌ Failed to start gRPC server: %v", err)
 	}
 }
 
+// main serves gRPC only. cmd/api-server serves gRPC next to REST on one
+// PipelineService, which is what the dashboard needs to see CLI-started runs;
+// this binary is for deployments without the REST API. It may share the
+// database with REST API replicas: pipeline leases keep them from running
+// the same pipeline twice.
 func main() {
 	// Initialize database
 	secondary.InitDatabase()
@@ -52,13 +63,46 @@ func main() {
 
 	// Initialize services
 	authService := services.NewAuthService(dbRepo)
-	pipelineService := services.NewPipelineService(dbRepo, sseManager) // gRPC does not need SSE
+	pipelineService := services.NewPipelineService(dbRepo, dbRepo, sseManager)
+	pipelineService.Instance = services.InstanceName("grpc-server")
+	studyService := services.NewStudyService(dbRepo, pipelineService)
+	bottleneckService := services.NewBottleneckService(pipelineService, dbRepo)
+	templateService := services.NewTemplateService(dbRepo, pipelineService)
+	scheduleService := services.NewScheduleService(dbRepo, pipelineService)
+	triggerService := services.NewTriggerService(dbRepo, pipelineService)
+	idempotencyService := services.NewIdempotencyService(dbRepo, services.IdempotencyTTLFromEnv())
+	analyticsService := services.NewAnalyticsService(dbRepo)
+
+	// Runs that end fire the triggers of their pipeline
+	pipelineService.OnRunEnded = triggerService.HandleRunEnded
+
+	// Pipelines by status are counted in the database on every scrape of /metrics
+	utils.RegisterPipelineStatus(pipelineService.CountPipelinesByStatus)
+
+	// Stages run in-process unless STAGE_EXECUTOR=remote hands them to worker processes
+	workerPool := services.NewWorkerPool(domain.LocalExecutor{}, sseManager)
+	if services.RemoteExecutionFromEnv() {
+		log.Println("🔹 Dispatching stages to remote workers")
+		pipelineService.Executor = workerPool
+	}
+
+	// Pick up the runs this server left behind when it stopped, then keep the
+	// leases of its runs alive and take over those of replicas that died
+	recoveryPolicy := services.RecoveryPolicyFromEnv()
+	if err := pipelineService.RecoverRuns(recoveryPolicy); err != nil {
+		log.Printf("❌ Failed to recover orphaned runs: %v", err)
+	}
+	go pipelineService.MaintainLeases(recoveryPolicy)
+
+	// Fire due pipeline schedules; replicas claim each fire time in the database
+	go scheduleService.RunScheduler()
+	go idempotencyService.PurgeExpiredKeys()
 
 	var wg sync.WaitGroup
 	wg.Add(1) // Only 1 (gRPC)
 
 	// Start gRPC server
-	go startGRPCServer(authService, pipelineService, &wg)
+	go startGRPCServer(authService, pipelineService, studyService, bottleneckService, templateService, scheduleService, triggerService, idempotencyService, analyticsService, workerPool, &wg)
 
 	// Wait for server
 	wg.Wait()
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/worker"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/adapters/primary"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pullWait is how long one PullTask call waits for work
+const pullWait = 30 * time.Second
+
+// resolveInterval is how often the worker looks up the server replicas again
+const resolveInterval = 30 * time.Second
+
+// runningTask is a task the worker is executing
+type runningTask struct {
+	task    domain.StageTask
+	started time.Time
+	cancel  context.CancelFunc
+}
+
+// worker pulls stage tasks from one server and runs them on the wall clock;
+// the process runs one per server replica
+type worker struct {
+	ctx        context.Context // Done once the replica is gone
+	server     string
+	client     proto.WorkerServiceClient
+	name       string
+	stageTypes []string
+	capacity   int
+	sse        *utils.SSEManager
+
+	registerMu sync.Mutex
+	mu         sync.Mutex
+	workerID   string
+	interval   time.Duration
+	tasks      map[string]*runningTask
+}
+
+func main() {
+	hostname, _ := os.Hostname()
+	serverURL := flag.String("server", envOr("WORKER_GRPC_URL", "localhost:50051"), "gRPC address of the orchestrating servers")
+	name := flag.String("name", envOr("WORKER_NAME", hostname), "Worker name shown in the worker list")
+	types := flag.String("types", envOr("WORKER_STAGE_TYPES", domain.DefaultStageType), "Comma-separated stage types this worker runs")
+	capacity := flag.Int("capacity", envInt("WORKER_CAPACITY", 1), "Number of stages run at once per server")
+	flag.Parse()
+
+	host, port, err := net.SplitHostPort(*serverURL)
+	if err != nil {
+		log.Fatalf("❌ Invalid server address %q: %v", *serverURL, err)
+	}
+
+	// Every server replica keeps its own worker pool, so the worker serves
+	// each address the server name resolves to, e.g. the pods behind a
+	// headless Service, and follows replicas as they come and go
+	sessions := make(map[string]context.CancelFunc)
+	for {
+		targets, err := serverTargets(host, port)
+		if err != nil {
+			log.Printf("[ERROR] Failed to resolve %s: %v", host, err)
+		}
+		seen := make(map[string]bool, len(targets))
+		for _, target := range targets {
+			seen[target] = true
+			if _, ok := sessions[target]; ok {
+				continue
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			sessions[target] = cancel
+			go serve(ctx, target, *name, splitTypes(*types), *capacity)
+		}
+		if err == nil {
+			for target, cancel := range sessions {
+				if !seen[target] {
+					log.Printf("Server %s is gone, leaving it", target)
+					cancel()
+					delete(sessions, target)
+				}
+			}
+		}
+		time.Sleep(resolveInterval)
+	}
+}
+
+// serverTargets resolves the server name to one address per replica; the
+// loopback addresses of a local server count once
+func serverTargets(host string, port string) ([]string, error) {
+	addrs, err := net.LookupHost(host)
+	if err != nil {
+		return nil, err
+	}
+	var targets []string
+	loopback := false
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.IsLoopback() {
+			if loopback {
+				continue
+			}
+			loopback = true
+		}
+		targets = append(targets, net.JoinHostPort(addr, port))
+	}
+	return targets, nil
+}
+
+// serve registers with one server replica and runs its tasks until ctx is done
+func serve(ctx context.Context, target string, name string, stageTypes []string, capacity int) {
+	conn, err := grpc.Dial(target, grpc.WithInsecure())
+	if err != nil {
+		log.Printf("[ERROR] Failed to connect to gRPC server %s: %v", target, err)
+		return
+	}
+	defer conn.Close()
+
+	w := &worker{
+		ctx:        ctx,
+		server:     target,
+		client:     proto.NewWorkerServiceClient(conn),
+		name:       name,
+		stageTypes: stageTypes,
+		capacity:   capacity,
+		sse:        utils.NewSSEManager(), // Stage updates are reported to the server, not streamed from here
+		tasks:      make(map[string]*runningTask),
+	}
+	for {
+		err := w.register()
+		if err == nil {
+			break
+		}
+		log.Printf("[ERROR] Failed to register worker with %s: %v", target, err)
+		if !w.wait(5 * time.Second) {
+			return
+		}
+	}
+
+	go w.heartbeat()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.capacity; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.pullLoop()
+		}()
+	}
+	wg.Wait()
+}
+
+// wait sleeps for d and reports whether the server is still served
+func (w *worker) wait(d time.Duration) bool {
+	select {
+	case <-w.ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// register announces the worker; it is called again when the server forgot it
+func (w *worker) register() error {
+	ctx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := w.client.RegisterWorker(ctx, &proto.RegisterWorkerRequest{
+		Name:       w.name,
+		StageTypes: w.stageTypes,
+		Capacity:   int32(w.capacity),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.workerID = resp.WorkerId
+	w.interval = time.Duration(resp.HeartbeatIntervalSeconds) * time.Second
+	w.mu.Unlock()
+
+	log.Printf("🚀 Worker %s registered with %s as %s for stage types %v", w.name, w.server, resp.WorkerId, w.stageTypes)
+	return nil
+}
+
+func (w *worker) id() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.workerID
+}
+
+// pullLoop runs one task at a time for as long as the server is served
+func (w *worker) pullLoop() {
+	for w.ctx.Err() == nil {
+		workerID := w.id()
+		ctx, cancel := context.WithTimeout(w.ctx, pullWait+10*time.Second)
+		resp, err := w.client.PullTask(ctx, &proto.PullTaskRequest{
+			WorkerId:    workerID,
+			WaitSeconds: int32(pullWait / time.Second),
+		})
+		cancel()
+
+		if err != nil {
+			w.handleError("pull task", workerID, err)
+			continue
+		}
+		if !resp.HasTask {
+			continue
+		}
+
+		task, err := primary.FromProtoTask(resp.Task)
+		if err != nil {
+			log.Printf("[ERROR] Invalid task %s: %v", resp.Task.TaskId, err)
+			w.report(resp.Task.TaskId, nil, time.Duration(resp.Task.OffsetNs), err)
+			continue
+		}
+		w.run(task)
+	}
+}
+
+// run executes a task and reports its outcome
+func (w *worker) run(task domain.StageTask) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+
+	taskID := task.TaskID.String()
+	w.mu.Lock()
+	w.tasks[taskID] = &runningTask{task: task, started: time.Now(), cancel: cancel}
+	w.mu.Unlock()
+
+	log.Printf("Running stage %s (%s) of pipeline %s", task.StageID, task.Type, task.PipelineID)
+	output, offset, err := domain.RunStageTask(ctx, task, domain.RealClock{}, w.sse)
+
+	w.mu.Lock()
+	delete(w.tasks, taskID)
+	w.mu.Unlock()
+
+	w.report(taskID, output, offset, err)
+}
+
+func (w *worker) report(taskID string, output interface{}, offset time.Duration, taskErr error) {
+	workerID := w.id()
+	req := &proto.ReportResultRequest{
+		WorkerId: workerID,
+		TaskId:   taskID,
+		OffsetNs: int64(offset),
+	}
+	if taskErr != nil {
+		req.Error = taskErr.Error()
+	} else {
+		encoded, err := json.Marshal(output)
+		if err != nil {
+			req.Error = err.Error()
+		} else {
+			req.OutputJson = string(encoded)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	if _, err := w.client.ReportResult(ctx, req); err != nil {
+		w.handleError("report result", workerID, err)
+	}
+}
+
+// heartbeat streams the worker's liveness and the estimated progress of its
+// tasks, and cancels tasks the server withdrew
+func (w *worker) heartbeat() {
+	for w.ctx.Err() == nil {
+		stream, err := w.client.Heartbeat(w.ctx)
+		if err != nil {
+			w.handleError("open heartbeat stream", w.id(), err)
+			continue
+		}
+
+		for {
+			req := w.progress()
+			if err := stream.Send(req); err != nil {
+				break
+			}
+			resp, err := stream.Recv()
+			if err != nil {
+				w.handleError("heartbeat", req.WorkerId, err)
+				break
+			}
+			w.cancelTasks(resp.CancelledTaskIds)
+
+			w.mu.Lock()
+			interval := w.interval
+			w.mu.Unlock()
+			if !w.wait(interval) {
+				return
+			}
+		}
+	}
+}
+
+// progress estimates how far each task is from its stage's mean processing time
+func (w *worker) progress() *proto.HeartbeatRequest {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	req := &proto.HeartbeatRequest{WorkerId: w.workerID}
+	for taskID, t := range w.tasks {
+		progress := 0.99
+		if mean := t.task.Spec.MeanSeconds; mean > 0 {
+			if p := time.Since(t.started).Seconds() / mean; p < progress {
+				progress = p
+			}
+		}
+		req.Tasks = append(req.Tasks, &proto.TaskProgress{TaskId: taskID, Progress: progress})
+	}
+	return req
+}
+
+func (w *worker) cancelTasks(taskIDs []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, id := range taskIDs {
+		if t, ok := w.tasks[id]; ok {
+			log.Printf("Cancelling task %s, its pipeline was cancelled", id)
+			t.cancel()
+		}
+	}
+}
+
+// handleError re-registers when the server no longer knows workerID and
+// backs off otherwise; concurrent callers register only once
+func (w *worker) handleError(action string, workerID string, err error) {
+	if status.Code(err) == codes.NotFound {
+		w.registerMu.Lock()
+		defer w.registerMu.Unlock()
+		if w.id() != workerID {
+			return
+		}
+		log.Printf("[WARN] Server does not know this worker, registering again")
+		if err := w.register(); err == nil {
+			return
+		}
+	} else if w.ctx.Err() == nil {
+		log.Printf("[ERROR] Failed to %s on %s: %v", action, w.server, err)
+	}
+	w.wait(time.Second)
+}
+
+func splitTypes(s string) []string {
+	var types []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
@@ -0,0 +1,115 @@
+package primary
+
+import (
+	"context"
+	"errors"
+
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/analytics"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AnalyticsServer implements the gRPC analytics service
+type AnalyticsServer struct {
+	proto.UnimplementedAnalyticsServiceServer
+	Service *services.AnalyticsService
+}
+
+// GetRunsPerDay counts the runs queued per day and status
+func (s *AnalyticsServer) GetRunsPerDay(ctx context.Context, req *proto.AnalyticsRequest) (*proto.RunsPerDayResponse, error) {
+	report, err := s.Service.GetRunsPerDay(toAnalyticsRequest(req))
+	if err != nil {
+		return nil, analyticsError(err)
+	}
+
+	resp := &proto.RunsPerDayResponse{From: timestamppb.New(report.From), To: timestamppb.New(report.To)}
+	for _, day := range report.Days {
+		byStatus := make(map[string]int32, len(day.ByStatus))
+		for s, n := range day.ByStatus {
+			byStatus[s] = int32(n)
+		}
+		resp.Days = append(resp.Days, &proto.DayRuns{Day: day.Day, Total: int32(day.Total), ByStatus: byStatus})
+	}
+	return resp, nil
+}
+
+// GetRunOutcomes returns the success, failure and cancel rates of runs
+func (s *AnalyticsServer) GetRunOutcomes(ctx context.Context, req *proto.AnalyticsRequest) (*proto.RunOutcomesResponse, error) {
+	outcomes, err := s.Service.GetRunOutcomes(toAnalyticsRequest(req))
+	if err != nil {
+		return nil, analyticsError(err)
+	}
+
+	return &proto.RunOutcomesResponse{
+		From:        timestamppb.New(outcomes.From),
+		To:          timestamppb.New(outcomes.To),
+		Total:       int32(outcomes.Total),
+		Completed:   int32(outcomes.Completed),
+		Failed:      int32(outcomes.Failed),
+		Cancelled:   int32(outcomes.Cancelled),
+		Interrupted: int32(outcomes.Interrupted),
+		Active:      int32(outcomes.Active),
+		SuccessRate: outcomes.SuccessRate,
+		FailureRate: outcomes.FailureRate,
+		CancelRate:  outcomes.CancelRate,
+	}, nil
+}
+
+// GetDurations returns the mean and p95 durations per pipeline mode and stage
+func (s *AnalyticsServer) GetDurations(ctx context.Context, req *proto.AnalyticsRequest) (*proto.DurationsResponse, error) {
+	report, err := s.Service.GetDurations(toAnalyticsRequest(req))
+	if err != nil {
+		return nil, analyticsError(err)
+	}
+
+	return &proto.DurationsResponse{
+		From:   timestamppb.New(report.From),
+		To:     timestamppb.New(report.To),
+		Modes:  toProtoDurations(report.Modes),
+		Stages: toProtoDurations(report.Stages),
+	}, nil
+}
+
+// GetTopErrors returns the most frequent error messages of failed runs
+func (s *AnalyticsServer) GetTopErrors(ctx context.Context, req *proto.AnalyticsRequest) (*proto.TopErrorsResponse, error) {
+	report, err := s.Service.GetTopErrors(toAnalyticsRequest(req), int(req.Limit))
+	if err != nil {
+		return nil, analyticsError(err)
+	}
+
+	resp := &proto.TopErrorsResponse{From: timestamppb.New(report.From), To: timestamppb.New(report.To)}
+	for _, e := range report.Errors {
+		resp.Errors = append(resp.Errors, &proto.ErrorFrequency{Message: e.Message, Count: int32(e.Count), LastSeen: timestamppb.New(e.LastSeen)})
+	}
+	return resp, nil
+}
+
+func toAnalyticsRequest(req *proto.AnalyticsRequest) services.AnalyticsRequest {
+	analyticsReq := services.AnalyticsRequest{UserID: req.UserId}
+	if req.From != nil {
+		t := req.From.AsTime()
+		analyticsReq.From = &t
+	}
+	if req.To != nil {
+		t := req.To.AsTime()
+		analyticsReq.To = &t
+	}
+	return analyticsReq
+}
+
+func toProtoDurations(summaries []services.DurationSummary) []*proto.DurationSummary {
+	durations := make([]*proto.DurationSummary, 0, len(summaries))
+	for _, d := range summaries {
+		durations = append(durations, &proto.DurationSummary{Name: d.Name, Count: int32(d.Count), MeanSeconds: d.MeanSeconds, P95Seconds: d.P95Seconds})
+	}
+	return durations
+}
+
+func analyticsError(err error) error {
+	if errors.Is(err, services.ErrInvalidAnalyticsQuery) {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "Failed to compute analytics: %v", err)
+}
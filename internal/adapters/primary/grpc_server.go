@@ -0,0 +1,43 @@
+package primary
+
+import (
+	analytics_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/analytics"
+	auth_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/auth"
+	pipeline_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/pipeline"
+	schedule_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/schedule"
+	study_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/study"
+	template_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/template"
+	trigger_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/trigger"
+	worker_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/worker"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// DefaultGRPCPort is the port the gRPC services listen on unless GRPC_PORT is set
+const DefaultGRPCPort = "50051"
+
+// NewGRPCServer registers every gRPC service on one server. The services are
+// shared with whatever else the process serves, so pipelines started over gRPC
+// use the same orchestrators, queue and SSE stream as the REST API. Create
+// and start calls honour idempotency keys, see IdempotencyInterceptor, and
+// every call is counted and timed, see MetricsInterceptor.
+func NewGRPCServer(authService *services.AuthService, pipelineService *services.PipelineService, studyService *services.StudyService, bottleneckService *services.BottleneckService, templateService *services.TemplateService, scheduleService *services.ScheduleService, triggerService *services.TriggerService, idempotencyService *services.IdempotencyService, analyticsService *services.AnalyticsService, workerPool *services.WorkerPool) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(MetricsInterceptor(), IdempotencyInterceptor(idempotencyService)),
+		grpc.StreamInterceptor(MetricsStreamInterceptor()),
+	)
+
+	auth_proto.RegisterAuthServiceServer(grpcServer, &AuthServer{AuthService: authService})
+	pipeline_proto.RegisterPipelineServiceServer(grpcServer, &PipelineServer{Service: pipelineService, Bottlenecks: bottleneckService})
+	study_proto.RegisterStudyServiceServer(grpcServer, &StudyServer{Service: studyService})
+	template_proto.RegisterTemplateServiceServer(grpcServer, &TemplateServer{Service: templateService})
+	schedule_proto.RegisterScheduleServiceServer(grpcServer, &ScheduleServer{Service: scheduleService})
+	trigger_proto.RegisterTriggerServiceServer(grpcServer, &TriggerServer{Service: triggerService})
+	analytics_proto.RegisterAnalyticsServiceServer(grpcServer, &AnalyticsServer{Service: analyticsService})
+	worker_proto.RegisterWorkerServiceServer(grpcServer, &WorkerServer{Pool: workerPool})
+	reflection.Register(grpcServer)
+
+	return grpcServer
+}
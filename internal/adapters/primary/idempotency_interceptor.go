@@ -0,0 +1,107 @@
+package primary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	pipeline_proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/pipeline"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyMetadataKey is the gRPC counterpart of the Idempotency-Key header
+const IdempotencyMetadataKey = "idempotency-key"
+
+// idempotentMethods are the RPCs that honour idempotency keys, with a
+// constructor for the response a replay decodes into
+var idempotentMethods = map[string]func() proto.Message{
+	pipeline_proto.PipelineService_CreatePipeline_FullMethodName: func() proto.Message { return &pipeline_proto.CreatePipelineResponse{} },
+	pipeline_proto.PipelineService_StartPipeline_FullMethodName:  func() proto.Message { return &pipeline_proto.StartPipelineResponse{} },
+}
+
+// idempotentRequest is a request that names the user it is sent for; gRPC
+// has no authentication, so keys are scoped to that user
+type idempotentRequest interface {
+	proto.Message
+	GetUserId() string
+}
+
+// IdempotencyInterceptor replays the stored response to a create or start
+// call repeated with the same idempotency-key metadata for the same user, and
+// fails with AlreadyExists when the key comes with a different request.
+// Successful responses are stored; after an error the key is free for a retry.
+// Calls with an invalid user ID go to the handler, which rejects them.
+func IdempotencyInterceptor(service *services.IdempotencyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		newResponse, ok := idempotentMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(IdempotencyMetadataKey)
+		if len(keys) == 0 {
+			return handler(ctx, req)
+		}
+		key := keys[0]
+		message, ok := req.(idempotentRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+		userID, err := uuid.Parse(message.GetUserId())
+		if err != nil {
+			return handler(ctx, req)
+		}
+		scope := services.IdempotencyScope(info.FullMethod, userID.String())
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+		}
+		stored, err := service.Begin(scope, key, services.RequestFingerprint(info.FullMethod, body))
+		switch {
+		case errors.Is(err, services.ErrInvalidIdempotencyKey):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, services.ErrIdempotencyMismatch):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, services.ErrIdempotencyInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "Failed to check idempotency key: %v", err)
+		case stored != nil:
+			resp := newResponse()
+			if err := protojson.Unmarshal(stored.Body, resp); err != nil {
+				return nil, status.Errorf(codes.Internal, "Failed to decode stored response: %v", err)
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+			return resp, nil
+		}
+
+		// The key is also released if the handler panics
+		completed := false
+		defer func() {
+			if !completed {
+				service.Release(scope, key)
+			}
+		}()
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		data, err := protojson.Marshal(resp.(proto.Message))
+		if err != nil {
+			return resp, nil
+		}
+		service.Complete(scope, key, http.StatusOK, data)
+		completed = true
+		return resp, nil
+	}
+}
@@ -0,0 +1,45 @@
+package primary
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMetricsPort is the port a standalone gRPC server serves /metrics on
+// unless METRICS_PORT is set
+const DefaultMetricsPort = "9090"
+
+// MetricsInterceptor counts and times unary calls by method and status code
+func MetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		utils.GRPCRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		utils.GRPCRequestDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor counts streams by method and status code once
+// they end; worker heartbeats last as long as the worker, so they are not timed
+func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		utils.GRPCRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return err
+	}
+}
+
+// ServeMetrics serves /metrics over plain HTTP on port, for gRPC servers
+// that run without the REST API
+func ServeMetrics(port string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", utils.MetricsHandler())
+	return http.ListenAndServe(":"+port, mux)
+}
@@ -2,22 +2,28 @@ package primary
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/google/uuid"
 	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/pipeline"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	// "google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // PipelineServer implements the gRPC pipeline service
 type PipelineServer struct {
 	proto.UnimplementedPipelineServiceServer
-	Service *services.PipelineService
+	Service     *services.PipelineService
+	Bottlenecks *services.BottleneckService
 }
 
 // CreatePipeline handles gRPC pipeline creation
@@ -27,8 +33,31 @@ func (s *PipelineServer) CreatePipeline(ctx context.Context, req *proto.CreatePi
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
 	}
 
-	pipelineID, err := s.Service.CreatePipeline(userID, int(req.Stages), req.IsParallel)
+	var specs []domain.StageSpec
+	for i, spec := range req.StageSpecs {
+		stageSpec := domain.StageSpec{
+			Name:          spec.Name,
+			MeanSeconds:   spec.MeanSeconds,
+			StdDevSeconds: spec.StddevSeconds,
+			Yield:         spec.Yield,
+			Type:          spec.StageType,
+		}
+		if spec.CalendarId != "" {
+			calendarID, err := uuid.Parse(spec.CalendarId)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid calendar ID of stage %d: %v", i+1, err)
+			}
+			stageSpec.CalendarID = &calendarID
+		}
+		specs = append(specs, stageSpec)
+	}
+
+	meta := domain.PipelineMetadata{Labels: req.Labels, Annotations: req.Annotations}
+	pipelineID, err := s.Service.CreatePipeline(userID, int(req.Stages), req.IsParallel, specs, meta)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMetadata) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "Failed to create pipeline: %v", err)
 	}
 
@@ -83,20 +112,48 @@ func (s *PipelineServer) StartPipeline(ctx context.Context, req *proto.StartPipe
 		log.Println("[DEBUG] No input data provided, using nil")
 	}
 
-	// Run the pipeline asynchronously
-	go func() {
-		log.Printf("[INFO] Starting pipeline execution: %s", pipelineID)
-		err := s.Service.StartPipeline(context.Background(), userID, pipelineID, input, req.IsParallel)
-		if err != nil {
-			log.Printf("[ERROR] Pipeline execution failed for %s: %v", pipelineID, err)
-		} else {
-			log.Printf("[INFO] Pipeline execution completed successfully: %s", pipelineID)
-		}
-	}()
+	seed := services.ResolveSeed(req.Seed)
+
+	order := &domain.WorkOrder{
+		PipelineID: pipelineID,
+		UserID:     userID,
+		IsParallel: req.IsParallel,
+		Input:      input,
+		Seed:       seed,
+		Priority:   int(req.Priority),
+		OnDone: func(err error) {
+			if errors.Is(err, services.ErrPipelineCancelled) {
+				log.Printf("[INFO] Pipeline execution cancelled: %s (%v)", pipelineID, err)
+			} else if err != nil {
+				log.Printf("[ERROR] Pipeline execution failed for %s: %v", pipelineID, err)
+			} else {
+				log.Printf("[INFO] Pipeline execution completed successfully: %s", pipelineID)
+			}
+		},
+	}
+	if req.DueDate != nil {
+		dueDate := req.DueDate.AsTime()
+		order.DueDate = &dueDate
+	}
+
+	// Queue the pipeline; it runs once a slot is free
+	log.Printf("[INFO] Queueing pipeline execution: %s (seed %d)", pipelineID, seed)
+	position, err := s.Service.EnqueuePipeline(order)
+	switch {
+	case errors.Is(err, services.ErrPipelineNotFound):
+		return nil, status.Errorf(codes.NotFound, "Failed to queue pipeline: %v", err)
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrStatusConflict), errors.Is(err, services.ErrLeaseHeld):
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to queue pipeline: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to queue pipeline: %v", err)
+	}
 
 	// Return response
 	return &proto.StartPipelineResponse{
-		Message: "Pipeline execution started",
+		Message:  "Pipeline queued for execution",
+		Seed:     seed,
+		Position: int32(position),
+		RunId:    order.RunID.String(),
 	}, nil
 }
 
@@ -132,6 +189,12 @@ func (s *PipelineServer) CancelPipeline(ctx context.Context, req *proto.CancelPi
 	}
 
 	err = s.Service.CancelPipeline(pipelineID, userID, req.IsParallel)
+	if errors.Is(err, services.ErrCancelForwarded) || errors.Is(err, services.ErrCancelRequested) {
+		return &proto.CancelPipelineResponse{Message: err.Error()}, nil
+	}
+	if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrStatusConflict) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to cancel pipeline: %v", err)
+	}
 	if err != nil {
 		log.Printf("Error cancelling pipeline %s: %v", pipelineID, err)
 		return nil, status.Errorf(codes.Internal, "Failed to cancel pipeline: %v", err)
@@ -139,3 +202,388 @@ func (s *PipelineServer) CancelPipeline(ctx context.Context, req *proto.CancelPi
 
 	return &proto.CancelPipelineResponse{Message: "Pipeline cancelled"}, nil
 }
+
+// ReplayPipeline re-executes a past run with its seed and configuration and diffs the events
+func (s *PipelineServer) ReplayPipeline(ctx context.Context, req *proto.ReplayPipelineRequest) (*proto.ReplayPipelineResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	result, err := s.Service.ReplayPipeline(ctx, userID, pipelineID)
+	if err != nil {
+		log.Printf("[ERROR] Replay of pipeline %s failed: %v", pipelineID, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to replay pipeline: %v", err)
+	}
+
+	resp := &proto.ReplayPipelineResponse{
+		OriginalPipelineId: result.OriginalPipelineID.String(),
+		OriginalRunId:      result.OriginalRunID.String(),
+		ReplayPipelineId:   result.ReplayPipelineID.String(),
+		ReplayRunId:        result.ReplayRunID.String(),
+		Seed:               result.Seed,
+		Status:             result.Status,
+		Identical:          result.Identical,
+	}
+	for _, e := range result.OriginalEvents {
+		resp.OriginalEvents = append(resp.OriginalEvents, toProtoEvent(&e))
+	}
+	for _, e := range result.ReplayEvents {
+		resp.ReplayEvents = append(resp.ReplayEvents, toProtoEvent(&e))
+	}
+	for _, d := range result.Diffs {
+		resp.Diffs = append(resp.Diffs, &proto.EventDiff{
+			Seq:      int32(d.Seq),
+			Original: toProtoEvent(d.Original),
+			Replay:   toProtoEvent(d.Replay),
+		})
+	}
+	return resp, nil
+}
+
+func toProtoEvent(e *domain.ExecutionEvent) *proto.ExecutionEvent {
+	if e == nil {
+		return nil
+	}
+	return &proto.ExecutionEvent{
+		Type:       e.Type,
+		StageIndex: int32(e.StageIndex),
+		StageId:    e.StageID.String(),
+		Status:     e.Status,
+		OffsetNs:   int64(e.Offset),
+	}
+}
+
+// GetBottlenecks returns the ranked bottleneck report of a pipeline run or study
+func (s *PipelineServer) GetBottlenecks(ctx context.Context, req *proto.GetBottlenecksRequest) (*proto.GetBottlenecksResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	var studyID *uuid.UUID
+	if req.StudyId != "" {
+		id, err := uuid.Parse(req.StudyId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid study ID: %v", err)
+		}
+		studyID = &id
+	}
+
+	report, err := s.Bottlenecks.GetBottlenecks(pipelineID, studyID)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to analyze bottlenecks: %v", err)
+	}
+
+	resp := &proto.GetBottlenecksResponse{
+		PipelineId:      report.PipelineID,
+		StudyId:         report.StudyID,
+		Source:          report.Source,
+		MakespanSeconds: report.MakespanSeconds,
+	}
+	for _, b := range report.Stages {
+		resp.Stages = append(resp.Stages, &proto.StageBottleneck{
+			Rank:              int32(b.Rank),
+			Bottleneck:        b.Bottleneck,
+			Index:             int32(b.Index),
+			Name:              b.Name,
+			Processed:         b.Processed,
+			BusySeconds:       b.BusySeconds,
+			StarvedSeconds:    b.StarvedSeconds,
+			BlockedSeconds:    b.BlockedSeconds,
+			AvgQueueLength:    b.AvgQueueLength,
+			MaxQueueLength:    b.MaxQueueLength,
+			Utilization:       b.Utilization,
+			SuggestedStations: int32(b.SuggestedStations),
+			TargetSeconds:     b.TargetSeconds,
+			Reason:            b.Reason,
+			Suggestion:        b.Suggestion,
+		})
+	}
+	return resp, nil
+}
+
+// GetQueue returns the work-order queue in dispatch order
+func (s *PipelineServer) GetQueue(ctx context.Context, req *proto.GetQueueRequest) (*proto.GetQueueResponse, error) {
+	queue := s.Service.GetQueue()
+
+	resp := &proto.GetQueueResponse{
+		Rule:           queue.Rule,
+		MaxConcurrency: int32(queue.MaxConcurrency),
+		Running:        int32(queue.Running),
+	}
+	for _, order := range queue.Orders {
+		wo := &proto.WorkOrder{
+			Position:          int32(order.Position),
+			PipelineId:        order.PipelineID.String(),
+			RunId:             order.RunID.String(),
+			UserId:            order.UserID.String(),
+			IsParallel:        order.IsParallel,
+			Priority:          int32(order.Priority),
+			ProcessingSeconds: order.ProcessingSeconds,
+			EnqueuedAt:        timestamppb.New(order.EnqueuedAt),
+		}
+		if order.DueDate != nil {
+			wo.DueDate = timestamppb.New(*order.DueDate)
+		}
+		resp.Orders = append(resp.Orders, wo)
+	}
+	return resp, nil
+}
+
+// ListPipelineRuns lists the runs of a pipeline, latest first
+func (s *PipelineServer) ListPipelineRuns(ctx context.Context, req *proto.ListPipelineRunsRequest) (*proto.ListPipelineRunsResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	runs, err := s.Service.GetPipelineRuns(pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to list runs: %v", err)
+	}
+
+	resp := &proto.ListPipelineRunsResponse{}
+	for i := range runs {
+		resp.Runs = append(resp.Runs, toProtoRun(&runs[i]))
+	}
+	return resp, nil
+}
+
+// ListPipelines returns a page of the pipelines of a user
+func (s *PipelineServer) ListPipelines(ctx context.Context, req *proto.ListPipelinesRequest) (*proto.ListPipelinesResponse, error) {
+	listReq := services.PipelineListRequest{
+		UserID:   req.UserId,
+		Statuses: req.Statuses,
+		Mode:     req.Mode,
+		Selector: req.Selector,
+		SortBy:   req.Sort,
+		Order:    req.Order,
+		Limit:    int(req.Limit),
+		Cursor:   req.Cursor,
+	}
+	if req.CreatedAfter != nil {
+		t := req.CreatedAfter.AsTime()
+		listReq.CreatedAfter = &t
+	}
+	if req.CreatedBefore != nil {
+		t := req.CreatedBefore.AsTime()
+		listReq.CreatedBefore = &t
+	}
+
+	page, err := s.Service.ListPipelines(listReq)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidListQuery) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to list pipelines: %v", err)
+	}
+
+	resp := &proto.ListPipelinesResponse{NextCursor: page.NextCursor}
+	for _, p := range page.Pipelines {
+		pipeline := &proto.Pipeline{
+			PipelineId: p.PipelineID.String(),
+			UserId:     p.UserID.String(),
+			Status:     p.Status,
+			IsParallel: p.Definition != nil && p.Definition.IsParallel,
+			CreatedAt:  timestamppb.New(p.CreatedAt),
+			UpdatedAt:  timestamppb.New(p.UpdatedAt),
+		}
+		_ = p.Labels.Unmarshal(&pipeline.Labels)
+		_ = p.Annotations.Unmarshal(&pipeline.Annotations)
+		resp.Pipelines = append(resp.Pipelines, pipeline)
+	}
+	return resp, nil
+}
+
+// GetPipelineMetadata returns the labels and annotations of a pipeline
+func (s *PipelineServer) GetPipelineMetadata(ctx context.Context, req *proto.GetPipelineMetadataRequest) (*proto.PipelineMetadata, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	meta, err := s.Service.GetPipelineMetadata(pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get metadata: %v", err)
+	}
+	return &proto.PipelineMetadata{PipelineId: req.PipelineId, Labels: meta.Labels, Annotations: meta.Annotations}, nil
+}
+
+// UpdatePipelineMetadata sets and removes labels and annotations of a pipeline
+func (s *PipelineServer) UpdatePipelineMetadata(ctx context.Context, req *proto.UpdatePipelineMetadataRequest) (*proto.PipelineMetadata, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	labels := ports.MetadataPatch{Set: req.SetLabels, Remove: req.RemoveLabels}
+	annotations := ports.MetadataPatch{Set: req.SetAnnotations, Remove: req.RemoveAnnotations}
+	meta, err := s.Service.UpdatePipelineMetadata(pipelineID, labels, annotations)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMetadata) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.NotFound, "Failed to update metadata: %v", err)
+	}
+	return &proto.PipelineMetadata{PipelineId: req.PipelineId, Labels: meta.Labels, Annotations: meta.Annotations}, nil
+}
+
+// GetPipelineRun returns one run of a pipeline with its stage logs
+func (s *PipelineServer) GetPipelineRun(ctx context.Context, req *proto.GetPipelineRunRequest) (*proto.PipelineRun, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+	runID, err := uuid.Parse(req.RunId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid run ID: %v", err)
+	}
+
+	run, err := s.Service.GetPipelineRun(pipelineID, runID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get run: %v", err)
+	}
+
+	resp := toProtoRun(run)
+	for i := range run.ExecutionLogs {
+		resp.Logs = append(resp.Logs, toProtoRunLog(&run.ExecutionLogs[i]))
+	}
+	return resp, nil
+}
+
+// GetPipelineResult returns the input and output of the latest run of a pipeline
+func (s *PipelineServer) GetPipelineResult(ctx context.Context, req *proto.GetPipelineResultRequest) (*proto.GetPipelineResultResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	result, err := s.Service.GetPipelineResult(pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to get result: %v", err)
+	}
+
+	resp := &proto.GetPipelineResultResponse{
+		PipelineId: result.PipelineID.String(),
+		RunId:      result.RunID.String(),
+		RunNumber:  int32(result.RunNumber),
+		Status:     result.Status,
+		Input:      toProtoValue(result.Input),
+		Output:     toProtoValue(result.Output),
+		Error:      result.Error,
+	}
+	if result.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*result.FinishedAt)
+	}
+	return resp, nil
+}
+
+// ListPipelineTransitions returns the status history of a pipeline and its runs
+func (s *PipelineServer) ListPipelineTransitions(ctx context.Context, req *proto.ListPipelineTransitionsRequest) (*proto.ListPipelineTransitionsResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	transitions, err := s.Service.GetPipelineTransitions(pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Failed to list transitions: %v", err)
+	}
+
+	resp := &proto.ListPipelineTransitionsResponse{}
+	for _, transition := range transitions {
+		entry := &proto.StatusTransition{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Actor:      transition.Actor,
+			Reason:     transition.Reason,
+			CreatedAt:  timestamppb.New(transition.CreatedAt),
+		}
+		if transition.RunID != nil {
+			entry.RunId = transition.RunID.String()
+		}
+		resp.Transitions = append(resp.Transitions, entry)
+	}
+	return resp, nil
+}
+
+func toProtoRun(run *models.PipelineRun) *proto.PipelineRun {
+	resp := &proto.PipelineRun{
+		RunId:      run.RunID.String(),
+		PipelineId: run.PipelineID.String(),
+		Number:     int32(run.Number),
+		UserId:     run.UserID.String(),
+		Status:     run.Status,
+		Input:      toProtoValue(run.Input),
+		Output:     toProtoValue(run.Output),
+		Error:      run.ErrorMsg,
+		Seed:       run.Seed,
+		CreatedAt:  timestamppb.New(run.CreatedAt),
+	}
+	if run.StartedAt != nil {
+		resp.StartedAt = timestamppb.New(*run.StartedAt)
+	}
+	if run.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	if run.TriggerID != nil && run.TriggeredByRunID != nil {
+		resp.TriggerId = run.TriggerID.String()
+		resp.TriggeredByRunId = run.TriggeredByRunID.String()
+	}
+	var chain domain.TriggerChain
+	if err := run.TriggerChain.Unmarshal(&chain); err == nil {
+		for _, link := range chain {
+			protoLink := &proto.TriggerLink{PipelineId: link.PipelineID.String(), RunId: link.RunID.String()}
+			if link.TriggerID != nil {
+				protoLink.TriggerId = link.TriggerID.String()
+			}
+			resp.TriggerChain = append(resp.TriggerChain, protoLink)
+		}
+	}
+	return resp
+}
+
+// toProtoValue converts stored JSON to a protobuf value; nil when unset or not representable
+func toProtoRunLog(entry *models.ExecutionLog) *proto.RunLog {
+	resp := &proto.RunLog{
+		StageId:    entry.StageID.String(),
+		Status:     entry.Status,
+		Error:      entry.ErrorMsg,
+		Timestamp:  timestamppb.New(entry.Timestamp),
+		LogId:      entry.LogID.String(),
+		StageIndex: int32(entry.StageIndex),
+		StageName:  entry.StageName,
+		Attempt:    int32(entry.Attempt),
+		DurationMs: entry.DurationMs,
+		Input:      toProtoValue(entry.Input),
+		Output:     toProtoValue(entry.Output),
+	}
+	if entry.StartedAt != nil {
+		resp.StartedAt = timestamppb.New(*entry.StartedAt)
+	}
+	if entry.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*entry.FinishedAt)
+	}
+	return resp
+}
+
+func toProtoValue(data models.JSONB) *structpb.Value {
+	if len(data) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := data.Unmarshal(&v); err != nil {
+		return nil
+	}
+	value, err := structpb.NewValue(v)
+	if err != nil {
+		return nil
+	}
+	return value
+}
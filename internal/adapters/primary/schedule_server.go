@@ -0,0 +1,180 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/schedule"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ScheduleServer implements the gRPC pipeline schedule service
+type ScheduleServer struct {
+	proto.UnimplementedScheduleServiceServer
+	Service *services.ScheduleService
+}
+
+// CreateSchedule schedules recurring runs of a pipeline
+func (s *ScheduleServer) CreateSchedule(ctx context.Context, req *proto.CreateScheduleRequest) (*proto.Schedule, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	schedule, err := s.Service.CreateSchedule(userID, pipelineID, fromProtoScheduleSpec(req.Spec))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create schedule: %v", err)
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// ListSchedules lists the schedules of a pipeline or of a user
+func (s *ScheduleServer) ListSchedules(ctx context.Context, req *proto.ListSchedulesRequest) (*proto.ListSchedulesResponse, error) {
+	var pipelineID *uuid.UUID
+	if req.PipelineId != "" {
+		id, err := uuid.Parse(req.PipelineId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+		}
+		pipelineID = &id
+	} else if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "User ID or pipeline ID is required")
+	}
+
+	schedules, err := s.Service.ListSchedules(req.UserId, pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list schedules: %v", err)
+	}
+
+	resp := &proto.ListSchedulesResponse{}
+	for i := range schedules {
+		resp.Schedules = append(resp.Schedules, toProtoSchedule(&schedules[i]))
+	}
+	return resp, nil
+}
+
+// GetSchedule fetches a schedule with its next and last fire
+func (s *ScheduleServer) GetSchedule(ctx context.Context, req *proto.ScheduleIdRequest) (*proto.Schedule, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule ID: %v", err)
+	}
+
+	schedule, err := s.Service.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// UpdateSchedule replaces the settings of a schedule
+func (s *ScheduleServer) UpdateSchedule(ctx context.Context, req *proto.UpdateScheduleRequest) (*proto.Schedule, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule ID: %v", err)
+	}
+
+	schedule, err := s.Service.UpdateSchedule(scheduleID, fromProtoScheduleSpec(req.Spec))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to update schedule: %v", err)
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// PauseSchedule stops a schedule from firing
+func (s *ScheduleServer) PauseSchedule(ctx context.Context, req *proto.ScheduleIdRequest) (*proto.Schedule, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule ID: %v", err)
+	}
+
+	schedule, err := s.Service.PauseSchedule(scheduleID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// ResumeSchedule lets a paused schedule fire again
+func (s *ScheduleServer) ResumeSchedule(ctx context.Context, req *proto.ScheduleIdRequest) (*proto.Schedule, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule ID: %v", err)
+	}
+
+	schedule, err := s.Service.ResumeSchedule(scheduleID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoSchedule(schedule), nil
+}
+
+// DeleteSchedule removes a schedule
+func (s *ScheduleServer) DeleteSchedule(ctx context.Context, req *proto.ScheduleIdRequest) (*proto.DeleteScheduleResponse, error) {
+	scheduleID, err := uuid.Parse(req.ScheduleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid schedule ID: %v", err)
+	}
+
+	if err := s.Service.DeleteSchedule(scheduleID); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.DeleteScheduleResponse{Message: "Schedule deleted"}, nil
+}
+
+func fromProtoScheduleSpec(spec *proto.ScheduleSpec) domain.ScheduleSpec {
+	if spec == nil {
+		return domain.ScheduleSpec{}
+	}
+	var input interface{}
+	if spec.Input != nil {
+		input = spec.Input.AsInterface()
+	}
+	return domain.ScheduleSpec{
+		Name:         spec.Name,
+		Cron:         spec.Cron,
+		Timezone:     spec.Timezone,
+		MissedPolicy: spec.MissedPolicy,
+		Input:        input,
+		Priority:     int(spec.Priority),
+	}
+}
+
+func toProtoSchedule(schedule *models.PipelineSchedule) *proto.Schedule {
+	resp := &proto.Schedule{
+		ScheduleId: schedule.ScheduleID.String(),
+		PipelineId: schedule.PipelineID.String(),
+		UserId:     schedule.UserID.String(),
+		Spec: &proto.ScheduleSpec{
+			Name:         schedule.Name,
+			Cron:         schedule.Cron,
+			Timezone:     schedule.Timezone,
+			MissedPolicy: schedule.MissedPolicy,
+			Input:        toProtoValue(schedule.Input),
+			Priority:     int32(schedule.Priority),
+		},
+		Enabled:     schedule.Enabled,
+		LastError:   schedule.LastError,
+		MissedFires: int32(schedule.MissedFires),
+		CreatedAt:   timestamppb.New(schedule.CreatedAt),
+	}
+	if schedule.NextFireAt != nil {
+		resp.NextFireAt = timestamppb.New(*schedule.NextFireAt)
+	}
+	if schedule.LastFireAt != nil {
+		resp.LastFireAt = timestamppb.New(*schedule.LastFireAt)
+	}
+	if schedule.LastRunID != nil {
+		resp.LastRunId = schedule.LastRunID.String()
+	}
+	return resp
+}
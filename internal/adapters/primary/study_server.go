@@ -0,0 +1,187 @@
+package primary
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/study"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StudyServer implements the gRPC study service
+type StudyServer struct {
+	proto.UnimplementedStudyServiceServer
+	Service *services.StudyService
+}
+
+// RunStudy runs a Monte Carlo study and returns its aggregated results
+func (s *StudyServer) RunStudy(ctx context.Context, req *proto.RunStudyRequest) (*proto.StudyResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	studyReq := services.StudyRequest{
+		UserID:       userID,
+		Name:         req.Name,
+		Definition:   domain.PipelineDefinition{IsParallel: req.IsParallel},
+		StageCount:   int(req.Stages),
+		Replications: int(req.Replications),
+		Units:        int(req.Units),
+		Seed:         req.Seed,
+	}
+	if req.PipelineId != "" {
+		pipelineID, err := uuid.Parse(req.PipelineId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+		}
+		studyReq.PipelineID = &pipelineID
+	}
+	studyReq.Definition.Stages = toDomainSpecs(req.StageSpecs)
+
+	study, err := s.Service.RunStudy(ctx, studyReq)
+	if err != nil {
+		log.Printf("[ERROR] Study failed: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to run study: %v", err)
+	}
+
+	return toStudyResponse(study), nil
+}
+
+// GetStudy retrieves a stored study
+func (s *StudyServer) GetStudy(ctx context.Context, req *proto.GetStudyRequest) (*proto.StudyResponse, error) {
+	studyID, err := uuid.Parse(req.StudyId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid study ID: %v", err)
+	}
+
+	study, err := s.Service.GetStudy(studyID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Study not found: %v", err)
+	}
+
+	return toStudyResponse(study), nil
+}
+
+func toStudyResponse(study *models.Study) *proto.StudyResponse {
+	resp := &proto.StudyResponse{
+		StudyId:      study.StudyID.String(),
+		Status:       study.Status,
+		Replications: int32(study.Replications),
+		Units:        int32(study.Units),
+		Seed:         study.Seed,
+	}
+	for _, m := range study.Metrics {
+		resp.Metrics = append(resp.Metrics, toProtoMetric(m.Metric, domain.MetricSummary{
+			N:       m.N,
+			Mean:    m.Mean,
+			StdDev:  m.StdDev,
+			Min:     m.Min,
+			Max:     m.Max,
+			P5:      m.P5,
+			P50:     m.P50,
+			P95:     m.P95,
+			CILower: m.CILower,
+			CIUpper: m.CIUpper,
+		}))
+	}
+	return resp
+}
+
+func toProtoMetric(metric string, m domain.MetricSummary) *proto.MetricSummary {
+	return &proto.MetricSummary{
+		Metric:  metric,
+		N:       int32(m.N),
+		Mean:    m.Mean,
+		Stddev:  m.StdDev,
+		Min:     m.Min,
+		Max:     m.Max,
+		P5:      m.P5,
+		P50:     m.P50,
+		P95:     m.P95,
+		CiLower: m.CILower,
+		CiUpper: m.CIUpper,
+	}
+}
+
+// CompareScenarios compares the KPIs of two or more scenarios against the first one
+func (s *StudyServer) CompareScenarios(ctx context.Context, req *proto.CompareScenariosRequest) (*proto.CompareScenariosResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	compareReq := services.CompareRequest{
+		UserID:       userID,
+		Replications: int(req.Replications),
+		Units:        int(req.Units),
+		Seed:         req.Seed,
+	}
+	for _, sc := range req.Scenarios {
+		input := services.ScenarioInput{
+			Name:       sc.Name,
+			Definition: domain.PipelineDefinition{IsParallel: sc.IsParallel, Stages: toDomainSpecs(sc.StageSpecs)},
+			StageCount: int(sc.Stages),
+		}
+		if sc.StudyId != "" {
+			studyID, err := uuid.Parse(sc.StudyId)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid study ID: %v", err)
+			}
+			input.StudyID = &studyID
+		}
+		if sc.PipelineId != "" {
+			pipelineID, err := uuid.Parse(sc.PipelineId)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+			}
+			input.PipelineID = &pipelineID
+		}
+		compareReq.Scenarios = append(compareReq.Scenarios, input)
+	}
+
+	comparison, err := s.Service.CompareScenarios(ctx, compareReq)
+	if err != nil {
+		log.Printf("[ERROR] Scenario comparison failed: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to compare scenarios: %v", err)
+	}
+
+	resp := &proto.CompareScenariosResponse{
+		Baseline:        comparison.Baseline,
+		ConfidenceLevel: comparison.ConfidenceLevel,
+	}
+	for _, sc := range comparison.Scenarios {
+		result := &proto.ScenarioComparison{Name: sc.Name, StudyId: sc.StudyID}
+		for _, m := range sc.Metrics {
+			result.Metrics = append(result.Metrics, &proto.MetricComparison{
+				Metric:       m.Metric,
+				Baseline:     toProtoMetric(m.Metric, m.Baseline),
+				Scenario:     toProtoMetric(m.Metric, m.Scenario),
+				Delta:        m.Delta,
+				DeltaPercent: m.DeltaPercent,
+				PValue:       m.PValue,
+				Significant:  m.Significant,
+			})
+		}
+		resp.Scenarios = append(resp.Scenarios, result)
+	}
+	return resp, nil
+}
+
+func toDomainSpecs(specs []*proto.StageSpec) []domain.StageSpec {
+	var out []domain.StageSpec
+	for _, spec := range specs {
+		out = append(out, domain.StageSpec{
+			Name:          spec.Name,
+			MeanSeconds:   spec.MeanSeconds,
+			StdDevSeconds: spec.StddevSeconds,
+			Yield:         spec.Yield,
+		})
+	}
+	return out
+}
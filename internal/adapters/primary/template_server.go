@@ -0,0 +1,158 @@
+package primary
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/template"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TemplateServer implements the gRPC pipeline template service
+type TemplateServer struct {
+	proto.UnimplementedTemplateServiceServer
+	Service *services.TemplateService
+}
+
+// CreateTemplate stores the next version of a template
+func (s *TemplateServer) CreateTemplate(ctx context.Context, req *proto.CreateTemplateRequest) (*proto.Template, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	def := domain.PipelineDefinition{IsParallel: req.IsParallel}
+	for _, spec := range req.Stages {
+		def.Stages = append(def.Stages, domain.StageSpec{
+			Name:          spec.Name,
+			MeanSeconds:   spec.MeanSeconds,
+			StdDevSeconds: spec.StddevSeconds,
+			Yield:         spec.Yield,
+			Type:          spec.StageType,
+		})
+	}
+
+	template, err := s.Service.CreateTemplate(userID, req.Name, req.Description, def, req.Publish)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create template: %v", err)
+	}
+	return toProtoTemplate(template)
+}
+
+// ListTemplates lists all template versions, or those of one template
+func (s *TemplateServer) ListTemplates(ctx context.Context, req *proto.ListTemplatesRequest) (*proto.ListTemplatesResponse, error) {
+	templates, err := s.Service.ListTemplates(req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list templates: %v", err)
+	}
+
+	resp := &proto.ListTemplatesResponse{}
+	for i := range templates {
+		template, err := toProtoTemplate(&templates[i])
+		if err != nil {
+			return nil, err
+		}
+		resp.Templates = append(resp.Templates, template)
+	}
+	return resp, nil
+}
+
+// GetTemplate fetches name@version, or the latest published version of name
+func (s *TemplateServer) GetTemplate(ctx context.Context, req *proto.TemplateRefRequest) (*proto.Template, error) {
+	template, err := s.Service.GetTemplate(req.Ref)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoTemplate(template)
+}
+
+// PublishTemplate makes a draft immutable and available for instantiation
+func (s *TemplateServer) PublishTemplate(ctx context.Context, req *proto.TemplateRefRequest) (*proto.Template, error) {
+	template, err := s.Service.PublishTemplate(req.Ref)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to publish template: %v", err)
+	}
+	return toProtoTemplate(template)
+}
+
+// DeprecateTemplate hides a published version from name@latest
+func (s *TemplateServer) DeprecateTemplate(ctx context.Context, req *proto.TemplateRefRequest) (*proto.Template, error) {
+	template, err := s.Service.DeprecateTemplate(req.Ref)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to deprecate template: %v", err)
+	}
+	return toProtoTemplate(template)
+}
+
+// InstantiateTemplate creates a pipeline from a template version with overrides
+func (s *TemplateServer) InstantiateTemplate(ctx context.Context, req *proto.InstantiateTemplateRequest) (*proto.InstantiateTemplateResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+
+	overrides := domain.TemplateOverrides{IsParallel: req.IsParallel}
+	for _, o := range req.Overrides {
+		if overrides.Stages == nil {
+			overrides.Stages = make(map[string]domain.StageOverride)
+		}
+		overrides.Stages[o.Stage] = domain.StageOverride{
+			MeanSeconds:   o.MeanSeconds,
+			StdDevSeconds: o.StddevSeconds,
+			Yield:         o.Yield,
+			Type:          o.StageType,
+		}
+	}
+
+	instantiation, err := s.Service.InstantiateTemplate(userID, req.Ref, overrides)
+	if err != nil {
+		log.Printf("[ERROR] Instantiating template %s failed: %v", req.Ref, err)
+		return nil, status.Errorf(codes.FailedPrecondition, "Failed to instantiate template: %v", err)
+	}
+
+	return &proto.InstantiateTemplateResponse{
+		PipelineId: instantiation.PipelineID.String(),
+		Template:   instantiation.Template,
+		Deprecated: instantiation.Deprecated,
+	}, nil
+}
+
+func toProtoTemplate(template *models.PipelineTemplate) (*proto.Template, error) {
+	def, err := services.TemplateDefinition(template)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to decode template: %v", err)
+	}
+
+	resp := &proto.Template{
+		TemplateId:  template.TemplateID.String(),
+		Name:        template.Name,
+		Version:     int32(template.Version),
+		Description: template.Description,
+		Status:      template.Status,
+		IsParallel:  def.IsParallel,
+		UserId:      template.UserID.String(),
+		CreatedAt:   timestamppb.New(template.CreatedAt),
+	}
+	for _, spec := range def.Stages {
+		resp.Stages = append(resp.Stages, &proto.StageSpec{
+			Name:          spec.Name,
+			MeanSeconds:   spec.MeanSeconds,
+			StddevSeconds: spec.StdDevSeconds,
+			Yield:         spec.Yield,
+			StageType:     spec.Type,
+		})
+	}
+	if template.PublishedAt != nil {
+		resp.PublishedAt = timestamppb.New(*template.PublishedAt)
+	}
+	if template.DeprecatedAt != nil {
+		resp.DeprecatedAt = timestamppb.New(*template.DeprecatedAt)
+	}
+	return resp, nil
+}
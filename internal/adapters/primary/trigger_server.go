@@ -0,0 +1,137 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/trigger"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TriggerServer implements the gRPC pipeline trigger service
+type TriggerServer struct {
+	proto.UnimplementedTriggerServiceServer
+	Service *services.TriggerService
+}
+
+// CreateTrigger makes the runs of a pipeline start another pipeline when they end
+func (s *TriggerServer) CreateTrigger(ctx context.Context, req *proto.CreateTriggerRequest) (*proto.Trigger, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
+	}
+	sourceID, err := uuid.Parse(req.SourcePipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid source pipeline ID: %v", err)
+	}
+	targetID, err := uuid.Parse(req.TargetPipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid target pipeline ID: %v", err)
+	}
+
+	spec := domain.TriggerSpec{TargetPipelineID: targetID, On: req.On, PassOutput: req.PassOutput}
+	if req.Input != nil {
+		spec.Input = req.Input.AsInterface()
+	}
+	trigger, err := s.Service.CreateTrigger(userID, sourceID, spec)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create trigger: %v", err)
+	}
+	return toProtoTrigger(trigger), nil
+}
+
+// ListTriggers lists the triggers from and to a pipeline
+func (s *TriggerServer) ListTriggers(ctx context.Context, req *proto.ListTriggersRequest) (*proto.ListTriggersResponse, error) {
+	pipelineID, err := uuid.Parse(req.PipelineId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid pipeline ID: %v", err)
+	}
+
+	triggers, err := s.Service.ListTriggers(pipelineID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list triggers: %v", err)
+	}
+
+	resp := &proto.ListTriggersResponse{}
+	for i := range triggers {
+		resp.Triggers = append(resp.Triggers, toProtoTrigger(&triggers[i]))
+	}
+	return resp, nil
+}
+
+// GetTrigger fetches a trigger with its last fire
+func (s *TriggerServer) GetTrigger(ctx context.Context, req *proto.TriggerIdRequest) (*proto.Trigger, error) {
+	triggerID, err := uuid.Parse(req.TriggerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid trigger ID: %v", err)
+	}
+
+	trigger, err := s.Service.GetTrigger(triggerID)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoTrigger(trigger), nil
+}
+
+// EnableTrigger lets a trigger fire again
+func (s *TriggerServer) EnableTrigger(ctx context.Context, req *proto.TriggerIdRequest) (*proto.Trigger, error) {
+	return s.setEnabled(req, true)
+}
+
+// DisableTrigger stops a trigger from firing
+func (s *TriggerServer) DisableTrigger(ctx context.Context, req *proto.TriggerIdRequest) (*proto.Trigger, error) {
+	return s.setEnabled(req, false)
+}
+
+func (s *TriggerServer) setEnabled(req *proto.TriggerIdRequest, enabled bool) (*proto.Trigger, error) {
+	triggerID, err := uuid.Parse(req.TriggerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid trigger ID: %v", err)
+	}
+
+	trigger, err := s.Service.SetTriggerEnabled(triggerID, enabled)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return toProtoTrigger(trigger), nil
+}
+
+// DeleteTrigger removes a trigger
+func (s *TriggerServer) DeleteTrigger(ctx context.Context, req *proto.TriggerIdRequest) (*proto.DeleteTriggerResponse, error) {
+	triggerID, err := uuid.Parse(req.TriggerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid trigger ID: %v", err)
+	}
+
+	if err := s.Service.DeleteTrigger(triggerID); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.DeleteTriggerResponse{Message: "Trigger deleted"}, nil
+}
+
+func toProtoTrigger(trigger *models.PipelineTrigger) *proto.Trigger {
+	resp := &proto.Trigger{
+		TriggerId:        trigger.TriggerID.String(),
+		SourcePipelineId: trigger.SourcePipelineID.String(),
+		TargetPipelineId: trigger.TargetPipelineID.String(),
+		UserId:           trigger.UserID.String(),
+		On:               trigger.On,
+		PassOutput:       trigger.PassOutput,
+		Input:            toProtoValue(trigger.Input),
+		Enabled:          trigger.Enabled,
+		LastError:        trigger.LastError,
+		CreatedAt:        timestamppb.New(trigger.CreatedAt),
+	}
+	if trigger.LastFiredAt != nil {
+		resp.LastFiredAt = timestamppb.New(*trigger.LastFiredAt)
+	}
+	if trigger.LastRunID != nil {
+		resp.LastRunId = trigger.LastRunID.String()
+	}
+	return resp
+}
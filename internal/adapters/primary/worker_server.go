@@ -0,0 +1,230 @@
+package primary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/api/grpc/proto/worker"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxPullWait bounds how long a PullTask call is held open
+const MaxPullWait = 60 * time.Second
+
+// WorkerServer implements the gRPC service remote stage workers talk to
+type WorkerServer struct {
+	proto.UnimplementedWorkerServiceServer
+	Pool *services.WorkerPool
+}
+
+// RegisterWorker adds a worker to the pool
+func (s *WorkerServer) RegisterWorker(ctx context.Context, req *proto.RegisterWorkerRequest) (*proto.RegisterWorkerResponse, error) {
+	worker, err := s.Pool.Register(req.Name, req.StageTypes, int(req.Capacity))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to register worker: %v", err)
+	}
+	return &proto.RegisterWorkerResponse{
+		WorkerId:                 worker.WorkerID.String(),
+		HeartbeatIntervalSeconds: int32(services.HeartbeatInterval / time.Second),
+	}, nil
+}
+
+// PullTask long-polls for a stage task the worker can run
+func (s *WorkerServer) PullTask(ctx context.Context, req *proto.PullTaskRequest) (*proto.PullTaskResponse, error) {
+	workerID, err := uuid.Parse(req.WorkerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid worker ID: %v", err)
+	}
+	wait := time.Duration(req.WaitSeconds) * time.Second
+	if wait <= 0 || wait > MaxPullWait {
+		wait = MaxPullWait
+	}
+
+	task, err := s.Pool.PullTask(ctx, workerID, wait)
+	if err != nil {
+		return nil, workerError(err)
+	}
+	if task == nil {
+		return &proto.PullTaskResponse{}, nil
+	}
+
+	protoTask, err := toProtoTask(task)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode task: %v", err)
+	}
+	return &proto.PullTaskResponse{HasTask: true, Task: protoTask}, nil
+}
+
+// Heartbeat receives heartbeats with task progress and answers each with the
+// tasks the worker should abandon
+func (s *WorkerServer) Heartbeat(stream proto.WorkerService_HeartbeatServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		workerID, err := uuid.Parse(req.WorkerId)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid worker ID: %v", err)
+		}
+		progress := make(map[uuid.UUID]float64, len(req.Tasks))
+		for _, t := range req.Tasks {
+			if taskID, err := uuid.Parse(t.TaskId); err == nil {
+				progress[taskID] = t.Progress
+			}
+		}
+
+		cancelled, err := s.Pool.Heartbeat(workerID, progress)
+		if err != nil {
+			return workerError(err)
+		}
+		resp := &proto.HeartbeatResponse{}
+		for _, id := range cancelled {
+			resp.CancelledTaskIds = append(resp.CancelledTaskIds, id.String())
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// ReportResult completes a task with the worker's output or error
+func (s *WorkerServer) ReportResult(ctx context.Context, req *proto.ReportResultRequest) (*proto.ReportResultResponse, error) {
+	workerID, err := uuid.Parse(req.WorkerId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid worker ID: %v", err)
+	}
+	taskID, err := uuid.Parse(req.TaskId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid task ID: %v", err)
+	}
+
+	result := services.TaskResult{Offset: time.Duration(req.OffsetNs)}
+	if req.Error != "" {
+		result.Err = errors.New(req.Error)
+	} else if req.OutputJson != "" {
+		if err := json.Unmarshal([]byte(req.OutputJson), &result.Output); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid output: %v", err)
+		}
+	}
+
+	accepted, err := s.Pool.ReportResult(workerID, taskID, result)
+	if err != nil {
+		return nil, workerError(err)
+	}
+	if !accepted {
+		log.Printf("[WARN] Ignoring result of task %s from worker %s", taskID, workerID)
+	}
+	return &proto.ReportResultResponse{Accepted: accepted}, nil
+}
+
+// ListWorkers returns the registered workers
+func (s *WorkerServer) ListWorkers(ctx context.Context, req *proto.ListWorkersRequest) (*proto.ListWorkersResponse, error) {
+	resp := &proto.ListWorkersResponse{}
+	for _, w := range s.Pool.ListWorkers() {
+		resp.Workers = append(resp.Workers, &proto.Worker{
+			WorkerId:      w.WorkerID.String(),
+			Name:          w.Name,
+			StageTypes:    w.StageTypes,
+			Capacity:      int32(w.Capacity),
+			Running:       int32(w.Running),
+			RegisteredAt:  timestamppb.New(w.RegisteredAt),
+			LastHeartbeat: timestamppb.New(w.LastHeartbeat),
+		})
+	}
+	return resp, nil
+}
+
+func workerError(err error) error {
+	if errors.Is(err, services.ErrWorkerNotRegistered) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toProtoTask(task *domain.StageTask) (*proto.StageTask, error) {
+	input, err := json.Marshal(task.Input)
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.StageTask{
+		TaskId:     task.TaskID.String(),
+		PipelineId: task.PipelineID.String(),
+		StageId:    task.StageID.String(),
+		StageIndex: int32(task.StageIndex),
+		StageType:  task.Type,
+		Spec: &proto.StageSpec{
+			Name:          task.Spec.Name,
+			MeanSeconds:   task.Spec.MeanSeconds,
+			StddevSeconds: task.Spec.StdDevSeconds,
+			Yield:         task.Spec.Yield,
+			StageType:     task.Spec.Type,
+		},
+		InputJson: string(input),
+		Seed:      task.Seed,
+		Start:     timestamppb.New(task.Start),
+		OffsetNs:  int64(task.Offset),
+	}
+	if task.Calendar != nil {
+		calendar, err := json.Marshal(task.Calendar)
+		if err != nil {
+			return nil, err
+		}
+		resp.CalendarJson = string(calendar)
+	}
+	return resp, nil
+}
+
+// FromProtoTask decodes a task received by a worker
+func FromProtoTask(task *proto.StageTask) (domain.StageTask, error) {
+	var out domain.StageTask
+	var err error
+	if out.TaskID, err = uuid.Parse(task.TaskId); err != nil {
+		return out, err
+	}
+	if out.PipelineID, err = uuid.Parse(task.PipelineId); err != nil {
+		return out, err
+	}
+	if out.StageID, err = uuid.Parse(task.StageId); err != nil {
+		return out, err
+	}
+	out.StageIndex = int(task.StageIndex)
+	out.Type = task.StageType
+	if task.Spec != nil {
+		out.Spec = domain.StageSpec{
+			Name:          task.Spec.Name,
+			MeanSeconds:   task.Spec.MeanSeconds,
+			StdDevSeconds: task.Spec.StddevSeconds,
+			Yield:         task.Spec.Yield,
+			Type:          task.Spec.StageType,
+		}
+	}
+	if task.InputJson != "" {
+		if err := json.Unmarshal([]byte(task.InputJson), &out.Input); err != nil {
+			return out, err
+		}
+	}
+	out.Seed = task.Seed
+	out.Start = task.Start.AsTime()
+	out.Offset = time.Duration(task.OffsetNs)
+	if task.CalendarJson != "" {
+		out.Calendar = &domain.Calendar{}
+		if err := json.Unmarshal([]byte(task.CalendarJson), out.Calendar); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
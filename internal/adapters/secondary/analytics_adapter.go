@@ -0,0 +1,112 @@
+package secondary
+
+import (
+	"fmt"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+var _ ports.AnalyticsRepository = (*DatabaseAdapter)(nil)
+
+// runScope restricts the runs aliased r to those an analytics query selects
+func runScope(query ports.AnalyticsQuery) (string, []interface{}) {
+	scope := "r.created_at >= ? AND r.created_at < ?"
+	args := []interface{}{query.From, query.To}
+	if query.UserID != nil {
+		scope += " AND r.user_id = ?"
+		args = append(args, *query.UserID)
+	}
+	return scope, args
+}
+
+// CountRunsPerDay counts the runs queued per UTC day and status
+func (d *DatabaseAdapter) CountRunsPerDay(query ports.AnalyticsQuery) ([]models.RunDayCount, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	scope, args := runScope(query)
+	var counts []models.RunDayCount
+	err := d.DB.Raw(fmt.Sprintf(`SELECT date_trunc('day', r.created_at AT TIME ZONE 'UTC') AS day, r.status, COUNT(*) AS runs
+		FROM pipeline_runs r
+		WHERE %s
+		GROUP BY 1, 2
+		ORDER BY 1, 2`, scope), args...).Scan(&counts).Error
+	return counts, err
+}
+
+// CountRunsByStatus counts the runs per status
+func (d *DatabaseAdapter) CountRunsByStatus(query ports.AnalyticsQuery) ([]models.RunStatusCount, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	scope, args := runScope(query)
+	var counts []models.RunStatusCount
+	err := d.DB.Raw(fmt.Sprintf(`SELECT r.status, COUNT(*) AS runs
+		FROM pipeline_runs r
+		WHERE %s
+		GROUP BY r.status
+		ORDER BY r.status`, scope), args...).Scan(&counts).Error
+	return counts, err
+}
+
+// GetModeDurations summarizes the time from start to finish of completed runs
+// per pipeline mode
+func (d *DatabaseAdapter) GetModeDurations(query ports.AnalyticsQuery) ([]models.DurationStats, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	scope, args := runScope(query)
+	args = append([]interface{}{string(domain.StatusCompleted)}, args...)
+	var stats []models.DurationStats
+	err := d.DB.Raw(fmt.Sprintf(`SELECT CASE WHEN p.is_parallel THEN 'parallel' ELSE 'sequential' END AS name,
+			COUNT(*) AS count,
+			AVG(EXTRACT(EPOCH FROM r.finished_at - r.started_at)) AS mean_seconds,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM r.finished_at - r.started_at)) AS p95_seconds
+		FROM pipeline_runs r
+		JOIN pipeline_definitions p ON p.pipeline_id = r.pipeline_id
+		WHERE r.status = ? AND r.started_at IS NOT NULL AND r.finished_at IS NOT NULL AND %s
+		GROUP BY 1
+		ORDER BY 1`, scope), args...).Scan(&stats).Error
+	return stats, err
+}
+
+// GetStageDurations summarizes the durations of completed stage attempts per
+// stage name, in the order the stages usually run
+func (d *DatabaseAdapter) GetStageDurations(query ports.AnalyticsQuery) ([]models.DurationStats, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	scope, args := runScope(query)
+	args = append([]interface{}{string(domain.StatusCompleted)}, args...)
+	var stats []models.DurationStats
+	err := d.DB.Raw(fmt.Sprintf(`SELECT l.stage_name AS name,
+			COUNT(*) AS count,
+			AVG(l.duration_ms) / 1000.0 AS mean_seconds,
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY l.duration_ms) / 1000.0 AS p95_seconds
+		FROM execution_logs l
+		JOIN pipeline_runs r ON r.run_id = l.run_id
+		WHERE l.stage_index >= 0 AND l.status = ? AND l.finished_at IS NOT NULL AND %s
+		GROUP BY l.stage_name
+		ORDER BY MIN(l.stage_index), l.stage_name`, scope), args...).Scan(&stats).Error
+	return stats, err
+}
+
+// GetTopErrors groups failed runs by error message
+func (d *DatabaseAdapter) GetTopErrors(query ports.AnalyticsQuery, limit int) ([]models.ErrorCount, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	scope, args := runScope(query)
+	args = append([]interface{}{string(domain.StatusFailed)}, args...)
+	args = append(args, limit)
+	var counts []models.ErrorCount
+	err := d.DB.Raw(fmt.Sprintf(`SELECT r.error_msg AS message, COUNT(*) AS count, MAX(COALESCE(r.finished_at, r.updated_at)) AS last_seen
+		FROM pipeline_runs r
+		WHERE r.status = ? AND r.error_msg <> '' AND %s
+		GROUP BY r.error_msg
+		ORDER BY count DESC, last_seen DESC
+		LIMIT ?`, scope), args...).Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,97 @@
+package secondary
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+var _ ports.CalendarRepository = (*DatabaseAdapter)(nil)
+
+// SaveCalendar inserts a new calendar
+func (d *DatabaseAdapter) SaveCalendar(calendar *models.Calendar) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(calendar).Error
+}
+
+// UpdateCalendar replaces the name, timezone and definition of a calendar
+func (d *DatabaseAdapter) UpdateCalendar(calendar *models.Calendar) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.Calendar{}).Where("calendar_id = ?", calendar.CalendarID).Updates(map[string]interface{}{
+		"name":       calendar.Name,
+		"timezone":   calendar.Timezone,
+		"definition": calendar.Definition,
+		"updated_at": calendar.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("calendar not found")
+	}
+	return nil
+}
+
+// DeleteCalendar removes a calendar and detaches it from its pipelines
+func (d *DatabaseAdapter) DeleteCalendar(calendarID uuid.UUID) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PipelineExecution{}).Where("calendar_id = ?", calendarID).
+			Update("calendar_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Calendar{}, "calendar_id = ?", calendarID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("calendar not found")
+		}
+		return nil
+	})
+}
+
+// GetCalendar retrieves a calendar by ID
+func (d *DatabaseAdapter) GetCalendar(calendarID uuid.UUID) (*models.Calendar, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var calendar models.Calendar
+	if err := d.DB.First(&calendar, "calendar_id = ?", calendarID).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// GetCalendarsByUser retrieves all calendars of a user
+func (d *DatabaseAdapter) GetCalendarsByUser(userID string) ([]models.Calendar, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var calendars []models.Calendar
+	err := d.DB.Where("user_id = ?", userID).Order("name").Find(&calendars).Error
+	return calendars, err
+}
+
+// SetPipelineCalendar attaches a calendar to a pipeline, or detaches it when calendarID is nil
+func (d *DatabaseAdapter) SetPipelineCalendar(pipelineID uuid.UUID, calendarID *uuid.UUID) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.PipelineExecution{}).Where("pipeline_id = ?", pipelineID).Update("calendar_id", calendarID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pipeline not found")
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	// "github.com/joho/godotenv"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
 )
 
@@ -38,14 +39,162 @@ func InitDatabase() {
 
 	log.Println("✅ Database connection established.")
 
+	if err := registerMetricsCallbacks(DB); err != nil {
+		log.Fatalf("❌ Failed to register database metrics: %v", err)
+	}
+
+	if err := rekeyExecutionLogs(DB); err != nil {
+		log.Fatalf("❌ Rekeying execution logs failed: %v", err)
+	}
+
 	// Run database migrations
-	if err := DB.AutoMigrate(&models.User{}, &models.PipelineExecution{}, &models.ExecutionLog{}); err != nil {
+	if err := DB.AutoMigrate(&models.User{}, &models.PipelineExecution{}, &models.PipelineRun{}, &models.ExecutionLog{}, &models.ExecutionEvent{},
+		&models.Study{}, &models.StudyMetric{}, &models.StudyReplication{}, &models.StudyStage{},
+		&models.Calendar{}, &models.PipelineDefinition{}, &models.PipelineStage{}, &models.PipelineLease{},
+		&models.PipelineTemplate{}, &models.PipelineSchedule{}, &models.PipelineTrigger{},
+		&models.IdempotencyKey{}, &models.StatusTransition{}); err != nil {
 		log.Fatalf("❌ Database migration failed: %v", err)
 	}
 
+	if err := backfillRuns(DB); err != nil {
+		log.Fatalf("❌ Backfilling pipeline runs failed: %v", err)
+	}
+	if err := migrateStatuses(DB); err != nil {
+		log.Fatalf("❌ Migrating pipeline statuses failed: %v", err)
+	}
+	if err := migrateStageNames(DB); err != nil {
+		log.Fatalf("❌ Migrating stage names failed: %v", err)
+	}
+
 	log.Println("✅ Database migration completed.")
 }
 
+// backfillRuns gives every pipeline executed before runs were recorded its
+// first run, which reuses the pipeline ID as its run ID, and files the logs of
+// the pipeline under that run. Statuses are fixed up by migrateStatuses.
+func backfillRuns(db *gorm.DB) error {
+	var ended []string
+	for _, status := range domain.Statuses {
+		if status.Ended() {
+			ended = append(ended, string(status))
+		}
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if run, err := pending(tx, `SELECT 1 FROM pipeline_executions e
+			WHERE e.status <> ? AND NOT EXISTS (SELECT 1 FROM pipeline_runs r WHERE r.pipeline_id = e.pipeline_id)`,
+			string(domain.StatusCreated)); err != nil {
+			return err
+		} else if run {
+			log.Println("🔄 Backfilling runs of pipelines executed before runs were recorded...")
+			if err := tx.Exec(`INSERT INTO pipeline_runs (run_id, pipeline_id, number, user_id, status, seed, created_at, started_at, finished_at, updated_at)
+				SELECT e.pipeline_id, e.pipeline_id, 1, e.user_id, e.status, 0, e.created_at, e.created_at,
+					CASE WHEN e.status IN ? THEN e.updated_at END, e.updated_at
+				FROM pipeline_executions e
+				WHERE e.status <> ? AND NOT EXISTS (SELECT 1 FROM pipeline_runs r WHERE r.pipeline_id = e.pipeline_id)`,
+				ended, string(domain.StatusCreated)).Error; err != nil {
+				return err
+			}
+		}
+
+		if run, err := pending(tx, `SELECT 1 FROM execution_logs l
+			WHERE l.run_id IS NULL AND EXISTS (SELECT 1 FROM pipeline_runs r WHERE r.run_id = l.pipeline_id)`); err != nil || !run {
+			return err
+		}
+		return tx.Exec(`UPDATE execution_logs l SET run_id = l.pipeline_id
+			WHERE l.run_id IS NULL AND EXISTS (SELECT 1 FROM pipeline_runs r WHERE r.run_id = l.pipeline_id)`).Error
+	})
+}
+
+// migrateStatuses replaces statuses the state machine does not know, such as
+// "Failed to Cancel": a run in one was left behind and is interrupted, and a
+// pipeline takes the status of its latest run again
+func migrateStatuses(db *gorm.DB) error {
+	known := make([]string, len(domain.Statuses))
+	for i, status := range domain.Statuses {
+		known[i] = string(status)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if run, err := pending(tx, `SELECT 1 FROM pipeline_runs WHERE status NOT IN ?`, known); err != nil {
+			return err
+		} else if run {
+			if err := tx.Exec(`UPDATE pipeline_runs SET status = ?, finished_at = COALESCE(finished_at, updated_at)
+				WHERE status NOT IN ?`, string(domain.StatusInterrupted), known).Error; err != nil {
+				return err
+			}
+		}
+		if run, err := pending(tx, `SELECT 1 FROM pipeline_executions WHERE status NOT IN ?`, known); err != nil || !run {
+			return err
+		}
+		return tx.Exec(`UPDATE pipeline_executions e SET status = COALESCE(
+				(SELECT r.status FROM pipeline_runs r WHERE r.pipeline_id = e.pipeline_id ORDER BY r.number DESC LIMIT 1), ?)
+			WHERE status NOT IN ?`, string(domain.StatusCreated), known).Error
+	})
+}
+
+// migrateStageNames names the stages of pipelines created without names after
+// their position, and copies the position and name of each stage into the
+// logs written before logs recorded them
+func migrateStageNames(db *gorm.DB) error {
+	runLevel := []string{domain.LogError, domain.LogRecovery}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if run, err := pending(tx, `SELECT 1 FROM pipeline_stages WHERE name IS NULL OR name = ''`); err != nil {
+			return err
+		} else if run {
+			if err := tx.Exec(`UPDATE pipeline_stages SET name = 'stage-' || (position + 1)
+				WHERE name IS NULL OR name = ''`).Error; err != nil {
+				return err
+			}
+		}
+		if run, err := pending(tx, `SELECT 1 FROM execution_logs l JOIN pipeline_stages s ON l.stage_id = s.stage_id
+			WHERE (l.stage_name IS NULL OR l.stage_name = '') AND l.status NOT IN ?`, runLevel); err != nil {
+			return err
+		} else if run {
+			if err := tx.Exec(`UPDATE execution_logs l SET stage_index = s.position, stage_name = s.name
+				FROM pipeline_stages s
+				WHERE l.stage_id = s.stage_id AND (l.stage_name IS NULL OR l.stage_name = '') AND l.status NOT IN ?`,
+				runLevel).Error; err != nil {
+				return err
+			}
+		}
+		if run, err := pending(tx, `SELECT 1 FROM execution_logs WHERE status IN ? AND stage_index <> -1`, runLevel); err != nil || !run {
+			return err
+		}
+		return tx.Exec(`UPDATE execution_logs SET stage_index = -1 WHERE status IN ? AND stage_index <> -1`,
+			runLevel).Error
+	})
+}
+
+// rekeyExecutionLogs moves the primary key of execution_logs from stage_id,
+// which allowed one log per stage, to log_id so every attempt, run and
+// recovery decision gets its own row. AutoMigrate adds columns but never moves
+// a primary key; this does nothing on new databases and once the key moved.
+func rekeyExecutionLogs(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.ExecutionLog{}) {
+		return nil
+	}
+	if run, err := pending(db, `SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = 'execution_logs'::regclass AND i.indisprimary AND a.attname = 'log_id' AND i.indnatts = 1)`); err != nil || !run {
+		return err
+	}
+	log.Println("🔄 Rekeying execution logs by log ID...")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`ALTER TABLE execution_logs ADD COLUMN IF NOT EXISTS log_id uuid NOT NULL DEFAULT uuid_generate_v4()`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`ALTER TABLE execution_logs DROP CONSTRAINT IF EXISTS execution_logs_pkey, ADD PRIMARY KEY (log_id)`).Error
+	})
+}
+
+// pending reports whether any row matches the query, so startup migrations
+// leave the tables alone once they have run
+func pending(tx *gorm.DB, query string, args ...interface{}) (bool, error) {
+	var exists bool
+	err := tx.Raw("SELECT EXISTS ("+query+")", args...).Scan(&exists).Error
+	return exists, err
+}
+
 // CloseDatabase closes the database connection
 func CloseDatabase() {
 	sqlDB, err := DB.DB()
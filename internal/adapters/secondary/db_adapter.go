@@ -1,7 +1,10 @@
 package secondary
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
@@ -20,6 +23,14 @@ func NewDatabaseAdapter() *DatabaseAdapter {
 	return &DatabaseAdapter{DB: DB}
 }
 
+// notFound lets a missing record match ports.ErrNotFound as well as gorm.ErrRecordNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %w", ports.ErrNotFound, err)
+	}
+	return err
+}
+
 // SaveUser inserts a new user into the database, ensuring uniqueness
 func (d *DatabaseAdapter) SaveUser(user *models.User) error {
 	sqlDB, _ := d.DB.DB()
@@ -67,20 +78,30 @@ func (d *DatabaseAdapter) UpdateUser(userID uuid.UUID, updates map[string]interf
 	return d.DB.Model(&models.User{}).Where("user_id = ?", userID).Updates(updates).Error
 }
 
-// SavePipelineExecution saves pipeline execution details
+// SavePipelineExecution saves pipeline execution details, and its definition
+// and stages in the same transaction when set
 func (d *DatabaseAdapter) SavePipelineExecution(execution *models.PipelineExecution) error {
 	sqlDB, _ := d.DB.DB()
 	sqlDB.Exec("DEALLOCATE ALL")
 	return d.DB.Create(execution).Error
 }
 
-// UpdatePipelineExecution updates the pipeline execution status
-func (d *DatabaseAdapter) UpdatePipelineExecution(execution *models.PipelineExecution) error {
+// SetPipelineStatus moves a pipeline from status from at version to status,
+// bumping its version; it reports false when another writer got there first
+func (d *DatabaseAdapter) SetPipelineStatus(pipelineID uuid.UUID, from string, version int, status string) (bool, error) {
 	sqlDB, _ := d.DB.DB()
 	sqlDB.Exec("DEALLOCATE ALL")
-	return d.DB.Model(execution).Where("pipeline_id = ?", execution.PipelineID).Update("status", execution.Status).Error
+
+	result := d.DB.Exec(setPipelineStatusSQL, status, pipelineID, from, version)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
+const setPipelineStatusSQL = `UPDATE pipeline_executions SET status = ?, version = version + 1, updated_at = NOW()
+	WHERE pipeline_id = ? AND status = ? AND version = ?`
+
 // SaveExecutionLog saves execution logs
 func (d *DatabaseAdapter) SaveExecutionLog(logEntry *models.ExecutionLog) error {
 	sqlDB, _ := d.DB.DB()
@@ -100,28 +121,264 @@ func (d *DatabaseAdapter) GetPipelineStatus(pipelineID string) (string, error) {
 	return execution.Status, nil
 }
 
-// GetPipelinesByUser retrieves all pipelines for a user
-func (d *DatabaseAdapter) GetPipelinesByUser(userID string) ([]models.PipelineExecution, error) {
+// CountPipelinesByStatus counts the pipelines in each status
+func (d *DatabaseAdapter) CountPipelinesByStatus() (map[string]int, error) {
 	sqlDB, _ := d.DB.DB()
 	sqlDB.Exec("DEALLOCATE ALL")
 
+	var rows []struct {
+		Status    string
+		Pipelines int
+	}
+	if err := d.DB.Model(&models.PipelineExecution{}).
+		Select("status, COUNT(*) AS pipelines").
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Pipelines
+	}
+	return counts, nil
+}
+
+// ListPipelines retrieves a page of the pipelines of a user. Pages are keyed
+// on the sort column and pipeline ID, so rows added between pages do not shift
+// the next page. Sorted by updated_at, a pipeline that changes between pages
+// moves in the order: it may be skipped or listed twice.
+func (d *DatabaseAdapter) ListPipelines(query ports.PipelineQuery) ([]models.PipelineExecution, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	column := ports.SortByCreatedAt
+	if query.SortBy == ports.SortByUpdatedAt {
+		column = ports.SortByUpdatedAt
+	}
+	direction, compare := "ASC", ">"
+	if query.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	db := d.DB.Where("user_id = ?", query.UserID)
+	if len(query.Statuses) > 0 {
+		db = db.Where("status IN ?", query.Statuses)
+	}
+	if query.IsParallel != nil {
+		db = db.Where("pipeline_id IN (SELECT pipeline_id FROM pipeline_definitions WHERE is_parallel = ?)", *query.IsParallel)
+	}
+	if query.CreatedAfter != nil {
+		db = db.Where("created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *query.CreatedBefore)
+	}
+	for _, requirement := range query.Selector {
+		db = whereLabel(db, requirement)
+	}
+	if query.After != nil {
+		db = db.Where(fmt.Sprintf("(%s, pipeline_id) %s (?, ?)", column, compare), query.After.SortValue, query.After.PipelineID)
+	}
+
 	var pipelines []models.PipelineExecution
-	err := d.DB.Where("user_id = ?", userID).Find(&pipelines).Error
+	err := db.Preload("Definition").Order(fmt.Sprintf("%s %s, pipeline_id %s", column, direction, direction)).Limit(query.Limit).Find(&pipelines).Error
 	return pipelines, err
 }
 
-// GetPipelineStages fetches all stages associated with a pipeline
-func (d *DatabaseAdapter) GetPipelineStages(pipelineID uuid.UUID) ([]models.ExecutionLog, error) {
+// whereLabel narrows a pipeline query to the pipelines matching one label requirement
+func whereLabel(db *gorm.DB, requirement ports.LabelRequirement) *gorm.DB {
+	switch requirement.Operator {
+	case ports.LabelEquals:
+		return db.Where("labels->>? = ?", requirement.Key, requirement.Values[0])
+	case ports.LabelNotEquals:
+		return db.Where("labels->>? IS DISTINCT FROM ?", requirement.Key, requirement.Values[0])
+	case ports.LabelIn:
+		return db.Where("labels->>? IN ?", requirement.Key, requirement.Values)
+	case ports.LabelNotIn:
+		return db.Where("(labels->>? IS NULL OR labels->>? NOT IN ?)", requirement.Key, requirement.Key, requirement.Values)
+	case ports.LabelExists:
+		return db.Where("labels->>? IS NOT NULL", requirement.Key)
+	default:
+		return db.Where("labels->>? IS NULL", requirement.Key)
+	}
+}
+
+// UpdatePipelineMetadata patches the labels and annotations of a pipeline in
+// one statement, so concurrent patches of different keys do not undo each other
+func (d *DatabaseAdapter) UpdatePipelineMetadata(pipelineID uuid.UUID, labels ports.MetadataPatch, annotations ports.MetadataPatch) error {
 	sqlDB, _ := d.DB.DB()
 	sqlDB.Exec("DEALLOCATE ALL")
 
-	var stages []models.ExecutionLog
-	if err := d.DB.Where("pipeline_id = ?", pipelineID).Find(&stages).Error; err != nil {
-		return nil, err
+	updates := map[string]interface{}{"updated_at": time.Now()}
+	for column, patch := range map[string]ports.MetadataPatch{"labels": labels, "annotations": annotations} {
+		if patch.Empty() {
+			continue
+		}
+		// A nil map would encode as null, which || appends as an array element
+		values := patch.Set
+		if values == nil {
+			values = map[string]string{}
+		}
+		set, err := models.NewJSONB(values)
+		if err != nil {
+			return err
+		}
+		expr := fmt.Sprintf("(COALESCE(%s, '{}'::jsonb) || ?::jsonb)", column)
+		args := []interface{}{string(set)}
+		for _, key := range patch.Remove {
+			expr += " - ?::text"
+			args = append(args, key)
+		}
+		updates[column] = gorm.Expr(expr, args...)
+	}
+
+	result := d.DB.Model(&models.PipelineExecution{}).Where("pipeline_id = ?", pipelineID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetPipelineExecution retrieves a pipeline execution record
+func (d *DatabaseAdapter) GetPipelineExecution(pipelineID uuid.UUID) (*models.PipelineExecution, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var execution models.PipelineExecution
+	if err := d.DB.First(&execution, "pipeline_id = ?", pipelineID).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &execution, nil
+}
+
+// SaveExecutionEvents stores the event sequence of an execution
+func (d *DatabaseAdapter) SaveExecutionEvents(events []models.ExecutionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(&events).Error
+}
+
+// GetExecutionEvents retrieves the event sequence of a run in order
+func (d *DatabaseAdapter) GetExecutionEvents(runID uuid.UUID) ([]models.ExecutionEvent, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var events []models.ExecutionEvent
+	err := d.DB.Where("run_id = ?", runID).Order("seq").Find(&events).Error
+	return events, err
+}
+
+// GetPipelineDefinition retrieves the definition of a pipeline with its stages in order
+func (d *DatabaseAdapter) GetPipelineDefinition(pipelineID uuid.UUID) (*models.PipelineDefinition, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var definition models.PipelineDefinition
+	err := d.DB.Preload("Stages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).First(&definition, "pipeline_id = ?", pipelineID).Error
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &definition, nil
+}
+
+// SetPipelineOwner records which server instance queued or runs a pipeline
+func (d *DatabaseAdapter) SetPipelineOwner(pipelineID uuid.UUID, owner string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Model(&models.PipelineExecution{}).Where("pipeline_id = ?", pipelineID).Update("owner", owner).Error
+}
+
+// GetOrphanedPipelines retrieves the pipelines in one of the given statuses
+// that no replica holds a live lease on, or whose lease belongs to owner
+func (d *DatabaseAdapter) GetOrphanedPipelines(owner string, statuses []string) ([]models.PipelineExecution, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var pipelines []models.PipelineExecution
+	err := d.DB.Joins("LEFT JOIN pipeline_leases ON pipeline_leases.pipeline_id = pipeline_executions.pipeline_id").
+		Where("pipeline_executions.status IN ?", statuses).
+		Where("pipeline_leases.pipeline_id IS NULL OR pipeline_leases.expires_at < NOW() OR pipeline_leases.owner = ?", owner).
+		Order("pipeline_executions.updated_at").Find(&pipelines).Error
+	return pipelines, err
+}
+
+// AcquirePipelineLease takes the lease of a pipeline for ttl. It succeeds when
+// the pipeline has no lease, its lease expired, or owner already holds it.
+func (d *DatabaseAdapter) AcquirePipelineLease(pipelineID uuid.UUID, owner string, ttl time.Duration) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Exec(`INSERT INTO pipeline_leases (pipeline_id, owner, expires_at, acquired_at, renewed_at, cancel_requested)
+		VALUES (?, ?, NOW() + make_interval(secs => ?), NOW(), NOW(), false)
+		ON CONFLICT (pipeline_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			expires_at = EXCLUDED.expires_at,
+			acquired_at = CASE WHEN pipeline_leases.owner = EXCLUDED.owner THEN pipeline_leases.acquired_at ELSE EXCLUDED.acquired_at END,
+			renewed_at = EXCLUDED.renewed_at,
+			cancel_requested = false
+		WHERE pipeline_leases.owner = EXCLUDED.owner OR pipeline_leases.expires_at < NOW()`,
+		pipelineID, owner, ttl.Seconds())
+	if result.Error != nil {
+		return false, result.Error
 	}
-	return stages, nil
+	return result.RowsAffected == 1, nil
 }
 
+// RenewPipelineLeases extends the leases owner still holds among pipelineIDs
+// and returns them; a missing ID means another replica took the lease over.
+// Pending cancel requests are returned once and cleared.
+func (d *DatabaseAdapter) RenewPipelineLeases(owner string, pipelineIDs []uuid.UUID, ttl time.Duration) ([]models.PipelineLease, error) {
+	if len(pipelineIDs) == 0 {
+		return nil, nil
+	}
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
 
+	var leases []models.PipelineLease
+	err := d.DB.Raw(`UPDATE pipeline_leases SET expires_at = NOW() + make_interval(secs => ?), renewed_at = NOW(), cancel_requested = false
+		FROM (SELECT pipeline_id, cancel_requested FROM pipeline_leases WHERE owner = ? AND pipeline_id IN ? FOR UPDATE) AS held
+		WHERE pipeline_leases.pipeline_id = held.pipeline_id
+		RETURNING pipeline_leases.pipeline_id, pipeline_leases.owner, pipeline_leases.expires_at,
+			pipeline_leases.acquired_at, pipeline_leases.renewed_at, held.cancel_requested`,
+		ttl.Seconds(), owner, pipelineIDs).Scan(&leases).Error
+	return leases, err
+}
 
+// ReleasePipelineLease gives up the lease of a pipeline if owner holds it
+func (d *DatabaseAdapter) ReleasePipelineLease(pipelineID uuid.UUID, owner string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Where("pipeline_id = ? AND owner = ?", pipelineID, owner).Delete(&models.PipelineLease{}).Error
+}
 
+// GetPipelineLease retrieves the live lease of a pipeline; expired leases are
+// reported as not found
+func (d *DatabaseAdapter) GetPipelineLease(pipelineID uuid.UUID) (*models.PipelineLease, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var lease models.PipelineLease
+	if err := d.DB.First(&lease, "pipeline_id = ? AND expires_at > NOW()", pipelineID).Error; err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+// RequestPipelineCancel flags the live lease of a pipeline for cancellation and
+// reports whether a replica holds one
+func (d *DatabaseAdapter) RequestPipelineCancel(pipelineID uuid.UUID) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.PipelineLease{}).Where("pipeline_id = ? AND expires_at > NOW()", pipelineID).
+		Update("cancel_requested", true)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,50 @@
+package secondary
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+	"gorm.io/gorm"
+)
+
+const callStartKey = "metrics:call_start"
+
+// registerMetricsCallbacks times every statement GORM runs into the
+// db_call_duration_seconds histogram, by operation and table; statements
+// without a model, such as raw SQL, are recorded under the table "raw"
+func registerMetricsCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("metrics:before_create", startCall),
+		cb.Create().After("gorm:create").Register("metrics:after_create", finishCall("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", startCall),
+		cb.Query().After("gorm:query").Register("metrics:after_query", finishCall("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", startCall),
+		cb.Update().After("gorm:update").Register("metrics:after_update", finishCall("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", startCall),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", finishCall("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", startCall),
+		cb.Row().After("gorm:row").Register("metrics:after_row", finishCall("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", startCall),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", finishCall("exec")),
+	)
+}
+
+func startCall(tx *gorm.DB) {
+	tx.InstanceSet(callStartKey, time.Now())
+}
+
+func finishCall(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		start, ok := tx.InstanceGet(callStartKey)
+		if !ok {
+			return
+		}
+		table := tx.Statement.Table
+		if table == "" {
+			table = "raw"
+		}
+		utils.DBCallDuration.WithLabelValues(operation, table).Observe(time.Since(start.(time.Time)).Seconds())
+	}
+}
@@ -0,0 +1,69 @@
+package secondary
+
+import (
+	"time"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+var _ ports.IdempotencyRepository = (*DatabaseAdapter)(nil)
+
+// ReserveIdempotencyKey inserts the key, taking over a record that expired;
+// concurrent requests with one key race on the primary key and one wins
+func (d *DatabaseAdapter) ReserveIdempotencyKey(scope string, key string, fingerprint string, ttl time.Duration) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Exec(`INSERT INTO idempotency_keys (scope, key, fingerprint, status_code, response, created_at, expires_at)
+		VALUES (?, ?, ?, 0, NULL, NOW(), NOW() + make_interval(secs => ?))
+		ON CONFLICT (scope, key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = 0,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()`,
+		scope, key, fingerprint, ttl.Seconds())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetIdempotencyKey retrieves the record of a key
+func (d *DatabaseAdapter) GetIdempotencyKey(scope string, key string) (*models.IdempotencyKey, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var record models.IdempotencyKey
+	if err := d.DB.First(&record, "scope = ? AND key = ?", scope, key).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request a key was reserved for
+func (d *DatabaseAdapter) CompleteIdempotencyKey(scope string, key string, statusCode int, response models.JSONB) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	return d.DB.Model(&models.IdempotencyKey{}).Where("scope = ? AND key = ?", scope, key).
+		Updates(map[string]interface{}{"status_code": statusCode, "response": response}).Error
+}
+
+// DeleteIdempotencyKey releases a key so the request can be retried
+func (d *DatabaseAdapter) DeleteIdempotencyKey(scope string, key string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Where("scope = ? AND key = ?", scope, key).Delete(&models.IdempotencyKey{}).Error
+}
+
+// DeleteExpiredIdempotencyKeys removes the keys whose window has passed
+func (d *DatabaseAdapter) DeleteExpiredIdempotencyKeys() (int64, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Where("expires_at < NOW()").Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,118 @@
+package secondary
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"gorm.io/gorm"
+)
+
+// QueuePipelineRun moves a pipeline from status from at version to Queued
+// and inserts its run, numbered after the latest one, in one transaction. It
+// reports false and saves nothing when another writer changed the pipeline
+// first.
+func (d *DatabaseAdapter) QueuePipelineRun(run *models.PipelineRun, from string, version int) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	queued := false
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(setPipelineStatusSQL, run.Status, run.PipelineID, from, version)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		var last int
+		if err := tx.Model(&models.PipelineRun{}).Where("pipeline_id = ?", run.PipelineID).
+			Select("COALESCE(MAX(number), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		run.Number = last + 1
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		queued = true
+		return nil
+	})
+	return queued, err
+}
+
+// UpdatePipelineRun moves a run from status from, along with any other
+// columns in updates; it reports false when the run was no longer in from. A
+// resumed run keeps the time it first started.
+func (d *DatabaseAdapter) UpdatePipelineRun(runID uuid.UUID, from string, updates map[string]interface{}) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	columns := make(map[string]interface{}, len(updates))
+	for column, value := range updates {
+		columns[column] = value
+	}
+	if startedAt, ok := columns["started_at"]; ok {
+		columns["started_at"] = gorm.Expr("COALESCE(started_at, ?)", startedAt)
+	}
+	result := d.DB.Model(&models.PipelineRun{}).Where("run_id = ? AND status = ?", runID, from).Updates(columns)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RecordPipelineRun stores the seed and configuration a run executes with
+func (d *DatabaseAdapter) RecordPipelineRun(runID uuid.UUID, seed int64, config models.JSONB) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Model(&models.PipelineRun{}).Where("run_id = ?", runID).
+		Updates(map[string]interface{}{"seed": seed, "config": config}).Error
+}
+
+// GetPipelineRun retrieves a run with its execution logs in order
+func (d *DatabaseAdapter) GetPipelineRun(runID uuid.UUID) (*models.PipelineRun, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var run models.PipelineRun
+	err := d.DB.Preload("ExecutionLogs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("timestamp")
+	}).First(&run, "run_id = ?", runID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetPipelineRuns retrieves the runs of a pipeline, latest first
+func (d *DatabaseAdapter) GetPipelineRuns(pipelineID uuid.UUID) ([]models.PipelineRun, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var runs []models.PipelineRun
+	err := d.DB.Where("pipeline_id = ?", pipelineID).Order("number DESC").Find(&runs).Error
+	return runs, err
+}
+
+// GetLatestPipelineRun retrieves the latest run of a pipeline; with started
+// set, the latest one whose configuration was recorded
+func (d *DatabaseAdapter) GetLatestPipelineRun(pipelineID uuid.UUID, started bool) (*models.PipelineRun, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	query := d.DB.Where("pipeline_id = ?", pipelineID)
+	if started {
+		query = query.Where("config IS NOT NULL")
+	}
+	var run models.PipelineRun
+	if err := query.Order("number DESC").First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetRunLogs fetches the execution logs of a run in order
+func (d *DatabaseAdapter) GetRunLogs(runID uuid.UUID) ([]models.ExecutionLog, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var logs []models.ExecutionLog
+	err := d.DB.Where("run_id = ?", runID).Order("timestamp").Find(&logs).Error
+	return logs, err
+}
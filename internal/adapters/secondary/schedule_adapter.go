@@ -0,0 +1,130 @@
+package secondary
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+var _ ports.ScheduleRepository = (*DatabaseAdapter)(nil)
+
+// SaveSchedule inserts a new schedule
+func (d *DatabaseAdapter) SaveSchedule(schedule *models.PipelineSchedule) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(schedule).Error
+}
+
+// UpdateSchedule replaces the settings and next fire time of a schedule
+func (d *DatabaseAdapter) UpdateSchedule(schedule *models.PipelineSchedule) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.PipelineSchedule{}).Where("schedule_id = ?", schedule.ScheduleID).
+		Updates(map[string]interface{}{
+			"name":          schedule.Name,
+			"cron":          schedule.Cron,
+			"timezone":      schedule.Timezone,
+			"missed_policy": schedule.MissedPolicy,
+			"enabled":       schedule.Enabled,
+			"input":         schedule.Input,
+			"priority":      schedule.Priority,
+			"next_fire_at":  schedule.NextFireAt,
+			"updated_at":    time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("schedule not found")
+	}
+	return nil
+}
+
+// DeleteSchedule removes a schedule
+func (d *DatabaseAdapter) DeleteSchedule(scheduleID uuid.UUID) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Where("schedule_id = ?", scheduleID).Delete(&models.PipelineSchedule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("schedule not found")
+	}
+	return nil
+}
+
+// GetSchedule retrieves a schedule by ID
+func (d *DatabaseAdapter) GetSchedule(scheduleID uuid.UUID) (*models.PipelineSchedule, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var schedule models.PipelineSchedule
+	if err := d.DB.First(&schedule, "schedule_id = ?", scheduleID).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetSchedules retrieves the schedules of a user, or of a pipeline, oldest first
+func (d *DatabaseAdapter) GetSchedules(userID string, pipelineID *uuid.UUID) ([]models.PipelineSchedule, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	query := d.DB.Order("created_at")
+	if pipelineID != nil {
+		query = query.Where("pipeline_id = ?", *pipelineID)
+	} else {
+		query = query.Where("user_id = ?", userID)
+	}
+	var schedules []models.PipelineSchedule
+	err := query.Find(&schedules).Error
+	return schedules, err
+}
+
+// GetDueSchedules retrieves the enabled schedules due at now, most overdue first
+func (d *DatabaseAdapter) GetDueSchedules(now time.Time) ([]models.PipelineSchedule, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var schedules []models.PipelineSchedule
+	err := d.DB.Where("enabled AND next_fire_at <= ?", now).Order("next_fire_at").Find(&schedules).Error
+	return schedules, err
+}
+
+// ClaimScheduleFire advances next_fire_at only if it still holds due, so
+// replicas ticking at the same time fire each fire time once
+func (d *DatabaseAdapter) ClaimScheduleFire(scheduleID uuid.UUID, due time.Time, next time.Time, missed int) (bool, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var nextFire *time.Time
+	if !next.IsZero() {
+		nextFire = &next
+	}
+	result := d.DB.Exec(`UPDATE pipeline_schedules SET next_fire_at = ?, missed_fires = missed_fires + ?, updated_at = NOW()
+		WHERE schedule_id = ? AND enabled AND next_fire_at = ?`,
+		nextFire, missed, scheduleID, due)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RecordScheduleFire stores when a schedule last fired and the run it started or the error it hit
+func (d *DatabaseAdapter) RecordScheduleFire(scheduleID uuid.UUID, firedAt time.Time, runID *uuid.UUID, errorMsg string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	return d.DB.Model(&models.PipelineSchedule{}).Where("schedule_id = ?", scheduleID).
+		Updates(map[string]interface{}{
+			"last_fire_at": firedAt,
+			"last_run_id":  runID,
+			"last_error":   errorMsg,
+		}).Error
+}
@@ -0,0 +1,59 @@
+package secondary
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+var _ ports.StudyRepository = (*DatabaseAdapter)(nil)
+
+// studyBatchSize keeps every insert of replications well below the 65535
+// bind parameters Postgres accepts in one statement
+const studyBatchSize = 1000
+
+// SaveStudy inserts a study together with its metrics and replications
+func (d *DatabaseAdapter) SaveStudy(study *models.Study) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Samples").Create(study).Error; err != nil {
+			return err
+		}
+		for i := range study.Samples {
+			study.Samples[i].StudyID = study.StudyID
+		}
+		if len(study.Samples) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(study.Samples, studyBatchSize).Error
+	})
+}
+
+// GetStudy retrieves a study with its metrics, per-replication results and stage statistics
+func (d *DatabaseAdapter) GetStudy(studyID uuid.UUID) (*models.Study, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var study models.Study
+	err := d.DB.Preload("Metrics").Preload("Samples", func(db *gorm.DB) *gorm.DB {
+		return db.Order("replication")
+	}).Preload("Stages", func(db *gorm.DB) *gorm.DB {
+		return db.Order("stage_index")
+	}).First(&study, "study_id = ?", studyID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &study, nil
+}
+
+// GetStudiesByUser retrieves all studies of a user with their aggregated metrics
+func (d *DatabaseAdapter) GetStudiesByUser(userID string) ([]models.Study, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var studies []models.Study
+	err := d.DB.Preload("Metrics").Where("user_id = ?", userID).Order("created_at DESC").Find(&studies).Error
+	return studies, err
+}
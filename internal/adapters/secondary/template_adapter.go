@@ -0,0 +1,103 @@
+package secondary
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+var _ ports.TemplateRepository = (*DatabaseAdapter)(nil)
+
+// SaveTemplate inserts a new template version; the unique name and version
+// index rejects a version created concurrently
+func (d *DatabaseAdapter) SaveTemplate(template *models.PipelineTemplate) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(template).Error
+}
+
+// UpdateTemplate replaces the description and definition of a draft
+func (d *DatabaseAdapter) UpdateTemplate(template *models.PipelineTemplate) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.PipelineTemplate{}).
+		Where("template_id = ? AND status = ?", template.TemplateID, domain.TemplateDraft).
+		Updates(map[string]interface{}{
+			"description": template.Description,
+			"definition":  template.Definition,
+			"updated_at":  template.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("only draft templates can be changed")
+	}
+	return nil
+}
+
+// SetTemplateStatus publishes or deprecates a template version and records when
+func (d *DatabaseAdapter) SetTemplateStatus(templateID uuid.UUID, status string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	updates := map[string]interface{}{"status": status, "updated_at": time.Now()}
+	switch status {
+	case domain.TemplatePublished:
+		updates["published_at"] = time.Now()
+	case domain.TemplateDeprecated:
+		updates["deprecated_at"] = time.Now()
+	}
+	result := d.DB.Model(&models.PipelineTemplate{}).Where("template_id = ?", templateID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("template not found")
+	}
+	return nil
+}
+
+// GetTemplate retrieves one version of a template
+func (d *DatabaseAdapter) GetTemplate(name string, version int) (*models.PipelineTemplate, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var template models.PipelineTemplate
+	if err := d.DB.First(&template, "name = ? AND version = ?", name, version).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetLatestTemplate retrieves the highest version of a template in one of the given statuses
+func (d *DatabaseAdapter) GetLatestTemplate(name string, statuses []string) (*models.PipelineTemplate, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var template models.PipelineTemplate
+	err := d.DB.Where("name = ? AND status IN ?", name, statuses).Order("version DESC").First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListTemplates retrieves all template versions, or those of name, by name and version
+func (d *DatabaseAdapter) ListTemplates(name string) ([]models.PipelineTemplate, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	query := d.DB.Order("name").Order("version")
+	if name != "" {
+		query = query.Where("name = ?", name)
+	}
+	var templates []models.PipelineTemplate
+	err := query.Find(&templates).Error
+	return templates, err
+}
@@ -0,0 +1,36 @@
+package secondary
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// GetRunStatus retrieves the status of a run
+func (d *DatabaseAdapter) GetRunStatus(runID uuid.UUID) (string, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var run models.PipelineRun
+	if err := d.DB.Select("status").Where("run_id = ?", runID).First(&run).Error; err != nil {
+		return "", err
+	}
+	return run.Status, nil
+}
+
+// SaveStatusTransition records a status change
+func (d *DatabaseAdapter) SaveStatusTransition(transition *models.StatusTransition) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(transition).Error
+}
+
+// GetStatusTransitions retrieves the status history of a pipeline and its
+// runs, oldest first
+func (d *DatabaseAdapter) GetStatusTransitions(pipelineID uuid.UUID) ([]models.StatusTransition, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var transitions []models.StatusTransition
+	err := d.DB.Where("pipeline_id = ?", pipelineID).Order("created_at, transition_id").Find(&transitions).Error
+	return transitions, err
+}
@@ -0,0 +1,96 @@
+package secondary
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+var _ ports.TriggerRepository = (*DatabaseAdapter)(nil)
+
+// SaveTrigger inserts a new trigger
+func (d *DatabaseAdapter) SaveTrigger(trigger *models.PipelineTrigger) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+	return d.DB.Create(trigger).Error
+}
+
+// SetTriggerEnabled enables or disables a trigger
+func (d *DatabaseAdapter) SetTriggerEnabled(triggerID uuid.UUID, enabled bool) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Model(&models.PipelineTrigger{}).Where("trigger_id = ?", triggerID).
+		Updates(map[string]interface{}{"enabled": enabled, "updated_at": time.Now()})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("trigger not found")
+	}
+	return nil
+}
+
+// DeleteTrigger removes a trigger
+func (d *DatabaseAdapter) DeleteTrigger(triggerID uuid.UUID) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	result := d.DB.Where("trigger_id = ?", triggerID).Delete(&models.PipelineTrigger{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("trigger not found")
+	}
+	return nil
+}
+
+// GetTrigger retrieves a trigger by ID
+func (d *DatabaseAdapter) GetTrigger(triggerID uuid.UUID) (*models.PipelineTrigger, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var trigger models.PipelineTrigger
+	if err := d.DB.First(&trigger, "trigger_id = ?", triggerID).Error; err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// GetTriggers retrieves the triggers from and to a pipeline, oldest first
+func (d *DatabaseAdapter) GetTriggers(pipelineID uuid.UUID) ([]models.PipelineTrigger, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var triggers []models.PipelineTrigger
+	err := d.DB.Where("source_pipeline_id = ? OR target_pipeline_id = ?", pipelineID, pipelineID).
+		Order("created_at").Find(&triggers).Error
+	return triggers, err
+}
+
+// GetSourceTriggers retrieves the enabled triggers from a pipeline
+func (d *DatabaseAdapter) GetSourceTriggers(pipelineID uuid.UUID) ([]models.PipelineTrigger, error) {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	var triggers []models.PipelineTrigger
+	err := d.DB.Where("source_pipeline_id = ? AND enabled", pipelineID).Order("created_at").Find(&triggers).Error
+	return triggers, err
+}
+
+// RecordTriggerFire stores when a trigger last fired and the run it started or the error it hit
+func (d *DatabaseAdapter) RecordTriggerFire(triggerID uuid.UUID, firedAt time.Time, runID *uuid.UUID, errorMsg string) error {
+	sqlDB, _ := d.DB.DB()
+	sqlDB.Exec("DEALLOCATE ALL")
+
+	return d.DB.Model(&models.PipelineTrigger{}).Where("trigger_id = ?", triggerID).
+		Updates(map[string]interface{}{
+			"last_fired_at": firedAt,
+			"last_run_id":   runID,
+			"last_error":    errorMsg,
+		}).Error
+}
@@ -0,0 +1,279 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Thresholds used when classifying stages in a bottleneck report
+const (
+	// BottleneckTolerance is how far below the busiest stage a stage may be
+	// and still be reported as a bottleneck
+	BottleneckTolerance = 0.05
+	// UnderutilizedRatio marks stages busy less than this fraction of the
+	// busiest stage as candidates for capacity reduction
+	UnderutilizedRatio = 0.5
+)
+
+// StageStats is the stage-level performance of a run or the average over the
+// replications of a study
+type StageStats struct {
+	Index     int     `json:"index"`
+	Name      string  `json:"name"`
+	Processed float64 `json:"processed"`
+
+	// BusySeconds and StarvedSeconds split the makespan into processing and
+	// idling for lack of input; BlockedSeconds is the time finished units of
+	// the stage waited for the next stage to take them
+	BusySeconds    float64 `json:"busy_seconds"`
+	StarvedSeconds float64 `json:"starved_seconds"`
+	BlockedSeconds float64 `json:"blocked_seconds"`
+
+	// AvgQueueLength and MaxQueueLength count the units waiting in front of the stage
+	AvgQueueLength float64 `json:"avg_queue_length"`
+	MaxQueueLength float64 `json:"max_queue_length"`
+	Utilization    float64 `json:"utilization"`
+}
+
+// PerUnitSeconds returns the average processing time of one unit
+func (s StageStats) PerUnitSeconds() float64 {
+	if s.Processed == 0 {
+		return 0
+	}
+	return s.BusySeconds / s.Processed
+}
+
+// stageCounter accumulates the stage-level statistics of one replication
+type stageCounter struct {
+	busy, starved, blocked, wait time.Duration
+	processed                    int
+	maxQueue                     int
+
+	// queued is the time at least one unit waited in front of the stage, up
+	// to queuedUntil; pending holds the start times of the waiting units
+	queued      time.Duration
+	queuedUntil time.Duration
+	pending     []time.Duration
+}
+
+// observe records a unit that arrived at the stage, started once the stage was
+// free and finished at end; free is when the stage finished its previous unit
+func (c *stageCounter) observe(free, arrival, start, end time.Duration) {
+	if arrival > free {
+		c.starved += arrival - free
+	}
+	c.busy += end - start
+	c.wait += start - arrival
+	c.processed++
+
+	if start > c.queuedUntil {
+		from := arrival
+		if c.queuedUntil > from {
+			from = c.queuedUntil
+		}
+		if start > from {
+			c.queued += start - from
+		}
+		c.queuedUntil = start
+	}
+
+	n := 0
+	for n < len(c.pending) && c.pending[n] <= arrival {
+		n++
+	}
+	c.pending = c.pending[n:]
+	if start > arrival {
+		c.pending = append(c.pending, start)
+	}
+	if len(c.pending) > c.maxQueue {
+		c.maxQueue = len(c.pending)
+	}
+}
+
+// stats converts the counter into StageStats; idle time after the stage's last
+// unit counts as starved
+func (c *stageCounter) stats(index int, name string, free, makespan time.Duration) StageStats {
+	s := StageStats{
+		Index:          index,
+		Name:           name,
+		Processed:      float64(c.processed),
+		BusySeconds:    c.busy.Seconds(),
+		StarvedSeconds: (c.starved + makespan - free).Seconds(),
+		BlockedSeconds: c.blocked.Seconds(),
+		MaxQueueLength: float64(c.maxQueue),
+	}
+	if makespan > 0 {
+		s.Utilization = float64(c.busy) / float64(makespan)
+		s.AvgQueueLength = float64(c.wait) / float64(makespan)
+	}
+	return s
+}
+
+// AverageStageStats averages the stage statistics of several replications of
+// the same definition
+func AverageStageStats(replications [][]StageStats) []StageStats {
+	if len(replications) == 0 {
+		return nil
+	}
+
+	avg := make([]StageStats, len(replications[0]))
+	for i, s := range replications[0] {
+		avg[i] = StageStats{Index: s.Index, Name: s.Name}
+	}
+	for _, stages := range replications {
+		for i, s := range stages {
+			avg[i].Processed += s.Processed
+			avg[i].BusySeconds += s.BusySeconds
+			avg[i].StarvedSeconds += s.StarvedSeconds
+			avg[i].BlockedSeconds += s.BlockedSeconds
+			avg[i].AvgQueueLength += s.AvgQueueLength
+			avg[i].MaxQueueLength += s.MaxQueueLength
+			avg[i].Utilization += s.Utilization
+		}
+	}
+
+	n := float64(len(replications))
+	for i := range avg {
+		avg[i].Processed /= n
+		avg[i].BusySeconds /= n
+		avg[i].StarvedSeconds /= n
+		avg[i].BlockedSeconds /= n
+		avg[i].AvgQueueLength /= n
+		avg[i].MaxQueueLength /= n
+		avg[i].Utilization /= n
+	}
+	return avg
+}
+
+// StageStatsFromEvents derives the stage statistics of a single execution from
+// its recorded events. The run moves one unit, so queues and blocking are empty.
+func StageStatsFromEvents(events []ExecutionEvent, stages []StageSpec) ([]StageStats, time.Duration, error) {
+	var makespan time.Duration
+	finished := false
+	start := make(map[int]time.Duration)
+	end := make(map[int]time.Duration)
+	for _, e := range events {
+		switch {
+		case e.Type == EventPipeline && e.Status != "Running":
+			makespan = e.Offset
+			finished = true
+		case e.Type == EventStage && e.Status == "Running":
+			start[e.StageIndex] = e.Offset
+		case e.Type == EventStage:
+			end[e.StageIndex] = e.Offset
+		}
+	}
+	if !finished {
+		return nil, 0, errors.New("pipeline has no finished run")
+	}
+
+	stats := make([]StageStats, len(stages))
+	for i, spec := range stages {
+		var c stageCounter
+		free := time.Duration(0)
+		if finish, ok := end[i]; ok {
+			c.observe(0, start[i], start[i], finish)
+			free = finish
+		}
+		stats[i] = c.stats(i, spec.Name, free, makespan)
+	}
+	return stats, makespan, nil
+}
+
+// StageBottleneck is one ranked entry of a bottleneck report
+type StageBottleneck struct {
+	Rank       int  `json:"rank"`
+	Bottleneck bool `json:"bottleneck"`
+	StageStats
+
+	// SuggestedStations is the number of parallel stations to add and
+	// TargetSeconds the per-unit processing time that would relieve the stage
+	SuggestedStations int     `json:"suggested_stations"`
+	TargetSeconds     float64 `json:"target_seconds"`
+	Reason            string  `json:"reason"`
+	Suggestion        string  `json:"suggestion"`
+}
+
+// BottleneckReport ranks the stages of a run or study by how much they limit throughput
+type BottleneckReport struct {
+	PipelineID      string            `json:"pipeline_id"`
+	StudyID         string            `json:"study_id,omitempty"`
+	Source          string            `json:"source"`
+	MakespanSeconds float64           `json:"makespan_seconds"`
+	Stages          []StageBottleneck `json:"stages"`
+}
+
+// AnalyzeBottlenecks ranks stages by utilization, then by queue length, and
+// suggests capacity changes. Stages within BottleneckTolerance of the busiest
+// one are bottlenecks; their suggestion brings them down to the utilization of
+// the busiest remaining stage. Neighbouring stages only matter in a flow line.
+func AnalyzeBottlenecks(stats []StageStats, isParallel bool) []StageBottleneck {
+	ranked := make([]StageBottleneck, len(stats))
+	for i, s := range stats {
+		ranked[i] = StageBottleneck{StageStats: s}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Utilization != b.Utilization {
+			return a.Utilization > b.Utilization
+		}
+		return a.AvgQueueLength > b.AvgQueueLength
+	})
+	if len(ranked) == 0 {
+		return ranked
+	}
+
+	top := ranked[0].Utilization
+	next := 0.0
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+		ranked[i].Bottleneck = top > 0 && ranked[i].Utilization >= top*(1-BottleneckTolerance)
+		if !ranked[i].Bottleneck && next == 0 {
+			next = ranked[i].Utilization
+		}
+	}
+
+	for i := range ranked {
+		b := &ranked[i]
+		b.Reason = bottleneckReason(*b, stats, isParallel)
+		switch {
+		case b.Bottleneck && next > 0:
+			b.SuggestedStations = int(math.Ceil(b.Utilization/next)) - 1
+			if b.SuggestedStations < 1 {
+				b.SuggestedStations = 1
+			}
+			b.TargetSeconds = b.PerUnitSeconds() * next / b.Utilization
+			b.Suggestion = fmt.Sprintf("add %d parallel station(s) or cut processing time from %.2fs to %.2fs per unit",
+				b.SuggestedStations, b.PerUnitSeconds(), b.TargetSeconds)
+		case b.Bottleneck:
+			b.SuggestedStations = 1
+			b.TargetSeconds = b.PerUnitSeconds() / 2
+			b.Suggestion = "stages are balanced; add a parallel station to every bottleneck stage to raise throughput"
+		case b.Utilization < top*UnderutilizedRatio:
+			b.Suggestion = fmt.Sprintf("underutilized at %.0f%%; capacity can be reduced or shared", b.Utilization*100)
+		default:
+			b.Suggestion = "no change"
+		}
+	}
+	return ranked
+}
+
+// bottleneckReason summarizes the signals behind a stage's rank: its own load
+// and queue, blocking upstream and starvation downstream
+func bottleneckReason(b StageBottleneck, stats []StageStats, isParallel bool) string {
+	reason := fmt.Sprintf("utilization %.0f%%, %.2f units queued on average (max %.0f)",
+		b.Utilization*100, b.AvgQueueLength, b.MaxQueueLength)
+	if isParallel {
+		return reason
+	}
+	if b.Index > 0 && b.Index-1 < len(stats) && stats[b.Index-1].BlockedSeconds > 0 {
+		reason += fmt.Sprintf(", upstream blocked %.1fs", stats[b.Index-1].BlockedSeconds)
+	}
+	if b.Index+1 < len(stats) && stats[b.Index+1].StarvedSeconds > 0 {
+		reason += fmt.Sprintf(", downstream starved %.1fs", stats[b.Index+1].StarvedSeconds)
+	}
+	return reason
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // calendars name IANA zones; embed the database for minimal images
+)
+
+// MaxCalendarHorizon bounds how far ahead a calendar is searched for working time
+const MaxCalendarHorizon = 366 * 24 * time.Hour
+
+// TimeWindow is a daily recurring window such as a shift or a break, in the
+// calendar's local time
+type TimeWindow struct {
+	Name  string   `json:"name"`
+	Days  []string `json:"days"`  // Weekday names ("mon", "tuesday", ...); empty means every day
+	Start string   `json:"start"` // "HH:MM"
+	End   string   `json:"end"`   // "HH:MM"; at or before Start means the window ends the next day
+}
+
+// Holiday is a full local day without work
+type Holiday struct {
+	Date string `json:"date"` // "YYYY-MM-DD"
+	Name string `json:"name"`
+}
+
+// Downtime is a one-off period without work, such as planned maintenance
+type Downtime struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason"`
+}
+
+// Calendar defines when work can be done: the shifts, minus breaks, holidays
+// and planned downtime
+type Calendar struct {
+	Timezone string       `json:"timezone"`
+	Shifts   []TimeWindow `json:"shifts"`
+	Breaks   []TimeWindow `json:"breaks"`
+	Holidays []Holiday    `json:"holidays"`
+	Downtime []Downtime   `json:"downtime"`
+}
+
+// WorkingWindow is one continuous period of working time
+type WorkingWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Validate checks that the calendar is well formed and has at least one shift
+func (c *Calendar) Validate() error {
+	if _, err := c.location(); err != nil {
+		return err
+	}
+	if len(c.Shifts) == 0 {
+		return errors.New("calendar needs at least one shift")
+	}
+	for i, w := range c.Shifts {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("shift %d: %v", i+1, err)
+		}
+	}
+	for i, w := range c.Breaks {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("break %d: %v", i+1, err)
+		}
+	}
+	for i, h := range c.Holidays {
+		if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+			return fmt.Errorf("holiday %d: date must be YYYY-MM-DD", i+1)
+		}
+	}
+	for i, d := range c.Downtime {
+		if !d.End.After(d.Start) {
+			return fmt.Errorf("downtime %d: end must be after start", i+1)
+		}
+	}
+	return nil
+}
+
+func (c *Calendar) location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", c.Timezone)
+	}
+	return loc, nil
+}
+
+func (w TimeWindow) validate() error {
+	if _, _, err := parseClock(w.Start); err != nil {
+		return err
+	}
+	if _, _, err := parseClock(w.End); err != nil {
+		return err
+	}
+	for _, day := range w.Days {
+		if _, err := parseWeekday(day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appliesOn reports whether the window starts on the given weekday
+func (w TimeWindow) appliesOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if d, err := parseWeekday(name); err == nil && d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// on returns the occurrence of the window that starts on the given local date
+func (w TimeWindow) on(date time.Time) (interval, error) {
+	sh, sm, err := parseClock(w.Start)
+	if err != nil {
+		return interval{}, err
+	}
+	eh, em, err := parseClock(w.End)
+	if err != nil {
+		return interval{}, err
+	}
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, sh, sm, 0, 0, date.Location())
+	end := time.Date(y, m, d, eh, em, 0, 0, date.Location())
+	if !end.After(start) {
+		end = time.Date(y, m, d+1, eh, em, 0, 0, date.Location())
+	}
+	return interval{start, end}, nil
+}
+
+func parseClock(s string) (int, int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("time %q must be HH:MM", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if len(name) >= 3 {
+		if day, ok := weekdays[name[:3]]; ok {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// WorkingWindows lists the working time between from and to
+func (c *Calendar) WorkingWindows(from, to time.Time) ([]WorkingWindow, error) {
+	intervals, err := c.intervals(from, to)
+	if err != nil {
+		return nil, err
+	}
+	windows := make([]WorkingWindow, len(intervals))
+	for i, iv := range intervals {
+		windows[i] = WorkingWindow{Start: iv.start, End: iv.end}
+	}
+	return windows, nil
+}
+
+// NextWorkingTime returns t if it is working time, otherwise the start of the
+// next working window
+func (c *Calendar) NextWorkingTime(t time.Time) (time.Time, error) {
+	return c.AddWorkingTime(t, 0)
+}
+
+// AddWorkingTime returns when d of work started at t is finished, pausing
+// outside working time. Work never starts outside working time, so even a zero
+// duration moves t to the next working window.
+func (c *Calendar) AddWorkingTime(t time.Time, d time.Duration) (time.Time, error) {
+	const chunk = 7 * 24 * time.Hour
+
+	remaining := d
+	cursor := t
+	for cursor.Sub(t) < MaxCalendarHorizon {
+		intervals, err := c.intervals(cursor, cursor.Add(chunk))
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, iv := range intervals {
+			if d == 0 {
+				return iv.start, nil
+			}
+			available := iv.end.Sub(iv.start)
+			if remaining <= available {
+				return iv.start.Add(remaining), nil
+			}
+			remaining -= available
+		}
+		cursor = cursor.Add(chunk)
+	}
+	return time.Time{}, errors.New("calendar has no working time within a year")
+}
+
+// interval is a half-open time range [start, end)
+type interval struct {
+	start, end time.Time
+}
+
+// intervals returns the merged working intervals clipped to [from, to)
+func (c *Calendar) intervals(from, to time.Time) ([]interval, error) {
+	loc, err := c.location()
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows starting the day before from may run past midnight into it
+	y, m, d := from.In(loc).Date()
+	first := time.Date(y, m, d-1, 0, 0, 0, 0, loc)
+
+	var work, cut []interval
+	for day := first; day.Before(to); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc) {
+		for _, w := range c.Shifts {
+			if !w.appliesOn(day.Weekday()) {
+				continue
+			}
+			iv, err := w.on(day)
+			if err != nil {
+				return nil, err
+			}
+			work = append(work, iv)
+		}
+		for _, w := range c.Breaks {
+			if !w.appliesOn(day.Weekday()) {
+				continue
+			}
+			iv, err := w.on(day)
+			if err != nil {
+				return nil, err
+			}
+			cut = append(cut, iv)
+		}
+	}
+	for _, h := range c.Holidays {
+		date, err := time.ParseInLocation("2006-01-02", h.Date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("holiday date %q must be YYYY-MM-DD", h.Date)
+		}
+		cut = append(cut, interval{date, date.AddDate(0, 0, 1)})
+	}
+	for _, dt := range c.Downtime {
+		cut = append(cut, interval{dt.Start, dt.End})
+	}
+	cut = append(cut, interval{first, from}, interval{to, to.Add(48 * time.Hour)})
+
+	return subtractIntervals(mergeIntervals(work), mergeIntervals(cut)), nil
+}
+
+// mergeIntervals sorts intervals and joins the ones that overlap or touch
+func mergeIntervals(in []interval) []interval {
+	sort.Slice(in, func(i, j int) bool { return in[i].start.Before(in[j].start) })
+	var out []interval
+	for _, iv := range in {
+		if !iv.end.After(iv.start) {
+			continue
+		}
+		if n := len(out); n > 0 && !iv.start.After(out[n-1].end) {
+			if iv.end.After(out[n-1].end) {
+				out[n-1].end = iv.end
+			}
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// subtractIntervals removes cut from base; both must be merged
+func subtractIntervals(base, cut []interval) []interval {
+	var out []interval
+	for _, b := range base {
+		cursor := b.start
+		for _, c := range cut {
+			if !c.end.After(cursor) || !c.start.Before(b.end) {
+				continue
+			}
+			if c.start.After(cursor) {
+				out = append(out, interval{cursor, c.start})
+			}
+			if c.end.After(cursor) {
+				cursor = c.end
+			}
+		}
+		if b.end.After(cursor) {
+			out = append(out, interval{cursor, b.end})
+		}
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+// SignificanceLevel is the alpha below which a difference is reported as significant
+const SignificanceLevel = 1 - ConfidenceLevel
+
+// MaxScenarios bounds a comparison; every scenario without a study runs one
+// within the request
+const MaxScenarios = 10
+
+// Scenario is a named set of aggregated KPIs taken from a study
+type Scenario struct {
+	Name    string
+	StudyID string
+	Metrics map[string]MetricSummary
+}
+
+// MetricComparison compares one KPI of a scenario against the baseline
+type MetricComparison struct {
+	Metric       string        `json:"metric"`
+	Baseline     MetricSummary `json:"baseline"`
+	Scenario     MetricSummary `json:"scenario"`
+	Delta        float64       `json:"delta"`
+	DeltaPercent float64       `json:"delta_percent"`
+	PValue       float64       `json:"p_value"`
+	Significant  bool          `json:"significant"`
+}
+
+// ScenarioComparison holds the comparisons of one scenario against the baseline
+type ScenarioComparison struct {
+	Name    string             `json:"name"`
+	StudyID string             `json:"study_id"`
+	Metrics []MetricComparison `json:"metrics"`
+}
+
+// Comparison is the side-by-side result of a what-if analysis; the first
+// scenario is the baseline and is compared against itself
+type Comparison struct {
+	Baseline        string               `json:"baseline"`
+	ConfidenceLevel float64              `json:"confidence_level"`
+	Scenarios       []ScenarioComparison `json:"scenarios"`
+}
+
+// CompareScenarios compares every scenario against the first one using
+// Welch's two-sided t-test on each KPI
+func CompareScenarios(scenarios []Scenario) (*Comparison, error) {
+	if len(scenarios) < 2 {
+		return nil, errors.New("at least two scenarios are required")
+	}
+
+	baseline := scenarios[0]
+	comparison := &Comparison{Baseline: baseline.Name, ConfidenceLevel: ConfidenceLevel}
+	for _, scenario := range scenarios {
+		result := ScenarioComparison{Name: scenario.Name, StudyID: scenario.StudyID}
+		for _, metric := range []string{MetricMakespan, MetricThroughput, MetricYield} {
+			base, ok := baseline.Metrics[metric]
+			if !ok {
+				continue
+			}
+			other, ok := scenario.Metrics[metric]
+			if !ok {
+				continue
+			}
+
+			mc := MetricComparison{
+				Metric:   metric,
+				Baseline: base,
+				Scenario: other,
+				Delta:    other.Mean - base.Mean,
+				PValue:   WelchTTest(base, other),
+			}
+			if base.Mean != 0 {
+				mc.DeltaPercent = mc.Delta / math.Abs(base.Mean) * 100
+			}
+			mc.Significant = mc.PValue < SignificanceLevel
+			result.Metrics = append(result.Metrics, mc)
+		}
+		comparison.Scenarios = append(comparison.Scenarios, result)
+	}
+	return comparison, nil
+}
+
+// WelchTTest returns the two-sided p-value for the difference of the means of
+// two samples with possibly unequal variances. It returns 1 when there are too
+// few samples to tell.
+func WelchTTest(a, b MetricSummary) float64 {
+	if a.N < 2 || b.N < 2 {
+		return 1
+	}
+
+	va := a.StdDev * a.StdDev / float64(a.N)
+	vb := b.StdDev * b.StdDev / float64(b.N)
+	se := math.Sqrt(va + vb)
+	if se == 0 {
+		if a.Mean == b.Mean {
+			return 1
+		}
+		return 0
+	}
+
+	t := (b.Mean - a.Mean) / se
+	df := (va + vb) * (va + vb) / (va*va/float64(a.N-1) + vb*vb/float64(b.N-1))
+	return studentTTwoSided(t, df)
+}
+
+// studentTTwoSided returns P(|T| >= |t|) for Student's t with df degrees of freedom
+func studentTTwoSided(t, df float64) float64 {
+	return regularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) with the continued fraction
+// from Numerical Recipes
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 3e-14
+		tiny          = 1e-300
+	)
+
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
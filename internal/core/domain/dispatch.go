@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dispatching rules that decide which queued work order runs next
+const (
+	DispatchFIFO     = "fifo"
+	DispatchEDD      = "edd"
+	DispatchSPT      = "spt"
+	DispatchPriority = "priority"
+
+	DefaultDispatchRule = DispatchFIFO
+)
+
+// WorkOrder is a request to run a pipeline that waits in the work-order queue
+type WorkOrder struct {
+	PipelineID uuid.UUID   `json:"pipeline_id"`
+	RunID      uuid.UUID   `json:"run_id"` // Set when the order is queued
+	UserID     uuid.UUID   `json:"user_id"`
+	IsParallel bool        `json:"is_parallel"`
+	Input      interface{} `json:"input"`
+	Seed       int64       `json:"seed"`
+
+	// SkipStages lists the stages an interrupted run already completed; a
+	// resumed run does not execute them again
+	SkipStages []int `json:"skip_stages,omitempty"`
+
+	// Trigger is set on orders queued by a trigger; the run records it
+	Trigger *TriggerCause `json:"trigger,omitempty"`
+
+	// Actor queued the order, e.g. a schedule; the user when empty
+	Actor string `json:"actor,omitempty"`
+
+	// Priority orders work under the priority rule, higher first
+	Priority int        `json:"priority"`
+	DueDate  *time.Time `json:"due_date,omitempty"`
+
+	// ProcessingSeconds is the expected run time used by the SPT rule
+	ProcessingSeconds float64   `json:"processing_seconds"`
+	EnqueuedAt        time.Time `json:"enqueued_at"`
+	Seq               int64     `json:"seq"`
+
+	// OnDone is called with the outcome once the order has run
+	OnDone func(error) `json:"-"`
+}
+
+// DispatchRule orders work orders; Less reports whether a should run before b
+type DispatchRule interface {
+	Name() string
+	Less(a, b *WorkOrder) bool
+}
+
+type fifoRule struct{}
+
+func (fifoRule) Name() string { return DispatchFIFO }
+
+func (fifoRule) Less(a, b *WorkOrder) bool { return a.Seq < b.Seq }
+
+// eddRule runs the earliest due date first; orders without one go last
+type eddRule struct{}
+
+func (eddRule) Name() string { return DispatchEDD }
+
+func (eddRule) Less(a, b *WorkOrder) bool {
+	switch {
+	case a.DueDate != nil && b.DueDate != nil && !a.DueDate.Equal(*b.DueDate):
+		return a.DueDate.Before(*b.DueDate)
+	case a.DueDate != nil && b.DueDate == nil:
+		return true
+	case a.DueDate == nil && b.DueDate != nil:
+		return false
+	}
+	return a.Seq < b.Seq
+}
+
+// sptRule runs the shortest expected processing time first
+type sptRule struct{}
+
+func (sptRule) Name() string { return DispatchSPT }
+
+func (sptRule) Less(a, b *WorkOrder) bool {
+	if a.ProcessingSeconds != b.ProcessingSeconds {
+		return a.ProcessingSeconds < b.ProcessingSeconds
+	}
+	return a.Seq < b.Seq
+}
+
+// priorityRule runs the highest priority first
+type priorityRule struct{}
+
+func (priorityRule) Name() string { return DispatchPriority }
+
+func (priorityRule) Less(a, b *WorkOrder) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.Seq < b.Seq
+}
+
+// DispatchRules holds the available rules by name; register new ones here
+var DispatchRules = map[string]DispatchRule{
+	DispatchFIFO:     fifoRule{},
+	DispatchEDD:      eddRule{},
+	DispatchSPT:      sptRule{},
+	DispatchPriority: priorityRule{},
+}
+
+// DispatchRuleByName looks up a dispatching rule
+func DispatchRuleByName(name string) (DispatchRule, error) {
+	rule, ok := DispatchRules[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(DispatchRules))
+		for n := range DispatchRules {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown dispatch rule %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return rule, nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestDispatchRules(t *testing.T) {
+	due := func(hours int) *time.Time {
+		ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+		return &ts
+	}
+	// Seq is the arrival order; the expected orders list Seqs
+	orders := []WorkOrder{
+		{Seq: 1, Priority: 0, DueDate: nil, ProcessingSeconds: 30},
+		{Seq: 2, Priority: 5, DueDate: due(8), ProcessingSeconds: 10},
+		{Seq: 3, Priority: 5, DueDate: due(2), ProcessingSeconds: 60},
+		{Seq: 4, Priority: 1, DueDate: due(8), ProcessingSeconds: 10},
+		{Seq: 5, Priority: -1, DueDate: nil, ProcessingSeconds: 5},
+	}
+
+	tests := []struct {
+		rule string
+		want []int64
+	}{
+		{rule: DispatchFIFO, want: []int64{1, 2, 3, 4, 5}},
+		{rule: DispatchEDD, want: []int64{3, 2, 4, 1, 5}},
+		{rule: DispatchSPT, want: []int64{5, 2, 4, 1, 3}},
+		{rule: DispatchPriority, want: []int64{2, 3, 4, 1, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := DispatchRuleByName(tt.rule)
+			if err != nil {
+				t.Fatalf("DispatchRuleByName(%q) failed: %v", tt.rule, err)
+			}
+			if rule.Name() != tt.rule {
+				t.Errorf("Name() = %q, want %q", rule.Name(), tt.rule)
+			}
+
+			// Start from the reverse of the arrival order so ties are broken by Seq, not by position
+			queue := make([]*WorkOrder, len(orders))
+			for i := range orders {
+				queue[len(orders)-1-i] = &orders[i]
+			}
+			sort.Slice(queue, func(i, j int) bool { return rule.Less(queue[i], queue[j]) })
+
+			for i, order := range queue {
+				if order.Seq != tt.want[i] {
+					got := make([]int64, len(queue))
+					for k, o := range queue {
+						got[k] = o.Seq
+					}
+					t.Fatalf("dispatch order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchRuleByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "fifo", want: DispatchFIFO},
+		{name: "EDD", want: DispatchEDD},
+		{name: "Priority", want: DispatchPriority},
+		{name: "lifo", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := DispatchRuleByName(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DispatchRuleByName(%q) = %s, want an error", tt.name, rule.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DispatchRuleByName(%q) failed: %v", tt.name, err)
+			}
+			if rule.Name() != tt.want {
+				t.Errorf("DispatchRuleByName(%q) = %s, want %s", tt.name, rule.Name(), tt.want)
+			}
+		})
+	}
+}
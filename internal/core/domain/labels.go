@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// Limits of pipeline labels and annotations. Labels are short and selectable;
+// annotations hold free-form text such as notes or links.
+const (
+	MaxLabels          = 64
+	MaxLabelLength     = 63
+	MaxKeyPrefixLength = 253
+	MaxAnnotationBytes = 256 * 1024
+)
+
+// ErrInvalidMetadata matches every rejected label, annotation or selector
+var ErrInvalidMetadata = errors.New("invalid pipeline metadata")
+
+var (
+	labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	keyPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	setTermPattern   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// PipelineMetadata are the labels and annotations of a pipeline
+type PipelineMetadata struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// Validate checks the labels and annotations
+func (m PipelineMetadata) Validate() error {
+	if err := ValidateLabels(m.Labels); err != nil {
+		return err
+	}
+	return ValidateAnnotations(m.Annotations)
+}
+
+// ValidateMetadataKey checks a label or annotation key: a name of at most 63
+// letters, digits, '-', '_' or '.', starting and ending alphanumeric, with an
+// optional DNS subdomain prefix such as "example.com/team"
+func ValidateMetadataKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > MaxKeyPrefixLength || !keyPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("%w: key %q has an invalid prefix", ErrInvalidMetadata, key)
+		}
+	}
+	if len(name) > MaxLabelLength || !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: invalid key %q", ErrInvalidMetadata, key)
+	}
+	return nil
+}
+
+// ValidateLabelValue checks a label value: empty, or at most 63 characters
+// following the rules of key names
+func ValidateLabelValue(value string) error {
+	if value != "" && (len(value) > MaxLabelLength || !labelNamePattern.MatchString(value)) {
+		return fmt.Errorf("%w: invalid label value %q", ErrInvalidMetadata, value)
+	}
+	return nil
+}
+
+// ValidateLabels checks the keys and values of a set of labels
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("%w: at most %d labels are allowed", ErrInvalidMetadata, MaxLabels)
+	}
+	for key, value := range labels {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+		if err := ValidateLabelValue(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAnnotations checks the keys of a set of annotations and their total size
+func ValidateAnnotations(annotations map[string]string) error {
+	size := 0
+	for key, value := range annotations {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+		size += len(key) + len(value)
+	}
+	if size > MaxAnnotationBytes {
+		return fmt.Errorf("%w: annotations exceed %d bytes", ErrInvalidMetadata, MaxAnnotationBytes)
+	}
+	return nil
+}
+
+// ApplyMetadataPatch returns the labels or annotations current becomes under patch
+func ApplyMetadataPatch(current map[string]string, patch ports.MetadataPatch) map[string]string {
+	result := make(map[string]string, len(current)+len(patch.Set))
+	for key, value := range current {
+		result[key] = value
+	}
+	for _, key := range patch.Remove {
+		delete(result, key)
+	}
+	for key, value := range patch.Set {
+		result[key] = value
+	}
+	return result
+}
+
+// ParseSelector parses a label selector: comma-separated requirements of the
+// forms "key=value" (or "=="), "key!=value", "key in (a,b)", "key notin (a,b)",
+// "key" (has the label) and "!key" (lacks it). Every requirement must hold.
+func ParseSelector(selector string) ([]ports.LabelRequirement, error) {
+	var requirements []ports.LabelRequirement
+	for _, term := range splitSelector(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		requirement, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateMetadataKey(requirement.Key); err != nil {
+			return nil, err
+		}
+		for _, value := range requirement.Values {
+			if err := ValidateLabelValue(value); err != nil {
+				return nil, err
+			}
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// splitSelector splits a selector at the commas outside parentheses
+func splitSelector(selector string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+func parseRequirement(term string) (ports.LabelRequirement, error) {
+	if m := setTermPattern.FindStringSubmatch(term); m != nil {
+		var values []string
+		for _, value := range strings.Split(m[3], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			return ports.LabelRequirement{}, fmt.Errorf("%w: %q lists no values", ErrInvalidMetadata, term)
+		}
+		operator := ports.LabelIn
+		if m[2] == "notin" {
+			operator = ports.LabelNotIn
+		}
+		return ports.LabelRequirement{Key: m[1], Operator: operator, Values: values}, nil
+	}
+	if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		return ports.LabelRequirement{Key: strings.TrimSpace(term[1:]), Operator: ports.LabelDoesNotExist}, nil
+	}
+	for _, op := range []string{"!=", "==", "="} {
+		if i := strings.Index(term, op); i >= 0 {
+			operator := ports.LabelEquals
+			if op == "!=" {
+				operator = ports.LabelNotEquals
+			}
+			key, value := strings.TrimSpace(term[:i]), strings.TrimSpace(term[i+len(op):])
+			return ports.LabelRequirement{Key: key, Operator: operator, Values: []string{value}}, nil
+		}
+	}
+	if strings.ContainsAny(term, " ()") {
+		return ports.LabelRequirement{}, fmt.Errorf("%w: cannot parse selector term %q", ErrInvalidMetadata, term)
+	}
+	return ports.LabelRequirement{Key: term, Operator: ports.LabelExists}, nil
+}
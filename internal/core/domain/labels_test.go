@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []ports.LabelRequirement
+		wantErr  bool
+	}{
+		{selector: "", want: nil},
+		{selector: " , ", want: nil},
+		{selector: "line=assembly", want: []ports.LabelRequirement{
+			{Key: "line", Operator: ports.LabelEquals, Values: []string{"assembly"}},
+		}},
+		{selector: "line == assembly", want: []ports.LabelRequirement{
+			{Key: "line", Operator: ports.LabelEquals, Values: []string{"assembly"}},
+		}},
+		{selector: "line!=paint", want: []ports.LabelRequirement{
+			{Key: "line", Operator: ports.LabelNotEquals, Values: []string{"paint"}},
+		}},
+		{selector: "tier=", want: []ports.LabelRequirement{
+			{Key: "tier", Operator: ports.LabelEquals, Values: []string{""}},
+		}},
+		{selector: "plant in (berlin, austin)", want: []ports.LabelRequirement{
+			{Key: "plant", Operator: ports.LabelIn, Values: []string{"berlin", "austin"}},
+		}},
+		{selector: "plant notin (berlin)", want: []ports.LabelRequirement{
+			{Key: "plant", Operator: ports.LabelNotIn, Values: []string{"berlin"}},
+		}},
+		{selector: "example.com/owner", want: []ports.LabelRequirement{
+			{Key: "example.com/owner", Operator: ports.LabelExists},
+		}},
+		{selector: "!deprecated", want: []ports.LabelRequirement{
+			{Key: "deprecated", Operator: ports.LabelDoesNotExist},
+		}},
+		{selector: "line=assembly,plant in (berlin,austin),!deprecated", want: []ports.LabelRequirement{
+			{Key: "line", Operator: ports.LabelEquals, Values: []string{"assembly"}},
+			{Key: "plant", Operator: ports.LabelIn, Values: []string{"berlin", "austin"}},
+			{Key: "deprecated", Operator: ports.LabelDoesNotExist},
+		}},
+		{selector: "plant in ()", wantErr: true},
+		{selector: "plant in (, )", wantErr: true},
+		{selector: "plant in berlin", wantErr: true},
+		{selector: "line=has space", wantErr: true},
+		{selector: "-line=assembly", wantErr: true},
+		{selector: "=assembly", wantErr: true},
+		{selector: "Example.com/owner", wantErr: true},
+		{selector: "line=" + strings.Repeat("a", MaxLabelLength+1), wantErr: true},
+		{selector: "plant in (berlin,-austin)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseSelector(tt.selector)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMetadata) {
+					t.Fatalf("ParseSelector(%q) = %+v, %v, want ErrInvalidMetadata", tt.selector, got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) failed: %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelector(%q) = %+v, want %+v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMetadataKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{key: "line"},
+		{key: "cost_center.v2"},
+		{key: "example.com/owner"},
+		{key: "a.b-c.io/x"},
+		{key: "", wantErr: true},
+		{key: "_line", wantErr: true},
+		{key: "line-", wantErr: true},
+		{key: "/owner", wantErr: true},
+		{key: "example.com/", wantErr: true},
+		{key: "Example.com/owner", wantErr: true},
+		{key: strings.Repeat("a", MaxLabelLength), wantErr: false},
+		{key: strings.Repeat("a", MaxLabelLength+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := ValidateMetadataKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMetadataKey(%q) = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
 )
@@ -20,6 +19,7 @@ type ParallelPipelineOrchestrator struct {
 	mu         sync.Mutex
 	dbRepo ports.PipelineRepository
 	SSE        *utils.SSEManager // ✅ Add SSEManager
+	Executor   StageExecutor
 }
 
 // NewParallelPipelineOrchestrator initializes a new parallel orchestrator
@@ -31,6 +31,7 @@ func NewParallelPipelineOrchestrator(pipelineID uuid.UUID, dbRepo ports.Pipeline
 		dbRepo:     dbRepo,
 		Stages:     []Stage{},
 		SSE:        sse,
+		Executor:   LocalExecutor{},
 	}
 }
 
@@ -45,7 +46,7 @@ func (p *ParallelPipelineOrchestrator) AddStage(stage Stage) error {
 	return nil
 }
 
-func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}) (uuid.UUID, interface{}, error) {
+func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}, run *RunContext) (uuid.UUID, interface{}, error) {
 	// ✅ Step 1: Validate user existence
 	user, err := p.dbRepo.GetUserByID(userID)
 	if err != nil {
@@ -55,6 +56,8 @@ func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.
 	if user == nil {
 		return pipelineID, nil, errors.New("user does not exist")
 	}
+	utils.ActiveExecutions.WithLabelValues("parallel").Inc()
+	defer utils.ActiveExecutions.WithLabelValues("parallel").Dec()
 
 	// 🔹 Broadcast pipeline start event via SSE
 	p.SSE.BroadcastUpdate(map[string]interface{}{
@@ -62,28 +65,37 @@ func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.
 		"pipeline_id": pipelineID.String(),
 		"status":      "Running",
 	})
+	run.Recorder.Record(ExecutionEvent{Type: EventPipeline, StageIndex: -1, Status: "Running"})
 
-	// ✅ Step 2: Update pipeline execution status
-	if err := p.dbRepo.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		Status:     "Running",
-		UpdatedAt:  time.Now(),
-	}); err != nil {
-		log.Printf("Failed to update pipeline execution status: %v", err)
-		return pipelineID, nil, err
-	}
-
-	// ✅ Step 3: Execute stages in parallel
+	// ✅ Step 2: Execute stages in parallel
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	results := make([]interface{}, 0, len(p.Stages))
+	// Each stage writes only its own slot; stages completed before the run
+	// resumed keep their stored output
+	results := make([]interface{}, len(p.Stages))
+	done := make([]bool, len(p.Stages))
 	errorsSlice := make([]error, 0, len(p.Stages))
+	var makespan time.Duration
+
+	for i := range p.Stages {
+		if run.Skips(i) {
+			results[i] = run.Outputs[i]
+			done[i] = true
+		}
+	}
 
-	for _, stage := range p.Stages {
+	for i, stage := range p.Stages {
+		if run.Skips(i) {
+			continue
+		}
 		wg.Add(1)
-		go func(stage Stage) {
+		go func(i int, stage Stage) {
 			defer wg.Done()
 
+			// Every stage starts at the beginning of the run, once its calendar allows work
+			stageRun := run.ForStage(pipelineID, i, 0, p.SSE)
+			err := stageRun.AwaitWorkingTime(ctx)
+
 			// 🔹 Broadcast stage start event via SSE
 			p.SSE.BroadcastUpdate(map[string]interface{}{
 				"type":        "stage",
@@ -91,19 +103,22 @@ func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.
 				"pipeline_id": pipelineID.String(),
 				"status":      "Running",
 			})
+			run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Running", Offset: stageRun.Offset})
 
-			result, err := stage.Execute(ctx, input, p.SSE, pipelineID)
-
-			logEntry := &models.ExecutionLog{
-				StageID:    stage.GetID(),
-				PipelineID: pipelineID,
-				Status:     "Completed",
-				Timestamp:  time.Now(),
+			var result interface{}
+			logEntry := newStageLog(pipelineID, run, i, stage, stageRun, input)
+			if err == nil {
+				result, err = p.Executor.ExecuteStage(ctx, stage, input, stageRun)
 			}
+			mu.Lock()
+			if stageRun.Offset > makespan {
+				makespan = stageRun.Offset
+			}
+			mu.Unlock()
+			finishStageLog(logEntry, stageRun, result, err)
+			utils.StageDuration.WithLabelValues("parallel", logEntry.Status).Observe(float64(logEntry.DurationMs) / 1000)
 
 			if err != nil {
-				logEntry.Status = "Failed"
-				logEntry.ErrorMsg = err.Error()
 				mu.Lock()
 				errorsSlice = append(errorsSlice, err)
 				mu.Unlock()
@@ -115,10 +130,12 @@ func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.
 					"pipeline_id": pipelineID.String(),
 					"status":      "Failed",
 				})
+				run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Failed", Offset: stageRun.Offset})
 
 			} else {
 				mu.Lock()
-				results = append(results, result)
+				results[i] = result
+				done[i] = true
 				mu.Unlock()
 
 				// 🔹 Broadcast stage completion via SSE
@@ -128,43 +145,47 @@ func (p *ParallelPipelineOrchestrator) Execute(ctx context.Context, userID uuid.
 					"pipeline_id": pipelineID.String(),
 					"status":      "Completed",
 				})
+				run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Completed", Offset: stageRun.Offset})
 			}
 
 			// Save execution log for each stage
 			if err := p.dbRepo.SaveExecutionLog(logEntry); err != nil {
 				log.Printf("Failed to save execution log: %v", err)
 			}
-		}(stage)
+		}(i, stage)
 	}
 
 	wg.Wait()
 
-	// ✅ Step 4: Update pipeline execution status
+	// ✅ Step 3: The run fails if any stage failed; PipelineService records the status
 	finalStatus := "Completed"
 	if len(errorsSlice) > 0 {
 		finalStatus = "Failed"
 	}
 
-	if err := p.dbRepo.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		Status:     finalStatus,
-		UpdatedAt:  time.Now(),
-	}); err != nil {
-		log.Printf("Failed to update final pipeline execution status: %v", err)
-	}
-
 	// 🔹 Broadcast pipeline completion via SSE
 	p.SSE.BroadcastUpdate(map[string]interface{}{
 		"type":        "pipeline",
 		"pipeline_id": pipelineID.String(),
 		"status":      finalStatus,
 	})
+	run.Recorder.Record(ExecutionEvent{Type: EventPipeline, StageIndex: len(p.Stages), Status: finalStatus, Offset: makespan})
 
-	if len(results) == 0 {
+	if len(errorsSlice) > 0 {
+		return pipelineID, nil, errorsSlice[0]
+	}
+	outputs := make([]interface{}, 0, len(results))
+	for i, result := range results {
+		if done[i] {
+			outputs = append(outputs, result)
+		}
+	}
+	if len(outputs) == 0 {
 		return pipelineID, nil, errors.New("no valid results from pipeline stages")
 	}
 
-	return pipelineID, results, nil
+	// Outputs are in stage order, whichever stage finished first
+	return pipelineID, outputs, nil
 }
 
 // GetStatus retrieves the status of a pipeline from the database
@@ -172,7 +193,7 @@ func (p *ParallelPipelineOrchestrator) GetStatus(pipelineID uuid.UUID) (string,
 	return p.dbRepo.GetPipelineStatus(pipelineID.String())
 }
 
-// Cancel updates the pipeline execution status to "Canceled"
+// Cancel checks the pipeline can be cancelled; PipelineService records the transition
 func (p *ParallelPipelineOrchestrator) Cancel(pipelineID uuid.UUID, userID uuid.UUID) error {
 	log.Printf("Cancelling pipeline: %s for user: %s", pipelineID, userID)
 
@@ -182,21 +203,9 @@ func (p *ParallelPipelineOrchestrator) Cancel(pipelineID uuid.UUID, userID uuid.
 		return errors.New("pipeline not found")
 	}
 
-	if status == "Completed" {
-		log.Printf("Pipeline %s is already completed, cannot cancel", pipelineID)
-		return errors.New("cannot cancel a completed pipeline")
-	}
-	log.Printf("Cancelling pipeline %s...", pipelineID)
-
-	err = p.dbRepo.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID, // ✅ Ensure correct pipeline ID
-		Status:     "Cancelled",
-		UpdatedAt:  time.Now(),
-	})
-
-	if err != nil {
-		log.Printf("Failed to update pipeline status: %v", err)
-		return errors.New("failed to update pipeline status")
+	if err := PipelineStates.Validate(Status(status), StatusCancelled); err != nil {
+		log.Printf("Pipeline %s cannot be cancelled: %v", pipelineID, err)
+		return err
 	}
 
 	log.Printf("Pipeline %s successfully cancelled", pipelineID)
@@ -9,7 +9,7 @@ import (
 // PipelineOrchestrator defines the core contract for executing pipelines
 type PipelineOrchestrator interface {
 	AddStage(stage Stage) error
-	Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}) (uuid.UUID, interface{}, error)
+	Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}, run *RunContext) (uuid.UUID, interface{}, error)
 	GetStatus(pipelineID uuid.UUID) (string, error)
 	Cancel(pipelineID uuid.UUID, userID uuid.UUID) error
 }
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// Event kinds recorded for every execution
+const (
+	EventPipeline = "pipeline"
+	EventStage    = "stage"
+)
+
+// RunConfig is the full configuration of an execution; together with the
+// seed it is enough to replay the execution exactly. The calendars in effect
+// are stored as snapshots so later edits do not change a replay.
+type RunConfig struct {
+	IsParallel     bool              `json:"is_parallel"`
+	Stages         []StageSpec       `json:"stages"`
+	Input          interface{}       `json:"input"`
+	StartedAt      time.Time         `json:"started_at"`
+	Calendar       *Calendar         `json:"calendar,omitempty"`
+	StageCalendars map[int]*Calendar `json:"stage_calendars,omitempty"`
+}
+
+// RunContext carries the per-execution settings that make a run reproducible
+type RunContext struct {
+	RunID    uuid.UUID // Tags the logs the run writes
+	Seed     int64
+	Clock    Clock
+	Recorder *EventRecorder
+
+	// Start anchors the logical offsets of the run in calendar time. Calendar
+	// applies to every stage without an entry in StageCalendars.
+	Start          time.Time
+	Calendar       *Calendar
+	StageCalendars map[int]*Calendar
+
+	// Completed holds the stages finished before an interruption; resumed
+	// runs skip them and use their stored Outputs instead
+	Completed map[int]bool
+	Outputs   map[int]interface{}
+
+	// Attempts counts the attempts each stage made before the run was
+	// resumed or re-run; the next one logs as Attempts+1
+	Attempts map[int]int
+}
+
+// NewRunContext creates a run context starting at the clock's current time; a
+// nil clock means the wall clock
+func NewRunContext(seed int64, clock Clock) *RunContext {
+	if clock == nil {
+		clock = RealClock{}
+	}
+	return &RunContext{
+		Seed:     seed,
+		Clock:    clock,
+		Recorder: &EventRecorder{},
+		Start:    clock.Now(),
+	}
+}
+
+// Skips reports whether the stage at index was completed before the run resumed
+func (r *RunContext) Skips(index int) bool {
+	return r.Completed[index]
+}
+
+// Attempt returns the number the stage at index logs its attempt under
+func (r *RunContext) Attempt(index int) int {
+	return r.Attempts[index] + 1
+}
+
+// ForStage builds the view of the run handed to the stage at index, starting
+// at the given logical offset
+func (r *RunContext) ForStage(pipelineID uuid.UUID, index int, start time.Duration, sse *utils.SSEManager) *StageRun {
+	calendar := r.Calendar
+	if c, ok := r.StageCalendars[index]; ok {
+		calendar = c
+	}
+	seed := stageSeed(r.Seed, index)
+	return &StageRun{
+		PipelineID: pipelineID,
+		Index:      index,
+		Seed:       seed,
+		Rand:       rand.New(rand.NewSource(seed)),
+		Clock:      r.Clock,
+		SSE:        sse,
+		Offset:     start,
+		Start:      r.Start,
+		Calendar:   calendar,
+	}
+}
+
+// stageSeed derives an independent seed per stage (splitmix64) so random draws
+// do not depend on the order in which parallel stages are scheduled
+func stageSeed(seed int64, index int) int64 {
+	z := uint64(seed) + uint64(index+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
+
+// StageRun is what a stage sees of the execution it belongs to
+type StageRun struct {
+	PipelineID uuid.UUID
+	Index      int
+	Seed       int64 // Seed of Rand, so a remote worker can draw the same numbers
+	Rand       *rand.Rand
+	Clock      Clock
+	SSE        *utils.SSEManager
+
+	// Offset is the logical time since the start of the run; it only moves
+	// through Sleep and AwaitWorkingTime so it is identical across replays
+	Offset time.Duration
+
+	// Start and Calendar place the offset in calendar time; a nil calendar
+	// means the stage works around the clock
+	Start    time.Time
+	Calendar *Calendar
+}
+
+// Sleep processes for d of working time, pausing outside the stage's calendar,
+// and advances the logical offset
+func (s *StageRun) Sleep(ctx context.Context, d time.Duration) error {
+	if s.Calendar != nil {
+		at := s.Start.Add(s.Offset)
+		finish, err := s.Calendar.AddWorkingTime(at, d)
+		if err != nil {
+			return err
+		}
+		d = finish.Sub(at)
+	}
+	return s.wait(ctx, d)
+}
+
+// AwaitWorkingTime waits until the stage's calendar allows work to start
+func (s *StageRun) AwaitWorkingTime(ctx context.Context) error {
+	if s.Calendar == nil {
+		return nil
+	}
+	at := s.Start.Add(s.Offset)
+	next, err := s.Calendar.NextWorkingTime(at)
+	if err != nil {
+		return err
+	}
+	return s.wait(ctx, next.Sub(at))
+}
+
+func (s *StageRun) wait(ctx context.Context, d time.Duration) error {
+	if err := s.Clock.Sleep(ctx, d); err != nil {
+		return err
+	}
+	s.Offset += d
+	return nil
+}
+
+// ExecutionEvent is one entry of the recorded event sequence of a run
+type ExecutionEvent struct {
+	Type       string        `json:"type"`
+	StageIndex int           `json:"stage_index"`
+	StageID    uuid.UUID     `json:"stage_id"`
+	Status     string        `json:"status"`
+	Offset     time.Duration `json:"offset_ns"`
+}
+
+// phase orders the events of one stage that share an offset: start before finish
+func (e ExecutionEvent) phase() int {
+	if e.Status == "Running" {
+		return 0
+	}
+	return 1
+}
+
+// EventRecorder collects the events of a run from concurrent stages
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []ExecutionEvent
+}
+
+// Record appends an event
+func (r *EventRecorder) Record(event ExecutionEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+}
+
+// Events returns the recorded events in canonical order: by offset, then by
+// stage index (the pipeline start uses -1 and its end len(stages)), then start
+// before finish
+func (r *EventRecorder) Events() []ExecutionEvent {
+	r.mu.Lock()
+	events := append([]ExecutionEvent(nil), r.events...)
+	r.mu.Unlock()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		if a.StageIndex != b.StageIndex {
+			return a.StageIndex < b.StageIndex
+		}
+		return a.phase() < b.phase()
+	})
+	return events
+}
+
+// EventDiff is a position where two event sequences disagree; a nil side
+// means the sequence has no event at that position
+type EventDiff struct {
+	Seq      int             `json:"seq"`
+	Original *ExecutionEvent `json:"original"`
+	Replay   *ExecutionEvent `json:"replay"`
+}
+
+// DiffEvents compares two event sequences position by position. Stage IDs are
+// ignored because a replay runs on fresh stages.
+func DiffEvents(original, replay []ExecutionEvent) []EventDiff {
+	var diffs []EventDiff
+	n := len(original)
+	if len(replay) > n {
+		n = len(replay)
+	}
+	for i := 0; i < n; i++ {
+		var a, b *ExecutionEvent
+		if i < len(original) {
+			a = &original[i]
+		}
+		if i < len(replay) {
+			b = &replay[i]
+		}
+		if a != nil && b != nil && a.Type == b.Type && a.StageIndex == b.StageIndex &&
+			a.Status == b.Status && a.Offset == b.Offset {
+			continue
+		}
+		diffs = append(diffs, EventDiff{Seq: i, Original: a, Replay: b})
+	}
+	return diffs
+}
@@ -0,0 +1,314 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Missed-fire policies decide what a schedule does about the fire times that
+// passed while no server was running it, or while its pipeline was busy
+const (
+	// MissedSkip drops missed fires and waits for the next fire time
+	MissedSkip = "skip"
+	// MissedRunOnce fires once for any number of missed fires
+	MissedRunOnce = "run_once"
+	// MissedCatchUp fires every missed fire time in turn, one run at a time
+	MissedCatchUp = "catch_up"
+
+	DefaultMissedPolicy = MissedSkip
+)
+
+// MisfireGrace is how late a fire may happen and still count as on time
+const MisfireGrace = time.Minute
+
+// MaxCatchUpFires bounds the backlog a catch_up schedule works off; older
+// missed fires are dropped
+const MaxCatchUpFires = 100
+
+// ValidMissedPolicy reports whether policy names a missed-fire policy
+func ValidMissedPolicy(policy string) bool {
+	switch policy {
+	case MissedSkip, MissedRunOnce, MissedCatchUp:
+		return true
+	}
+	return false
+}
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week. Fields take *, values, ranges, lists and
+// steps; months and weekdays also take names (JAN, MON). The macros @yearly,
+// @monthly, @weekly, @daily and @hourly are accepted too.
+type CronSchedule struct {
+	Expr string
+
+	minute, hour, dom, month, dow uint64
+	// When day of month and day of week are both restricted, a day matching
+	// either runs, as in standard cron
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+var weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// 7 is accepted for Sunday and folded onto 0
+	{name: "day of week", min: 0, max: 7, names: weekdayNames},
+}
+
+// ParseCron parses a cron expression
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(parts))
+	}
+
+	bits := make([]uint64, len(parts))
+	for i, part := range parts {
+		b, err := cronFields[i].parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		bits[i] = b
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &CronSchedule{
+		Expr:    strings.TrimSpace(expr),
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: parts[2] == "*" || parts[2] == "?",
+		dowStar: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// parse turns one comma-separated field into a bit set of its values
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepText, f.name)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = f.min, f.max
+		case strings.Contains(rng, "-"):
+			from, to, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(from); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(to); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q in %s", rng, f.name)
+			}
+		default:
+			v, err := f.value(rng)
+			if err != nil {
+				return 0, err
+			}
+			// 5/15 means every 15 from 5 on
+			lo, hi = v, v
+			if hasStep {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q, expected %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first fire time after after, evaluated on the wall clock
+// of loc. Wall-clock times skipped by a DST change do not fire that day, and
+// repeated ones fire once. It returns the zero time when the expression never
+// matches, e.g. on February 30th.
+func (c *CronSchedule) Next(after time.Time, loc *time.Location) time.Time {
+	t := after.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		var next time.Time
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchesDay(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			next = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			next = t.Add(time.Minute)
+		case time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Before(t):
+			// Second pass over a wall-clock time repeated by a DST change
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// Normalizing a wall-clock time around a DST change can land on or
+		// before t; never go back
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+		t = next
+	}
+	return time.Time{}
+}
+
+// FirePlan says what a due schedule does on a tick
+type FirePlan struct {
+	Fire     *time.Time // Fire time to run now, nil if this tick runs nothing
+	NextFire time.Time  // Fire time the schedule waits for next
+	Missed   int        // Fire times dropped by the policy
+}
+
+// PlanFire applies a missed-fire policy to a schedule due at due. Every fire
+// time between due and now that is more than MisfireGrace old counts as
+// missed: skip drops them, run_once runs the latest fire time once, and
+// catch_up runs the oldest and leaves the rest due.
+func (c *CronSchedule) PlanFire(due time.Time, now time.Time, loc *time.Location, policy string) FirePlan {
+	count, recent := c.firesBetween(due, now, loc)
+	upcoming := c.Next(now, loc)
+	latest := recent[len(recent)-1]
+
+	switch policy {
+	case MissedCatchUp:
+		plan := FirePlan{Fire: &recent[0], NextFire: upcoming, Missed: count - len(recent)}
+		if len(recent) > 1 {
+			plan.NextFire = recent[1]
+		}
+		return plan
+	case MissedRunOnce:
+		return FirePlan{Fire: &latest, NextFire: upcoming, Missed: count - 1}
+	default:
+		if now.Sub(latest) > MisfireGrace {
+			return FirePlan{NextFire: upcoming, Missed: count}
+		}
+		return FirePlan{Fire: &latest, NextFire: upcoming, Missed: count - 1}
+	}
+}
+
+// firesBetween counts the fire times from due up to now and returns the
+// latest MaxCatchUpFires of them
+func (c *CronSchedule) firesBetween(due time.Time, now time.Time, loc *time.Location) (int, []time.Time) {
+	count := 0
+	var recent []time.Time
+	for t := due; !t.IsZero() && !t.After(now); t = c.Next(t, loc) {
+		count++
+		recent = append(recent, t)
+		if len(recent) > MaxCatchUpFires {
+			recent = recent[1:]
+		}
+	}
+	if len(recent) == 0 {
+		// Not due yet; treat due as the only fire time
+		return 1, []time.Time{due}
+	}
+	return count, recent
+}
+
+// ScheduleSpec configures a pipeline schedule
+type ScheduleSpec struct {
+	Name         string      `json:"name"`
+	Cron         string      `json:"cron"`
+	Timezone     string      `json:"timezone"`      // IANA zone the cron expression is read in; UTC by default
+	MissedPolicy string      `json:"missed_policy"` // skip, run_once or catch_up; skip by default
+	Input        interface{} `json:"input"`         // Input of every run the schedule starts
+	Priority     int         `json:"priority"`      // Work-order priority of those runs
+}
+
+// WithDefaults fills in the time zone and missed-fire policy
+func (s ScheduleSpec) WithDefaults() ScheduleSpec {
+	if s.Timezone == "" {
+		s.Timezone = "UTC"
+	}
+	if s.MissedPolicy == "" {
+		s.MissedPolicy = DefaultMissedPolicy
+	}
+	return s
+}
+
+// Compile checks the spec and returns its cron expression and time zone
+func (s ScheduleSpec) Compile() (*CronSchedule, *time.Location, error) {
+	if len(s.Name) > 100 {
+		return nil, nil, fmt.Errorf("schedule name is at most 100 characters")
+	}
+	if !ValidMissedPolicy(s.MissedPolicy) {
+		return nil, nil, fmt.Errorf("unknown missed-fire policy %q, expected %s, %s or %s", s.MissedPolicy, MissedSkip, MissedRunOnce, MissedCatchUp)
+	}
+	cron, err := ParseCron(s.Cron)
+	if err != nil {
+		return nil, nil, err
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown timezone %q", s.Timezone)
+	}
+	if cron.Next(time.Now(), loc).IsZero() {
+		return nil, nil, fmt.Errorf("cron expression %q never fires", s.Cron)
+	}
+	return cron, loc, nil
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/15 * * * *"},
+		{expr: "0 9 * * MON-FRI"},
+		{expr: "0 0 1 jan,jul *"},
+		{expr: "5/20 1-3 ? * 7"},
+		{expr: "  @daily  "},
+		{expr: "@HOURLY"},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "*/x * * * *", wantErr: true},
+		{expr: "30-10 * * * *", wantErr: true},
+		{expr: "0 0 * FOO *", wantErr: true},
+		{expr: "@every 5m", wantErr: true},
+		{expr: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCron(%q) = %+v, want an error", tt.expr, cron)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	utc := func(value string) time.Time {
+		ts, err := time.Parse("2006-01-02 15:04:05", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	local := func(value string, offset string) time.Time {
+		ts, err := time.Parse("2006-01-02 15:04 -0700", value+" "+offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		loc   *time.Location
+		want  time.Time
+	}{
+		{name: "step", expr: "*/15 * * * *", after: utc("2025-01-01 10:07:00"), loc: time.UTC, want: utc("2025-01-01 10:15:00")},
+		{name: "strictly after", expr: "*/15 * * * *", after: utc("2025-01-01 10:15:00"), loc: time.UTC, want: utc("2025-01-01 10:30:00")},
+		{name: "seconds truncated", expr: "*/15 * * * *", after: utc("2025-01-01 10:14:59"), loc: time.UTC, want: utc("2025-01-01 10:15:00")},
+		{name: "weekdays skip the weekend", expr: "0 9 * * MON-FRI", after: utc("2025-01-03 09:00:00"), loc: time.UTC, want: utc("2025-01-06 09:00:00")},
+		{name: "month rollover", expr: "@monthly", after: utc("2025-01-31 12:00:00"), loc: time.UTC, want: utc("2025-02-01 00:00:00")},
+		{name: "year rollover", expr: "0 0 1 1 *", after: utc("2025-06-01 00:00:00"), loc: time.UTC, want: utc("2026-01-01 00:00:00")},
+		{name: "leap day", expr: "0 0 29 2 *", after: utc("2025-03-01 00:00:00"), loc: time.UTC, want: utc("2028-02-29 00:00:00")},
+		{name: "day of month or weekday", expr: "0 0 13 * FRI", after: utc("2025-01-01 00:00:00"), loc: time.UTC, want: utc("2025-01-03 00:00:00")},
+		{name: "sunday as 7", expr: "0 0 * * 7", after: utc("2025-01-01 00:00:00"), loc: time.UTC, want: utc("2025-01-05 00:00:00")},
+		{name: "never", expr: "0 0 30 2 *", after: utc("2025-01-01 00:00:00"), loc: time.UTC, want: time.Time{}},
+		{name: "time zone", expr: "0 9 * * *", after: utc("2025-01-01 15:00:00"), loc: newYork, want: local("2025-01-02 09:00", "-0500")},
+		{name: "skipped by spring forward", expr: "30 2 * * *", after: local("2025-03-08 03:00", "-0500"), loc: newYork, want: local("2025-03-10 02:30", "-0400")},
+		{name: "spring forward keeps the hour after", expr: "30 3 * * *", after: local("2025-03-08 04:00", "-0500"), loc: newYork, want: local("2025-03-09 03:30", "-0400")},
+		{name: "first of a repeated time", expr: "30 1 * * *", after: local("2025-11-01 12:00", "-0400"), loc: newYork, want: local("2025-11-02 01:30", "-0400")},
+		{name: "repeated time fires once", expr: "30 1 * * *", after: local("2025-11-02 01:30", "-0400"), loc: newYork, want: local("2025-11-03 01:30", "-0500")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			if got := cron.Next(tt.after, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronPlanFire(t *testing.T) {
+	at := func(minutes int) time.Time {
+		return time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+	}
+	fire := func(minutes int) *time.Time {
+		ts := at(minutes)
+		return &ts
+	}
+
+	tests := []struct {
+		name   string
+		expr   string
+		due    time.Time
+		now    time.Time
+		policy string
+		want   FirePlan
+	}{
+		{name: "on time", expr: "0 * * * *", due: at(0), now: at(0).Add(30 * time.Second), policy: MissedSkip,
+			want: FirePlan{Fire: fire(0), NextFire: at(60)}},
+		{name: "skip runs the latest fire within grace", expr: "0 * * * *", due: at(0), now: at(180).Add(30 * time.Second), policy: MissedSkip,
+			want: FirePlan{Fire: fire(180), NextFire: at(240), Missed: 3}},
+		{name: "skip drops late fires", expr: "0 * * * *", due: at(0), now: at(185), policy: MissedSkip,
+			want: FirePlan{NextFire: at(240), Missed: 4}},
+		{name: "run once runs the latest fire", expr: "0 * * * *", due: at(0), now: at(185), policy: MissedRunOnce,
+			want: FirePlan{Fire: fire(180), NextFire: at(240), Missed: 3}},
+		{name: "catch up runs the oldest fire", expr: "0 * * * *", due: at(0), now: at(185), policy: MissedCatchUp,
+			want: FirePlan{Fire: fire(0), NextFire: at(60)}},
+		{name: "catch up on the last fire", expr: "0 * * * *", due: at(180), now: at(185), policy: MissedCatchUp,
+			want: FirePlan{Fire: fire(180), NextFire: at(240)}},
+		{name: "catch up keeps a bounded backlog", expr: "* * * * *", due: at(0), now: at(150), policy: MissedCatchUp,
+			want: FirePlan{Fire: fire(51), NextFire: at(52), Missed: 51}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) failed: %v", tt.expr, err)
+			}
+			got := cron.PlanFire(tt.due, tt.now, time.UTC, tt.policy)
+			if (got.Fire == nil) != (tt.want.Fire == nil) || got.Fire != nil && !got.Fire.Equal(*tt.want.Fire) {
+				t.Errorf("Fire = %v, want %v", got.Fire, tt.want.Fire)
+			}
+			if !got.NextFire.Equal(tt.want.NextFire) {
+				t.Errorf("NextFire = %v, want %v", got.NextFire, tt.want.NextFire)
+			}
+			if got.Missed != tt.want.Missed {
+				t.Errorf("Missed = %d, want %d", got.Missed, tt.want.Missed)
+			}
+		})
+	}
+}
+
+func TestScheduleSpecCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ScheduleSpec
+		wantErr bool
+	}{
+		{name: "defaults", spec: ScheduleSpec{Cron: "@daily"}},
+		{name: "time zone", spec: ScheduleSpec{Cron: "0 9 * * MON-FRI", Timezone: "Europe/Berlin", MissedPolicy: MissedCatchUp}},
+		{name: "unknown policy", spec: ScheduleSpec{Cron: "@daily", MissedPolicy: "sometimes"}, wantErr: true},
+		{name: "unknown time zone", spec: ScheduleSpec{Cron: "@daily", Timezone: "Mars/Olympus"}, wantErr: true},
+		{name: "invalid cron", spec: ScheduleSpec{Cron: "daily"}, wantErr: true},
+		{name: "never fires", spec: ScheduleSpec{Cron: "0 0 31 4 *"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.spec.WithDefaults().Compile()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
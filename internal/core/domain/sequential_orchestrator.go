@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
 )
@@ -18,6 +17,7 @@ type SequentialPipelineOrchestrator struct {
 	Status    map[uuid.UUID]string
 	DBAdapter ports.PipelineRepository
 	SSE       *utils.SSEManager
+	Executor  StageExecutor
 }
 
 // func NewSequentialPipelineOrchestrator(pipelineID uuid.UUID, dbAdapter ports.PipelineRepository) *SequentialPipelineOrchestrator {
@@ -32,6 +32,7 @@ func NewSequentialPipelineOrchestrator(pipelineID uuid.UUID, dbAdapter ports.Pip
 		Status:    make(map[uuid.UUID]string),
 		DBAdapter: dbAdapter,
 		SSE:       sse,
+		Executor:  LocalExecutor{},
 	}
 }
 
@@ -114,12 +115,14 @@ func (p *SequentialPipelineOrchestrator) AddStage(stage Stage) error {
 // 	return uuid.Nil, result, nil
 // }
 
-func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}) (uuid.UUID, interface{}, error) {
+func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}, run *RunContext) (uuid.UUID, interface{}, error) {
 	// Ensure the user exists before proceeding
-	user, err := p.DBAdapter.GetUserByID(userID)
+	_, err := p.DBAdapter.GetUserByID(userID)
 	if err != nil {
 		return uuid.Nil, nil, errors.New("user not found")
 	}
+	utils.ActiveExecutions.WithLabelValues("sequential").Inc()
+	defer utils.ActiveExecutions.WithLabelValues("sequential").Dec()
 
 	// 🔹 Broadcast pipeline start event via SSE
 	p.SSE.BroadcastUpdate(map[string]interface{}{
@@ -127,28 +130,29 @@ func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uui
 		"pipeline_id": pipelineID.String(),
 		"status":      "Running",
 	})
-
-	// Update pipeline status to "Running" in the database
-	err = p.DBAdapter.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		UserID:     user.UserID,
-		Status:     "Running",
-		UpdatedAt:  time.Now(),
-	})
-	if err != nil {
-		return uuid.Nil, nil, err
-	}
+	run.Recorder.Record(ExecutionEvent{Type: EventPipeline, StageIndex: -1, Status: "Running"})
 
 	if len(p.Stages) == 0 {
 		return uuid.Nil, nil, errors.New("pipeline has no stages to execute")
 	}
 
 	var result interface{} = input
-	var completedStages []Stage
-
-	for _, stage := range p.Stages {
+	var completed []int
+	var offset time.Duration
+
+	for i, stage := range p.Stages {
+		if run.Skips(i) {
+			log.Printf("Skipping stage %v, completed before the run was interrupted", stage.GetID())
+			result = run.Outputs[i]
+			completed = append(completed, i)
+			continue
+		}
 		log.Printf("Executing stage: %v\n", stage.GetID())
 
+		// The stage starts where the previous one finished, once its calendar allows work
+		stageRun := run.ForStage(pipelineID, i, offset, p.SSE)
+		err = stageRun.AwaitWorkingTime(ctx)
+
 		// 🔹 Broadcast stage start event via SSE
 		p.SSE.BroadcastUpdate(map[string]interface{}{
 			"type":        "stage",
@@ -156,22 +160,23 @@ func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uui
 			"pipeline_id": pipelineID.String(),
 			"status":      "Running",
 		})
+		run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Running", Offset: stageRun.Offset})
 
 		// Execute stage
-		result, err = stage.Execute(ctx, result, p.SSE, pipelineID)
-		logEntry := &models.ExecutionLog{
-			StageID:    stage.GetID(),
-			PipelineID: pipelineID,
-			Status:     "Completed",
-			Timestamp:  time.Now(),
+		logEntry := newStageLog(pipelineID, run, i, stage, stageRun, result)
+		stageInput := result
+		if err == nil {
+			result, err = p.Executor.ExecuteStage(ctx, stage, result, stageRun)
 		}
+		offset = stageRun.Offset
+		finishStageLog(logEntry, stageRun, result, err)
+		utils.StageDuration.WithLabelValues("sequential", logEntry.Status).Observe(float64(logEntry.DurationMs) / 1000)
 
 		if err != nil {
-			logEntry.Status = "Failed"
-			logEntry.ErrorMsg = err.Error()
-
 			// Save failure log
-			p.DBAdapter.SaveExecutionLog(logEntry)
+			if err := p.DBAdapter.SaveExecutionLog(logEntry); err != nil {
+				log.Printf("Failed to save execution log: %v", err)
+			}
 
 			// 🔹 Broadcast stage failure event via SSE
 			p.SSE.BroadcastUpdate(map[string]interface{}{
@@ -180,25 +185,19 @@ func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uui
 				"pipeline_id": pipelineID.String(),
 				"status":      "Failed",
 			})
+			run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Failed", Offset: offset})
+			run.Recorder.Record(ExecutionEvent{Type: EventPipeline, StageIndex: len(p.Stages), Status: "Failed", Offset: offset})
 
 			// Rollback previous completed stages
-			p.rollback(ctx, completedStages, result)
-
-			// Update pipeline status to "Failed"
-			updateErr := p.DBAdapter.UpdatePipelineExecution(&models.PipelineExecution{
-				PipelineID: pipelineID,
-				Status:     "Failed",
-				UpdatedAt:  time.Now(),
-			})
-			if updateErr != nil {
-				log.Printf("Failed to update pipeline status: %v", updateErr)
-			}
+			p.rollback(ctx, pipelineID, run, completed, stageInput)
 
 			return stage.GetID(), nil, err
 		}
 
 		// Save execution log for successful stage completion
-		p.DBAdapter.SaveExecutionLog(logEntry)
+		if err := p.DBAdapter.SaveExecutionLog(logEntry); err != nil {
+			log.Printf("Failed to save execution log: %v", err)
+		}
 
 		// 🔹 Broadcast stage completion event via SSE
 		p.SSE.BroadcastUpdate(map[string]interface{}{
@@ -207,18 +206,9 @@ func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uui
 			"pipeline_id": pipelineID.String(),
 			"status":      "Completed",
 		})
+		run.Recorder.Record(ExecutionEvent{Type: EventStage, StageIndex: i, StageID: stage.GetID(), Status: "Completed", Offset: offset})
 
-		completedStages = append(completedStages, stage)
-	}
-
-	// Update pipeline status to "Completed"
-	err = p.DBAdapter.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		Status:     "Completed",
-		UpdatedAt:  time.Now(),
-	})
-	if err != nil {
-		log.Printf("Failed to update pipeline status: %v", err)
+		completed = append(completed, i)
 	}
 
 	// 🔹 Broadcast pipeline completion event via SSE
@@ -227,15 +217,20 @@ func (p *SequentialPipelineOrchestrator) Execute(ctx context.Context, userID uui
 		"pipeline_id": pipelineID.String(),
 		"status":      "Completed",
 	})
+	run.Recorder.Record(ExecutionEvent{Type: EventPipeline, StageIndex: len(p.Stages), Status: "Completed", Offset: offset})
 
 	return uuid.Nil, result, nil
 }
 
 
 
-func (p *SequentialPipelineOrchestrator) rollback(ctx context.Context, completedStages []Stage, input interface{}) {
-	for _, stage := range completedStages {
-		stage.Rollback(ctx, input)
+// rollback undoes the completed stages at the given indexes and logs each
+func (p *SequentialPipelineOrchestrator) rollback(ctx context.Context, pipelineID uuid.UUID, run *RunContext, completed []int, input interface{}) {
+	for _, i := range completed {
+		err := p.Stages[i].Rollback(ctx, input)
+		if err := p.DBAdapter.SaveExecutionLog(rollbackLog(pipelineID, run, i, p.Stages[i], err)); err != nil {
+			log.Printf("Failed to save execution log: %v", err)
+		}
 	}
 }
 
@@ -250,23 +245,10 @@ func (p *SequentialPipelineOrchestrator) Cancel(pipelineID uuid.UUID, userID uui
 
 	log.Printf("Pipeline status: %s", status)
 
-	// Step 2: Prevent Canceling Completed Pipelines
-	if status == "Completed" {
-		log.Printf("Pipeline %s is already completed, cannot cancel", pipelineID)
-		return errors.New("cannot cancel a completed pipeline")
-	}
-
-	// Step 3: Update Status to Cancelled
-	log.Printf("Cancelling pipeline %s...", pipelineID)
-	err = p.DBAdapter.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		Status:     "Cancelled",
-		UpdatedAt:  time.Now(),
-	})
-
-	if err != nil {
-		log.Printf("Failed to update pipeline status: %v", err)
-		return errors.New("failed to update pipeline status")
+	// Step 2: Check the pipeline can be cancelled; PipelineService records the transition
+	if err := PipelineStates.Validate(Status(status), StatusCancelled); err != nil {
+		log.Printf("Pipeline %s cannot be cancelled: %v", pipelineID, err)
+		return err
 	}
 
 	log.Printf("Pipeline %s successfully cancelled", pipelineID)
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults applied to stage specs that leave timing or yield unset. The mean
+// matches the fixed processing time of BaseStage.
+const (
+	DefaultStageMeanSeconds   = 5.0
+	DefaultStageStdDevSeconds = 1.0
+	DefaultStageYield         = 0.99
+)
+
+// StageSpec describes the simulated behaviour of a single stage
+type StageSpec struct {
+	Name          string  `json:"name"`
+	MeanSeconds   float64 `json:"mean_seconds"`
+	StdDevSeconds float64 `json:"stddev_seconds"`
+	Yield         float64 `json:"yield"`
+
+	// Type routes the stage to remote workers that advertise it; empty means
+	// DefaultStageType. Every type runs the same simulated stage model.
+	Type string `json:"type,omitempty"`
+
+	// CalendarID optionally gives the stage its own working hours; it is only
+	// applied by the orchestrators, studies run around the clock
+	CalendarID *uuid.UUID `json:"calendar_id,omitempty"`
+}
+
+// StageType returns the type workers must advertise to run the stage
+func (s StageSpec) StageType() string {
+	if s.Type == "" {
+		return DefaultStageType
+	}
+	return s.Type
+}
+
+// PipelineDefinition is the simulation model of a pipeline: its mode and the
+// ordered list of stages
+type PipelineDefinition struct {
+	IsParallel bool        `json:"is_parallel"`
+	Stages     []StageSpec `json:"stages"`
+}
+
+// DefaultStageName names the stage at index of a pipeline that was not given a name
+func DefaultStageName(index int) string {
+	return fmt.Sprintf("stage-%d", index+1)
+}
+
+// DefaultPipelineDefinition builds a definition of stageCount default stages
+func DefaultPipelineDefinition(stageCount int, isParallel bool) PipelineDefinition {
+	def := PipelineDefinition{IsParallel: isParallel, Stages: make([]StageSpec, stageCount)}
+	return def.WithDefaults()
+}
+
+// WithDefaults returns a copy of the definition with unset stage fields filled in
+func (d PipelineDefinition) WithDefaults() PipelineDefinition {
+	stages := make([]StageSpec, len(d.Stages))
+	for i, spec := range d.Stages {
+		if spec.Name == "" {
+			spec.Name = DefaultStageName(i)
+		}
+		if spec.MeanSeconds == 0 {
+			spec.MeanSeconds = DefaultStageMeanSeconds
+			if spec.StdDevSeconds == 0 {
+				spec.StdDevSeconds = DefaultStageStdDevSeconds
+			}
+		}
+		if spec.Yield == 0 {
+			spec.Yield = DefaultStageYield
+		}
+		stages[i] = spec
+	}
+	return PipelineDefinition{IsParallel: d.IsParallel, Stages: stages}
+}
+
+// ExpectedSeconds returns the mean time one unit takes through the pipeline
+func (d PipelineDefinition) ExpectedSeconds() float64 {
+	var total float64
+	for _, spec := range d.Stages {
+		if d.IsParallel {
+			if spec.MeanSeconds > total {
+				total = spec.MeanSeconds
+			}
+		} else {
+			total += spec.MeanSeconds
+		}
+	}
+	return total
+}
+
+// Validate checks that the definition can be simulated
+func (d PipelineDefinition) Validate() error {
+	if len(d.Stages) == 0 {
+		return errors.New("pipeline definition has no stages")
+	}
+	for i, spec := range d.Stages {
+		if spec.MeanSeconds < 0 || spec.StdDevSeconds < 0 {
+			return fmt.Errorf("stage %d: processing time must not be negative", i+1)
+		}
+		if spec.Yield <= 0 || spec.Yield > 1 {
+			return fmt.Errorf("stage %d: yield must be in (0, 1]", i+1)
+		}
+	}
+	return nil
+}
+
+// sampleDuration draws a processing time from a normal distribution truncated at zero
+func (s StageSpec) sampleDuration(rng *rand.Rand) time.Duration {
+	seconds := s.MeanSeconds + s.StdDevSeconds*rng.NormFloat64()
+	if seconds < 0 {
+		seconds = 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// samplePass draws whether a unit leaves the stage in good condition
+func (s StageSpec) samplePass(rng *rand.Rand) bool {
+	return rng.Float64() < s.Yield
+}
+
+// ReplicationResult holds the outcome of one simulated run
+type ReplicationResult struct {
+	Seed          int64
+	Makespan      time.Duration
+	GoodUnits     int
+	ScrappedUnits int
+	Stages        []StageStats
+}
+
+// Throughput returns good units per hour of simulated time
+func (r ReplicationResult) Throughput() float64 {
+	if r.Makespan <= 0 {
+		return 0
+	}
+	return float64(r.GoodUnits) / r.Makespan.Hours()
+}
+
+// Yield returns the fraction of units that completed in good condition
+func (r ReplicationResult) Yield() float64 {
+	total := r.GoodUnits + r.ScrappedUnits
+	if total == 0 {
+		return 0
+	}
+	return float64(r.GoodUnits) / float64(total)
+}
+
+// SimulateReplication runs units work items through the pipeline on a virtual
+// clock. Runs with the same definition, unit count and seed are identical.
+func SimulateReplication(ctx context.Context, def PipelineDefinition, units int, seed int64) (ReplicationResult, error) {
+	if err := def.Validate(); err != nil {
+		return ReplicationResult{}, err
+	}
+	if units <= 0 {
+		return ReplicationResult{}, errors.New("units must be positive")
+	}
+
+	epoch := time.Unix(0, 0).UTC()
+	clock := NewVirtualClock(epoch)
+	rng := rand.New(rand.NewSource(seed))
+	result := ReplicationResult{Seed: seed}
+
+	// stageFree[j] is the simulated time at which stage j finishes its current unit
+	stageFree := make([]time.Duration, len(def.Stages))
+	counters := make([]stageCounter, len(def.Stages))
+
+	for i := 0; i < units; i++ {
+		if err := ctx.Err(); err != nil {
+			return ReplicationResult{}, err
+		}
+
+		good := true
+		if def.IsParallel {
+			// Every stage works on every unit independently
+			for j, spec := range def.Stages {
+				start := stageFree[j]
+				stageFree[j] = start + spec.sampleDuration(rng)
+				counters[j].observe(start, 0, start, stageFree[j])
+				if !spec.samplePass(rng) {
+					good = false
+				}
+				clock.AdvanceTo(epoch.Add(stageFree[j]))
+			}
+		} else {
+			// Flow line: a unit enters stage j once it has left stage j-1 and
+			// stage j has finished the previous unit
+			var ready time.Duration
+			for j, spec := range def.Stages {
+				start := ready
+				if stageFree[j] > start {
+					start = stageFree[j]
+				}
+				free := stageFree[j]
+				stageFree[j] = start + spec.sampleDuration(rng)
+				counters[j].observe(free, ready, start, stageFree[j])
+				ready = stageFree[j]
+				clock.AdvanceTo(epoch.Add(ready))
+				if !spec.samplePass(rng) {
+					good = false
+					break
+				}
+			}
+		}
+
+		if good {
+			result.GoodUnits++
+		} else {
+			result.ScrappedUnits++
+		}
+	}
+
+	result.Makespan = clock.Now().Sub(epoch)
+	if !def.IsParallel {
+		// A stage is blocked while finished units wait in front of the next one
+		for j := 1; j < len(counters); j++ {
+			counters[j-1].blocked = counters[j].queued
+		}
+	}
+	result.Stages = make([]StageStats, len(def.Stages))
+	for j, spec := range def.Stages {
+		result.Stages[j] = counters[j].stats(j, spec.Name, stageFree[j], result.Makespan)
+	}
+	return result, nil
+}
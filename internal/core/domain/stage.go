@@ -3,35 +3,47 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
-	"time"
 
 	"github.com/google/uuid"
-	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
 )
 
 type Stage interface {
 	GetID() uuid.UUID
+	GetSpec() StageSpec
 	// Execute(ctx context.Context, input interface{}) (interface{}, error)
-	Execute(ctx context.Context, input interface{}, sse *utils.SSEManager, pipelineID uuid.UUID) (interface{}, error)
+	Execute(ctx context.Context, input interface{}, run *StageRun) (interface{}, error)
 	HandleError(ctx context.Context, err error) error
 	Rollback(ctx context.Context, input interface{}) error
 }
 
 type BaseStage struct {
-	ID uuid.UUID
+	ID   uuid.UUID
+	Spec StageSpec
 }
 
+// NewBaseStage creates a stage with a fixed 5 second processing time that never fails
 func NewBaseStage() *BaseStage {
-	return &BaseStage{ID: uuid.New()}
+	return NewSimulatedStage(StageSpec{MeanSeconds: DefaultStageMeanSeconds, Yield: 1})
+}
+
+// NewSimulatedStage creates a stage whose processing time and yield follow spec
+func NewSimulatedStage(spec StageSpec) *BaseStage {
+	return &BaseStage{ID: uuid.New(), Spec: spec}
 }
 
 func (s *BaseStage) GetID() uuid.UUID {
 	return s.ID
 }
 
-func (s *BaseStage) Execute(ctx context.Context, input interface{}, sse *utils.SSEManager, pipelineID uuid.UUID) (interface{}, error) {
+func (s *BaseStage) GetSpec() StageSpec {
+	return s.Spec
+}
+
+func (s *BaseStage) Execute(ctx context.Context, input interface{}, run *StageRun) (interface{}, error) {
 	log.Printf("Executing stage: %s with input: %v\n", s.ID, input)
+	sse, pipelineID := run.SSE, run.PipelineID
 
 	// ✅ Broadcast stage execution start as JSON
 	sse.BroadcastUpdate(map[string]interface{}{
@@ -56,7 +68,25 @@ func (s *BaseStage) Execute(ctx context.Context, input interface{}, sse *utils.S
 		return nil, err
 	}
 
-	time.Sleep(5 * time.Second)
+	// Processing time and quality are drawn from the stage's seeded generator
+	if err := run.Sleep(ctx, s.Spec.sampleDuration(run.Rand)); err != nil {
+		return nil, err
+	}
+
+	if !s.Spec.samplePass(run.Rand) {
+		err := fmt.Errorf("stage %s failed quality check", s.ID)
+		log.Printf("Stage %s execution failed: %v", s.ID, err)
+
+		// ✅ Broadcast stage failure as JSON
+		sse.BroadcastUpdate(map[string]interface{}{
+			"type":        "stage",
+			"stage_id":    s.ID.String(),
+			"pipeline_id": pipelineID.String(),
+			"status":      "Failed",
+		})
+
+		return nil, err
+	}
 
 	log.Printf("Stage %s executed successfully", s.ID)
 
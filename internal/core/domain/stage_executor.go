@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// DefaultStageType is the type of stages whose spec does not name one
+const DefaultStageType = "simulated"
+
+// StageExecutor runs a single stage of an execution. The orchestrators hand
+// every stage to their executor, which may run it in-process or on a remote
+// worker; either way the stage run's offset must end where the stage finished.
+type StageExecutor interface {
+	ExecuteStage(ctx context.Context, stage Stage, input interface{}, run *StageRun) (interface{}, error)
+}
+
+// LocalExecutor runs stages as goroutines of the orchestrating process
+type LocalExecutor struct{}
+
+func (LocalExecutor) ExecuteStage(ctx context.Context, stage Stage, input interface{}, run *StageRun) (interface{}, error) {
+	return stage.Execute(ctx, input, run)
+}
+
+// StageTask is a stage run packaged for a remote worker. It carries the stage
+// seed, offset and calendar so the worker reproduces exactly what the stage
+// would have done in-process.
+type StageTask struct {
+	TaskID     uuid.UUID     `json:"task_id"`
+	PipelineID uuid.UUID     `json:"pipeline_id"`
+	StageID    uuid.UUID     `json:"stage_id"`
+	StageIndex int           `json:"stage_index"`
+	Type       string        `json:"type"`
+	Spec       StageSpec     `json:"spec"`
+	Input      interface{}   `json:"input"`
+	Seed       int64         `json:"seed"`
+	Start      time.Time     `json:"start"`
+	Offset     time.Duration `json:"offset_ns"`
+	Calendar   *Calendar     `json:"calendar,omitempty"`
+}
+
+// NewStageTask packages a stage run for a remote worker
+func NewStageTask(stage Stage, input interface{}, run *StageRun) StageTask {
+	spec := stage.GetSpec()
+	return StageTask{
+		TaskID:     uuid.New(),
+		PipelineID: run.PipelineID,
+		StageID:    stage.GetID(),
+		StageIndex: run.Index,
+		Type:       spec.StageType(),
+		Spec:       spec,
+		Input:      input,
+		Seed:       run.Seed,
+		Start:      run.Start,
+		Offset:     run.Offset,
+		Calendar:   run.Calendar,
+	}
+}
+
+// RunStageTask executes a task on a worker against the given clock and returns
+// the stage output and the offset at which the stage finished
+func RunStageTask(ctx context.Context, task StageTask, clock Clock, sse *utils.SSEManager) (interface{}, time.Duration, error) {
+	stage := &BaseStage{ID: task.StageID, Spec: task.Spec}
+	run := &StageRun{
+		PipelineID: task.PipelineID,
+		Index:      task.StageIndex,
+		Seed:       task.Seed,
+		Rand:       rand.New(rand.NewSource(task.Seed)),
+		Clock:      clock,
+		SSE:        sse,
+		Offset:     task.Offset,
+		Start:      task.Start,
+		Calendar:   task.Calendar,
+	}
+	output, err := stage.Execute(ctx, task.Input, run)
+	return output, run.Offset, err
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// Statuses of execution log entries besides those of the attempts themselves
+const (
+	LogRolledBack = "RolledBack" // A completed stage undone after a later stage failed
+	LogError      = "Error"      // Error that ended the run
+	LogRecovery   = "Recovery"   // Decision taken for the run after a server stop
+)
+
+// StagePending is the state of a stage the latest run of its pipeline has
+// not logged an attempt of
+const StagePending = "Pending"
+
+// newStageLog opens the log entry of an attempt of the stage at index that
+// starts now on the run's clock, with a snapshot of its input
+func newStageLog(pipelineID uuid.UUID, run *RunContext, index int, stage Stage, stageRun *StageRun, input interface{}) *models.ExecutionLog {
+	startedAt := stageRun.Start.Add(stageRun.Offset)
+	return &models.ExecutionLog{
+		LogID:      uuid.New(),
+		StageID:    stage.GetID(),
+		PipelineID: pipelineID,
+		RunID:      run.RunID,
+		StageIndex: index,
+		StageName:  stage.GetSpec().Name,
+		Attempt:    run.Attempt(index),
+		Input:      snapshot(input),
+		StartedAt:  &startedAt,
+	}
+}
+
+// finishStageLog closes the entry once the attempt ended at the offset of stageRun,
+// with the output of a completed attempt or the error of a failed one
+func finishStageLog(entry *models.ExecutionLog, stageRun *StageRun, output interface{}, err error) {
+	finishedAt := stageRun.Start.Add(stageRun.Offset)
+	entry.FinishedAt = &finishedAt
+	entry.DurationMs = finishedAt.Sub(*entry.StartedAt).Milliseconds()
+	entry.Timestamp = time.Now()
+	if err != nil {
+		entry.Status = string(StatusFailed)
+		entry.ErrorMsg = err.Error()
+		return
+	}
+	entry.Status = string(StatusCompleted)
+	entry.Output = snapshot(output)
+}
+
+// rollbackLog records that the completed stage at index was rolled back,
+// under the attempt that completed it
+func rollbackLog(pipelineID uuid.UUID, run *RunContext, index int, stage Stage, err error) *models.ExecutionLog {
+	attempt := run.Attempt(index)
+	if run.Skips(index) {
+		attempt = run.Attempts[index]
+	}
+	entry := &models.ExecutionLog{
+		LogID:      uuid.New(),
+		StageID:    stage.GetID(),
+		PipelineID: pipelineID,
+		RunID:      run.RunID,
+		StageIndex: index,
+		StageName:  stage.GetSpec().Name,
+		Attempt:    attempt,
+		Status:     LogRolledBack,
+		Timestamp:  time.Now(),
+	}
+	if err != nil {
+		entry.ErrorMsg = "rollback failed: " + err.Error()
+	}
+	return entry
+}
+
+// snapshot stores a stage input or output; values that do not encode are
+// left out of the log rather than failing the stage
+func snapshot(v interface{}) models.JSONB {
+	data, err := models.NewJSONB(v)
+	if err != nil {
+		log.Printf("Failed to snapshot stage data: %v", err)
+		return nil
+	}
+	return data
+}
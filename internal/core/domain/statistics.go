@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// ConfidenceLevel is the level used for all reported confidence intervals
+const ConfidenceLevel = 0.95
+
+// MetricSummary aggregates the samples of one KPI across replications
+type MetricSummary struct {
+	N       int     `json:"n"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"stddev"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	P5      float64 `json:"p5"`
+	P50     float64 `json:"p50"`
+	P95     float64 `json:"p95"`
+	CILower float64 `json:"ci_lower"`
+	CIUpper float64 `json:"ci_upper"`
+}
+
+// Summarize computes descriptive statistics and a 95% confidence interval
+// for the mean of the given samples
+func Summarize(samples []float64) MetricSummary {
+	n := len(samples)
+	if n == 0 {
+		return MetricSummary{}
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(n)
+
+	var sq float64
+	for _, v := range sorted {
+		sq += (v - mean) * (v - mean)
+	}
+	var stddev float64
+	if n > 1 {
+		stddev = math.Sqrt(sq / float64(n-1))
+	}
+
+	summary := MetricSummary{
+		N:       n,
+		Mean:    mean,
+		StdDev:  stddev,
+		Min:     sorted[0],
+		Max:     sorted[n-1],
+		P5:      Percentile(sorted, 5),
+		P50:     Percentile(sorted, 50),
+		P95:     Percentile(sorted, 95),
+		CILower: mean,
+		CIUpper: mean,
+	}
+	if n > 1 {
+		half := tCritical95(n-1) * stddev / math.Sqrt(float64(n))
+		summary.CILower = mean - half
+		summary.CIUpper = mean + half
+	}
+	return summary
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted samples using
+// linear interpolation between closest ranks
+func Percentile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		return sorted[0]
+	}
+	rank := p / 100 * float64(n-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	frac := rank - float64(lower)
+	return sorted[lower] + frac*(sorted[upper]-sorted[lower])
+}
+
+// tTable95 holds two-sided 95% critical values of Student's t for 1..30 degrees of freedom
+var tTable95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tCritical95 returns the two-sided 95% critical value of Student's t
+func tCritical95(df int) float64 {
+	switch {
+	case df <= 0:
+		return math.Inf(1)
+	case df <= len(tTable95):
+		return tTable95[df-1]
+	case df <= 60:
+		return 2.000
+	case df <= 120:
+		return 1.980
+	default:
+		return 1.960
+	}
+}
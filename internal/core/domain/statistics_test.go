@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []float64
+		want    MetricSummary
+	}{
+		{name: "empty", samples: nil, want: MetricSummary{}},
+		{name: "one sample", samples: []float64{3},
+			want: MetricSummary{N: 1, Mean: 3, Min: 3, Max: 3, P5: 3, P50: 3, P95: 3, CILower: 3, CIUpper: 3}},
+		{name: "constant", samples: []float64{2, 2, 2},
+			want: MetricSummary{N: 3, Mean: 2, Min: 2, Max: 2, P5: 2, P50: 2, P95: 2, CILower: 2, CIUpper: 2}},
+		{name: "unsorted", samples: []float64{9, 4, 2, 5, 4, 7, 4, 5},
+			want: MetricSummary{N: 8, Mean: 5, StdDev: 2.13809, Min: 2, Max: 9, P5: 2.7, P50: 4.5, P95: 8.3, CILower: 3.21223, CIUpper: 6.78777}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.samples)
+			if got.N != tt.want.N {
+				t.Errorf("N = %d, want %d", got.N, tt.want.N)
+			}
+			fields := []struct {
+				name      string
+				got, want float64
+			}{
+				{"Mean", got.Mean, tt.want.Mean},
+				{"StdDev", got.StdDev, tt.want.StdDev},
+				{"Min", got.Min, tt.want.Min},
+				{"Max", got.Max, tt.want.Max},
+				{"P5", got.P5, tt.want.P5},
+				{"P50", got.P50, tt.want.P50},
+				{"P95", got.P95, tt.want.P95},
+				{"CILower", got.CILower, tt.want.CILower},
+				{"CIUpper", got.CIUpper, tt.want.CIUpper},
+			}
+			for _, f := range fields {
+				if math.Abs(f.got-f.want) > 1e-4 {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeKeepsSamples(t *testing.T) {
+	samples := []float64{3, 1, 2}
+	Summarize(samples)
+	if samples[0] != 3 || samples[1] != 1 || samples[2] != 2 {
+		t.Errorf("Summarize reordered its input to %v", samples)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40, 50}
+	tests := []struct {
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{sorted: nil, p: 50, want: 0},
+		{sorted: []float64{7}, p: 95, want: 7},
+		{sorted: sorted, p: 0, want: 10},
+		{sorted: sorted, p: 100, want: 50},
+		{sorted: sorted, p: 50, want: 30},
+		{sorted: sorted, p: 10, want: 14},
+		{sorted: sorted, p: 95, want: 48},
+	}
+	for _, tt := range tests {
+		if got := Percentile(tt.sorted, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTCritical95(t *testing.T) {
+	tests := []struct {
+		df   int
+		want float64
+	}{
+		{df: 0, want: math.Inf(1)},
+		{df: 1, want: 12.706},
+		{df: 7, want: 2.365},
+		{df: 30, want: 2.042},
+		{df: 31, want: 2.000},
+		{df: 60, want: 2.000},
+		{df: 61, want: 1.980},
+		{df: 120, want: 1.980},
+		{df: 121, want: 1.960},
+	}
+	for _, tt := range tests {
+		if got := tCritical95(tt.df); got != tt.want {
+			t.Errorf("tCritical95(%d) = %v, want %v", tt.df, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle state of a pipeline or of one of its runs. The
+// status of a pipeline is that of its latest run, or Created before it ran.
+type Status string
+
+const (
+	StatusCreated     Status = "Created" // Pipeline that has not been started; runs start out Queued
+	StatusQueued      Status = "Queued"
+	StatusRunning     Status = "Running"
+	StatusCompleted   Status = "Completed"
+	StatusFailed      Status = "Failed"
+	StatusCancelled   Status = "Cancelled"
+	StatusInterrupted Status = "Interrupted" // Server stopped during the run
+)
+
+// Statuses lists every status
+var Statuses = []Status{StatusCreated, StatusQueued, StatusRunning, StatusCompleted, StatusFailed, StatusCancelled, StatusInterrupted}
+
+// Active reports whether a run in this status is queued or running
+func (s Status) Active() bool {
+	return s == StatusQueued || s == StatusRunning
+}
+
+// Ended reports whether a run in this status is over
+func (s Status) Ended() bool {
+	switch s {
+	case StatusCompleted, StatusFailed, StatusCancelled, StatusInterrupted:
+		return true
+	}
+	return false
+}
+
+// ErrInvalidTransition matches every TransitionError
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// ErrStatusConflict is returned when a pipeline or run changed status between
+// reading and writing it, e.g. when two requests start the same pipeline
+var ErrStatusConflict = errors.New("status changed concurrently")
+
+// TransitionError rejects a status change the state machine does not allow
+type TransitionError struct {
+	Entity string // "pipeline" or "run"
+	From   Status
+	To     Status
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == e.To {
+		return fmt.Sprintf("%s is already %s", e.Entity, e.From)
+	}
+	return fmt.Sprintf("%s cannot go from %s to %s", e.Entity, e.From, e.To)
+}
+
+// Is lets errors.Is(err, ErrInvalidTransition) match
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// StateMachine holds the status changes allowed for one kind of entity
+type StateMachine struct {
+	Entity      string
+	transitions map[Status][]Status
+}
+
+// PipelineStates is the lifecycle of a pipeline: it is queued from Created or
+// after its last run ended, runs, and ends. Recovery moves a running pipeline
+// back to Queued to resume it, and a queued one whose work order was lost
+// back to Created.
+var PipelineStates = &StateMachine{
+	Entity: "pipeline",
+	transitions: map[Status][]Status{
+		StatusCreated:     {StatusQueued},
+		StatusQueued:      {StatusRunning, StatusFailed, StatusCancelled, StatusInterrupted, StatusCreated},
+		StatusRunning:     {StatusCompleted, StatusFailed, StatusCancelled, StatusInterrupted, StatusQueued},
+		StatusCompleted:   {StatusQueued},
+		StatusFailed:      {StatusQueued},
+		StatusCancelled:   {StatusQueued},
+		StatusInterrupted: {StatusQueued},
+	},
+}
+
+// RunStates is the lifecycle of a run: it is created Queued, runs and ends
+// once. Recovery moves a running run back to Queued to resume it.
+var RunStates = &StateMachine{
+	Entity: "run",
+	transitions: map[Status][]Status{
+		StatusQueued:  {StatusRunning, StatusFailed, StatusCancelled, StatusInterrupted},
+		StatusRunning: {StatusCompleted, StatusFailed, StatusCancelled, StatusInterrupted, StatusQueued},
+	},
+}
+
+// Validate returns a TransitionError unless the machine allows going from
+// from to to
+func (m *StateMachine) Validate(from Status, to Status) error {
+	for _, next := range m.transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{Entity: m.Entity, From: from, To: to}
+}
+
+// Actors name who made a status change
+const (
+	ActorUserPrefix     = "user:"
+	ActorInstancePrefix = "instance:"
+	ActorSchedulePrefix = "schedule:"
+	ActorTriggerPrefix  = "trigger:"
+)
+
+// UserActor is a user acting through the API
+func UserActor(userID uuid.UUID) string { return ActorUserPrefix + userID.String() }
+
+// InstanceActor is a server executing or recovering runs
+func InstanceActor(name string) string { return ActorInstancePrefix + name }
+
+// ScheduleActor is a schedule firing
+func ScheduleActor(scheduleID uuid.UUID) string { return ActorSchedulePrefix + scheduleID.String() }
+
+// TriggerActor is a trigger firing
+func TriggerActor(triggerID uuid.UUID) string { return ActorTriggerPrefix + triggerID.String() }
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStateMachineValidate(t *testing.T) {
+	tests := []struct {
+		machine *StateMachine
+		from    Status
+		to      Status
+		allowed bool
+	}{
+		{PipelineStates, StatusCreated, StatusQueued, true},
+		{PipelineStates, StatusCreated, StatusRunning, false},
+		{PipelineStates, StatusCreated, StatusCancelled, false},
+		{PipelineStates, StatusQueued, StatusRunning, true},
+		{PipelineStates, StatusQueued, StatusCancelled, true},
+		{PipelineStates, StatusQueued, StatusCreated, true},
+		{PipelineStates, StatusQueued, StatusCompleted, false},
+		{PipelineStates, StatusQueued, StatusQueued, false},
+		{PipelineStates, StatusRunning, StatusCompleted, true},
+		{PipelineStates, StatusRunning, StatusFailed, true},
+		{PipelineStates, StatusRunning, StatusCancelled, true},
+		{PipelineStates, StatusRunning, StatusInterrupted, true},
+		{PipelineStates, StatusRunning, StatusQueued, true},
+		{PipelineStates, StatusRunning, StatusCreated, false},
+		{PipelineStates, StatusCompleted, StatusQueued, true},
+		{PipelineStates, StatusCompleted, StatusCancelled, false},
+		{PipelineStates, StatusFailed, StatusQueued, true},
+		{PipelineStates, StatusCancelled, StatusQueued, true},
+		{PipelineStates, StatusCancelled, StatusCancelled, false},
+		{PipelineStates, StatusInterrupted, StatusQueued, true},
+		{PipelineStates, StatusInterrupted, StatusRunning, false},
+		{PipelineStates, "Failed to Cancel", StatusQueued, false},
+
+		{RunStates, StatusQueued, StatusRunning, true},
+		{RunStates, StatusQueued, StatusCancelled, true},
+		{RunStates, StatusQueued, StatusInterrupted, true},
+		{RunStates, StatusQueued, StatusCompleted, false},
+		{RunStates, StatusQueued, StatusCreated, false},
+		{RunStates, StatusRunning, StatusCompleted, true},
+		{RunStates, StatusRunning, StatusCancelled, true},
+		{RunStates, StatusRunning, StatusQueued, true},
+		{RunStates, StatusRunning, StatusRunning, false},
+		{RunStates, StatusCreated, StatusQueued, false},
+		{RunStates, StatusCompleted, StatusQueued, false},
+		{RunStates, StatusCancelled, StatusFailed, false},
+		{RunStates, StatusInterrupted, StatusRunning, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.machine.Entity+"/"+string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := tt.machine.Validate(tt.from, tt.to)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("Validate(%s, %s) = %v, want nil", tt.from, tt.to, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("Validate(%s, %s) = %v, want ErrInvalidTransition", tt.from, tt.to, err)
+			}
+			var transition *TransitionError
+			if !errors.As(err, &transition) || transition.Entity != tt.machine.Entity || transition.From != tt.from || transition.To != tt.to {
+				t.Errorf("Validate(%s, %s) = %#v, want a TransitionError for the %s", tt.from, tt.to, err, tt.machine.Entity)
+			}
+		})
+	}
+}
+
+func TestEndedRunsStayEnded(t *testing.T) {
+	for _, from := range Statuses {
+		if !from.Ended() {
+			continue
+		}
+		for _, to := range Statuses {
+			if err := RunStates.Validate(from, to); err == nil {
+				t.Errorf("run moved from ended status %s to %s", from, to)
+			}
+		}
+	}
+}
+
+func TestTransitionErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *TransitionError
+		want string
+	}{
+		{&TransitionError{Entity: "pipeline", From: StatusCompleted, To: StatusCancelled}, "pipeline cannot go from Completed to Cancelled"},
+		{&TransitionError{Entity: "run", From: StatusRunning, To: StatusRunning}, "run is already Running"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// KPI names reported by studies
+const (
+	MetricMakespan   = "makespan_seconds"
+	MetricThroughput = "throughput_per_hour"
+	MetricYield      = "yield"
+)
+
+// Limits of a study. Studies run within the request that starts them, so
+// MaxStudyWork caps the units simulated over all replications at what a few
+// seconds of simulation get through.
+const (
+	MaxStudyReplications = 1000
+	MaxStudyUnits        = 10000
+	MaxStudyWork         = 1000000 // Replications × units
+	DefaultStudyUnits    = 100
+)
+
+// StudyConfig controls a Monte Carlo study
+type StudyConfig struct {
+	Replications int
+	Units        int
+	Seed         int64
+}
+
+// StudyOutcome holds every replication, the aggregated KPIs and the
+// stage-level statistics averaged over replications
+type StudyOutcome struct {
+	Replications []ReplicationResult
+	Metrics      map[string]MetricSummary
+	Stages       []StageStats
+}
+
+// RunStudy executes the definition once per replication, using seed+i for
+// replication i, and aggregates the KPIs
+func RunStudy(ctx context.Context, def PipelineDefinition, cfg StudyConfig) (*StudyOutcome, error) {
+	if cfg.Replications <= 0 || cfg.Replications > MaxStudyReplications {
+		return nil, fmt.Errorf("replications must be between 1 and %d", MaxStudyReplications)
+	}
+	if cfg.Units <= 0 || cfg.Units > MaxStudyUnits {
+		return nil, fmt.Errorf("units must be between 1 and %d", MaxStudyUnits)
+	}
+	if cfg.Replications*cfg.Units > MaxStudyWork {
+		return nil, fmt.Errorf("replications × units must not exceed %d", MaxStudyWork)
+	}
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	outcome := &StudyOutcome{Replications: make([]ReplicationResult, 0, cfg.Replications)}
+	makespans := make([]float64, 0, cfg.Replications)
+	throughputs := make([]float64, 0, cfg.Replications)
+	yields := make([]float64, 0, cfg.Replications)
+	stages := make([][]StageStats, 0, cfg.Replications)
+
+	for i := 0; i < cfg.Replications; i++ {
+		result, err := SimulateReplication(ctx, def, cfg.Units, cfg.Seed+int64(i))
+		if err != nil {
+			return nil, err
+		}
+		outcome.Replications = append(outcome.Replications, result)
+		makespans = append(makespans, result.Makespan.Seconds())
+		throughputs = append(throughputs, result.Throughput())
+		yields = append(yields, result.Yield())
+		stages = append(stages, result.Stages)
+	}
+
+	outcome.Metrics = map[string]MetricSummary{
+		MetricMakespan:   Summarize(makespans),
+		MetricThroughput: Summarize(throughputs),
+		MetricYield:      Summarize(yields),
+	}
+	outcome.Stages = AverageStageStats(stages)
+	return outcome, nil
+}
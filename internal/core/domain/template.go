@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Template statuses. Drafts can still be edited; published versions are
+// immutable and can be instantiated; deprecated versions are skipped when a
+// template is referenced without a version.
+const (
+	TemplateDraft      = "draft"
+	TemplatePublished  = "published"
+	TemplateDeprecated = "deprecated"
+)
+
+// TemplateRef names a template version as name@version. Version 0 stands for
+// the latest published version, written as name or name@latest.
+type TemplateRef struct {
+	Name    string
+	Version int
+}
+
+// ParseTemplateRef parses name, name@latest or name@version
+func ParseTemplateRef(s string) (TemplateRef, error) {
+	name, version, found := strings.Cut(s, "@")
+	if name == "" {
+		return TemplateRef{}, errors.New("template name is required")
+	}
+	ref := TemplateRef{Name: name}
+	if !found || version == "latest" {
+		return ref, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil || n < 1 {
+		return TemplateRef{}, fmt.Errorf("invalid template version %q", version)
+	}
+	ref.Version = n
+	return ref, nil
+}
+
+func (r TemplateRef) String() string {
+	if r.Version == 0 {
+		return r.Name + "@latest"
+	}
+	return fmt.Sprintf("%s@%d", r.Name, r.Version)
+}
+
+// StageOverride changes one stage of a template when a pipeline is
+// instantiated from it; nil fields keep the template's value
+type StageOverride struct {
+	MeanSeconds   *float64   `json:"mean_seconds,omitempty"`
+	StdDevSeconds *float64   `json:"stddev_seconds,omitempty"`
+	Yield         *float64   `json:"yield,omitempty"`
+	Type          *string    `json:"type,omitempty"`
+	CalendarID    *uuid.UUID `json:"calendar_id,omitempty"`
+}
+
+// TemplateOverrides are the parameters a pipeline instantiated from a template
+// may change. Stages are addressed by name.
+type TemplateOverrides struct {
+	IsParallel *bool                    `json:"is_parallel,omitempty"`
+	Stages     map[string]StageOverride `json:"stages,omitempty"`
+}
+
+// ValidateTemplate checks a definition can be simulated and that its stages
+// have unique names, so overrides can address them
+func (d PipelineDefinition) ValidateTemplate() error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(d.Stages))
+	for i, spec := range d.Stages {
+		if names[spec.Name] {
+			return fmt.Errorf("stage %d: name %q is used twice", i+1, spec.Name)
+		}
+		names[spec.Name] = true
+	}
+	return nil
+}
+
+// Override returns a copy of the definition with the overrides applied
+func (d PipelineDefinition) Override(overrides TemplateOverrides) (PipelineDefinition, error) {
+	out := PipelineDefinition{IsParallel: d.IsParallel, Stages: make([]StageSpec, len(d.Stages))}
+	copy(out.Stages, d.Stages)
+	if overrides.IsParallel != nil {
+		out.IsParallel = *overrides.IsParallel
+	}
+
+	applied := 0
+	for i := range out.Stages {
+		o, ok := overrides.Stages[out.Stages[i].Name]
+		if !ok {
+			continue
+		}
+		applied++
+		if o.MeanSeconds != nil {
+			out.Stages[i].MeanSeconds = *o.MeanSeconds
+		}
+		if o.StdDevSeconds != nil {
+			out.Stages[i].StdDevSeconds = *o.StdDevSeconds
+		}
+		if o.Yield != nil {
+			out.Stages[i].Yield = *o.Yield
+		}
+		if o.Type != nil {
+			out.Stages[i].Type = *o.Type
+		}
+		if o.CalendarID != nil {
+			out.Stages[i].CalendarID = o.CalendarID
+		}
+	}
+	if applied < len(overrides.Stages) {
+		for name := range overrides.Stages {
+			if !out.hasStage(name) {
+				return PipelineDefinition{}, fmt.Errorf("template has no stage %q", name)
+			}
+		}
+	}
+	return out, out.Validate()
+}
+
+func (d PipelineDefinition) hasStage(name string) bool {
+	for _, spec := range d.Stages {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
+}
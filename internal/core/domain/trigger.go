@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Lifecycle events a trigger fires on, matched against the status a run ends with
+const (
+	TriggerOnCompleted = "completed"
+	TriggerOnFailed    = "failed"
+	TriggerOnCancelled = "cancelled"
+	TriggerOnFinished  = "finished" // Any of the above
+)
+
+// MaxTriggerDepth bounds how many runs a chain of triggers may start after
+// the run that began it
+const MaxTriggerDepth = 10
+
+// ErrTriggerLoop is returned for a trigger that would start a pipeline which
+// already ran earlier in the same chain
+var ErrTriggerLoop = errors.New("trigger loop")
+
+// ValidTriggerEvent reports whether on names a lifecycle event
+func ValidTriggerEvent(on string) bool {
+	switch on {
+	case TriggerOnCompleted, TriggerOnFailed, TriggerOnCancelled, TriggerOnFinished:
+		return true
+	}
+	return false
+}
+
+// TriggerMatches reports whether a trigger on the event on fires for a run
+// that ended with status
+func TriggerMatches(on string, status Status) bool {
+	switch status {
+	case StatusCompleted:
+		return on == TriggerOnCompleted || on == TriggerOnFinished
+	case StatusFailed:
+		return on == TriggerOnFailed || on == TriggerOnFinished
+	case StatusCancelled:
+		return on == TriggerOnCancelled || on == TriggerOnFinished
+	}
+	return false
+}
+
+// TriggerLink is one run in a trigger chain and the trigger that started it;
+// TriggerID is nil for the run that began the chain
+type TriggerLink struct {
+	PipelineID uuid.UUID  `json:"pipeline_id"`
+	RunID      uuid.UUID  `json:"run_id"`
+	TriggerID  *uuid.UUID `json:"trigger_id,omitempty"`
+}
+
+// TriggerChain lists the runs that led to a run through triggers, the one
+// that began the chain first
+type TriggerChain []TriggerLink
+
+// Contains reports whether a run of pipelineID is part of the chain
+func (c TriggerChain) Contains(pipelineID uuid.UUID) bool {
+	for _, link := range c {
+		if link.PipelineID == pipelineID {
+			return true
+		}
+	}
+	return false
+}
+
+// Extend returns the chain of a run that source starts in target. It fails
+// with ErrTriggerLoop when target already ran in the chain and when the chain
+// would grow beyond MaxTriggerDepth.
+func (c TriggerChain) Extend(source TriggerLink, target uuid.UUID) (TriggerChain, error) {
+	chain := append(append(TriggerChain{}, c...), source)
+	if chain.Contains(target) {
+		return nil, fmt.Errorf("%w: pipeline %s already ran in this chain", ErrTriggerLoop, target)
+	}
+	if len(chain) > MaxTriggerDepth {
+		return nil, fmt.Errorf("%w: chain is longer than %d runs", ErrTriggerLoop, MaxTriggerDepth)
+	}
+	return chain, nil
+}
+
+// TriggerCause records on a work order which trigger queued it, for which run
+// and along which chain
+type TriggerCause struct {
+	TriggerID uuid.UUID    `json:"trigger_id"`
+	RunID     uuid.UUID    `json:"run_id"` // The run whose end fired the trigger
+	Chain     TriggerChain `json:"chain"`
+}
+
+// TriggerSpec configures a trigger that starts TargetPipelineID when a run
+// of its source pipeline ends with the event On
+type TriggerSpec struct {
+	TargetPipelineID uuid.UUID   `json:"target_pipeline_id"`
+	On               string      `json:"on"`
+	PassOutput       bool        `json:"pass_output"` // Use the output of the source run as input
+	Input            interface{} `json:"input"`       // Input otherwise
+}
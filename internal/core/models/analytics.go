@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Aggregates computed over pipeline runs; they are query results, not tables
+
+// RunDayCount counts the runs queued on one UTC day that are in one status
+type RunDayCount struct {
+	Day    time.Time
+	Status string
+	Runs   int
+}
+
+// RunStatusCount counts the runs in one status
+type RunStatusCount struct {
+	Status string
+	Runs   int
+}
+
+// DurationStats summarizes the durations of a group of runs or stage attempts
+type DurationStats struct {
+	Name        string // Pipeline mode or stage name
+	Count       int
+	MeanSeconds float64
+	P95Seconds  float64
+}
+
+// ErrorCount counts the failed runs that ended with one error message
+type ErrorCount struct {
+	Message  string
+	Count    int
+	LastSeen time.Time
+}
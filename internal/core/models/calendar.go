@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Calendar stores a shift calendar; Definition holds the timezone, shifts,
+// breaks, holidays and planned downtime
+type Calendar struct {
+	CalendarID uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name       string    `gorm:"type:varchar(100);not null"`
+	Timezone   string    `gorm:"type:varchar(64);not null;default:'UTC'"`
+	Definition JSONB     `gorm:"type:jsonb;not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
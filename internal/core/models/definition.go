@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineDefinition stores the mode and ordered stages of a pipeline so its
+// orchestrator can be rebuilt after a restart
+type PipelineDefinition struct {
+	PipelineID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	IsParallel bool      `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+
+	Stages []PipelineStage `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
+}
+
+// PipelineStage is the stage at Position of a pipeline definition, with the
+// spec it simulates
+type PipelineStage struct {
+	PipelineID    uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Position      int        `gorm:"primaryKey;autoIncrement:false"`
+	StageID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	Name          string     `gorm:"type:varchar(100)"`
+	Type          string     `gorm:"type:varchar(50)"`
+	MeanSeconds   float64    `gorm:"not null"`
+	StdDevSeconds float64    `gorm:"not null"`
+	Yield         float64    `gorm:"not null"`
+	CalendarID    *uuid.UUID `gorm:"type:uuid"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKey remembers a request sent with an Idempotency-Key so a retry
+// gets the stored response instead of repeating the operation. StatusCode
+// stays 0 while the first request is still being handled.
+type IdempotencyKey struct {
+	Scope       string    `gorm:"type:varchar(100);primaryKey"` // Operation and caller the key was used for, see services.IdempotencyScope
+	Key         string    `gorm:"type:varchar(255);primaryKey"`
+	Fingerprint string    `gorm:"type:char(64);not null"` // SHA-256 of the request
+	StatusCode  int       `gorm:"not null;default:0"`
+	Response    JSONB     `gorm:"type:jsonb"`
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
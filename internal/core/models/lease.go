@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineLease grants one server replica the right to execute a pipeline
+// until ExpiresAt; the owner renews it while the pipeline is queued or running
+type PipelineLease struct {
+	PipelineID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Owner      string    `gorm:"type:varchar(100);not null;index"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	AcquiredAt time.Time `gorm:"not null"`
+	RenewedAt  time.Time `gorm:"not null"`
+
+	// CancelRequested is set by a replica that received a cancel for a
+	// pipeline it does not own; the owner cancels it on its next renewal
+	CancelRequested bool `gorm:"not null;default:false"`
+}
@@ -24,15 +24,74 @@ type PipelineExecution struct {
     CreatedAt  time.Time `gorm:"autoCreateTime"`
     UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 
+	// Version counts the status changes of the pipeline; a change only
+	// applies to the version it was validated against
+	Version int `gorm:"not null;default:0"`
+
+	// ReplayOf is the pipeline whose latest run this pipeline replays
+	ReplayOf *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Owner names the server instance that queued or runs the pipeline; on
+	// startup it recovers the runs it left behind
+	Owner string `gorm:"type:varchar(100);index"`
+
+	// CalendarID sets the working hours of every stage without a calendar of its own
+	CalendarID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// TemplateID is the template version the pipeline was instantiated from
+	TemplateID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Labels are key/value tags pipelines are selected by; Annotations hold
+	// free-form notes. Both are JSON objects of strings.
+	Labels      JSONB `gorm:"type:jsonb;index:,type:gin"`
+	Annotations JSONB `gorm:"type:jsonb"`
+
+	// Definition holds the stages the orchestrator is rebuilt from; it is
+	// created together with the execution
+	Definition *PipelineDefinition `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
+
+	// Runs of the pipeline; Status is that of the latest one, or "Created"
+	Runs []PipelineRun `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
+
 	// One PipelineExecution can have multiple ExecutionLogs
 	ExecutionLogs []ExecutionLog `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
+	ExecutionEvents []ExecutionEvent `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
+
+	// Transitions is the status history of the pipeline and its runs
+	Transitions []StatusTransition `gorm:"foreignKey:PipelineID;constraint:OnDelete:CASCADE;"`
 }
 
-// ExecutionLog stores logs related to pipeline execution stages
+// ExecutionLog records one attempt of a stage in a run: when it started and
+// finished on the run's clock, what the stage was given and what it produced.
+// A stage that runs again in the same run, after recovery, logs a new attempt.
+// Entries of no stage, such as recovery decisions, have StageIndex -1.
 type ExecutionLog struct {
-    StageID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+    LogID      uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+    StageID    uuid.UUID `gorm:"type:uuid;not null;index"`
     PipelineID uuid.UUID `gorm:"type:uuid;not null;index"`
+    RunID      uuid.UUID `gorm:"type:uuid;index"`
+    StageIndex int       `gorm:"not null;default:0"`
+    StageName  string    `gorm:"type:varchar(100)"`
+    Attempt    int       `gorm:"not null;default:1"` // 1 for the first attempt of the stage in the run
     Status     string    `gorm:"type:varchar(50);not null"`
     ErrorMsg   string    `gorm:"type:text"`
+    Input      JSONB     `gorm:"type:jsonb"`
+    Output     JSONB     `gorm:"type:jsonb"` // Unset unless the attempt completed
+    StartedAt  *time.Time
+    FinishedAt *time.Time
+    DurationMs int64
     Timestamp  time.Time `gorm:"autoCreateTime"`
-}
\ No newline at end of file
+}
+
+// ExecutionEvent stores one entry of the canonical event sequence of a run
+type ExecutionEvent struct {
+	RunID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Seq         int       `gorm:"primaryKey;autoIncrement:false"`
+	PipelineID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type        string    `gorm:"type:varchar(20);not null"`
+	StageIndex  int
+	StageID     uuid.UUID `gorm:"type:uuid"`
+	Status      string    `gorm:"type:varchar(50);not null"`
+	OffsetNanos int64
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
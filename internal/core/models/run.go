@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineRun is one execution of a pipeline. A pipeline runs any number of
+// times, one run at a time, and its status follows its latest run.
+type PipelineRun struct {
+	RunID      uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	PipelineID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_pipeline_run_number"`
+	Number     int       `gorm:"not null;uniqueIndex:idx_pipeline_run_number"` // 1 for the first run of a pipeline
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status     string    `gorm:"type:varchar(50);not null"`
+	Input      JSONB     `gorm:"type:jsonb"`
+	Output     JSONB     `gorm:"type:jsonb"`
+	ErrorMsg   string    `gorm:"type:text"`
+
+	// Seed and Config (mode, stage specs, input and calendars) are recorded
+	// when the run starts, enough to replay it
+	Seed   int64 `gorm:"not null;default:0"`
+	Config JSONB `gorm:"type:jsonb"`
+
+	// Set on runs a trigger started: the trigger, the run whose end fired it
+	// and the chain of runs before that one; a trigger fires once per run
+	TriggerID        *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_run_trigger"`
+	TriggeredByRunID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_run_trigger"`
+	TriggerChain     JSONB      `gorm:"type:jsonb"`
+
+	// The run is created when it is queued and started once dispatched
+	CreatedAt  time.Time `gorm:"autoCreateTime;index"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+
+	ExecutionLogs []ExecutionLog `gorm:"foreignKey:RunID;references:RunID"`
+}
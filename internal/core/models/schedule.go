@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineSchedule starts runs of a pipeline at the fire times of a cron
+// expression, read on the wall clock of Timezone. NextFireAt is claimed by
+// the replica that fires it, so each fire time starts at most one run.
+type PipelineSchedule struct {
+	ScheduleID   uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	PipelineID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name         string     `gorm:"type:varchar(100)"`
+	Cron         string     `gorm:"type:varchar(100);not null"`
+	Timezone     string     `gorm:"type:varchar(64);not null;default:'UTC'"`
+	MissedPolicy string     `gorm:"type:varchar(20);not null;default:'skip'"`
+	Enabled      bool       `gorm:"not null;default:true"`
+	Input        JSONB      `gorm:"type:jsonb"`
+	Priority     int        `gorm:"not null;default:0"`
+	NextFireAt   *time.Time `gorm:"index"` // nil while the schedule is paused
+	LastFireAt   *time.Time
+	LastRunID    *uuid.UUID `gorm:"type:uuid"`
+	LastError    string     `gorm:"type:text"`
+	MissedFires  int        `gorm:"not null;default:0"` // Fire times dropped by MissedPolicy so far
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
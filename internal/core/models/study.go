@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Study is a Monte Carlo study: one pipeline definition replicated with different seeds
+type Study struct {
+	StudyID      uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PipelineID   *uuid.UUID `gorm:"type:uuid;index"`
+	Name         string     `gorm:"type:varchar(100)"`
+	Replications int        `gorm:"not null"`
+	Units        int        `gorm:"not null"`
+	Seed         int64      `gorm:"not null"`
+	Definition   JSONB      `gorm:"type:jsonb;not null"`
+	Status       string     `gorm:"type:varchar(50);not null"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+
+	Metrics []StudyMetric      `gorm:"foreignKey:StudyID;constraint:OnDelete:CASCADE;"`
+	Samples []StudyReplication `gorm:"foreignKey:StudyID;constraint:OnDelete:CASCADE;"`
+	Stages  []StudyStage       `gorm:"foreignKey:StudyID;constraint:OnDelete:CASCADE;"`
+}
+
+// StudyMetric stores the aggregated statistics of one KPI of a study
+type StudyMetric struct {
+	StudyID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Metric  string    `gorm:"type:varchar(50);primaryKey"`
+	N       int
+	Mean    float64
+	StdDev  float64
+	Min     float64
+	Max     float64
+	P5      float64
+	P50     float64
+	P95     float64
+	CILower float64
+	CIUpper float64
+}
+
+// StudyReplication stores the KPIs of a single replication
+type StudyReplication struct {
+	StudyID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Replication     int       `gorm:"primaryKey;autoIncrement:false"`
+	Seed            int64     `gorm:"not null"`
+	MakespanSeconds float64
+	Throughput      float64
+	Yield           float64
+	GoodUnits       int
+	ScrappedUnits   int
+}
+
+// StudyStage stores the stage-level statistics of a study averaged over its replications
+type StudyStage struct {
+	StudyID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	StageIndex     int       `gorm:"primaryKey;autoIncrement:false"`
+	Name           string    `gorm:"type:varchar(100)"`
+	Processed      float64
+	BusySeconds    float64
+	StarvedSeconds float64
+	BlockedSeconds float64
+	AvgQueueLength float64
+	MaxQueueLength float64
+	Utilization    float64
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineTemplate stores one version of a named pipeline layout; Definition
+// holds the mode and stage specs. Published versions are never changed.
+type PipelineTemplate struct {
+	TemplateID   uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name         string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_template_version"`
+	Version      int        `gorm:"not null;uniqueIndex:idx_template_version"`
+	Description  string     `gorm:"type:text"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'draft'"`
+	Definition   JSONB      `gorm:"type:jsonb;not null"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+	PublishedAt  *time.Time
+	DeprecatedAt *time.Time
+}
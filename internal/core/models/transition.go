@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusTransition records one status change of a pipeline, or of one of its
+// runs when RunID is set, and who made it
+type StatusTransition struct {
+	TransitionID uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	PipelineID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RunID        *uuid.UUID `gorm:"type:uuid;index"`
+	FromStatus   string     `gorm:"type:varchar(50);not null"`
+	ToStatus     string     `gorm:"type:varchar(50);not null"`
+	Actor        string     `gorm:"type:varchar(150);not null"` // user:<id>, instance:<name>, schedule:<id> or trigger:<id>
+	Reason       string     `gorm:"type:text"`
+	CreatedAt    time.Time  `gorm:"not null;index"`
+}
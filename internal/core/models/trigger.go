@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineTrigger starts a run of TargetPipelineID whenever a run of
+// SourcePipelineID ends with the lifecycle event On
+type PipelineTrigger struct {
+	TriggerID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	SourcePipelineID uuid.UUID `gorm:"type:uuid;not null;index"`
+	TargetPipelineID uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID           uuid.UUID `gorm:"type:uuid;not null;index"`
+	On               string    `gorm:"type:varchar(20);not null"`
+	PassOutput       bool      `gorm:"not null;default:false"`
+	Input            JSONB     `gorm:"type:jsonb"` // Input of the target run unless PassOutput is set
+	Enabled          bool      `gorm:"not null;default:true"`
+	LastFiredAt      *time.Time
+	LastRunID        *uuid.UUID `gorm:"type:uuid"`
+	LastError        string     `gorm:"type:text"` // Why the last fire started no run, e.g. a trigger loop
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// AnalyticsQuery selects the runs queued in [From, To), of one user when
+// UserID is set
+type AnalyticsQuery struct {
+	UserID *uuid.UUID
+	From   time.Time
+	To     time.Time
+}
+
+// AnalyticsRepository aggregates pipeline runs in the database
+type AnalyticsRepository interface {
+	CountRunsPerDay(query AnalyticsQuery) ([]models.RunDayCount, error)
+	CountRunsByStatus(query AnalyticsQuery) ([]models.RunStatusCount, error)
+	// GetModeDurations summarizes the wall-clock durations of completed runs per pipeline mode
+	GetModeDurations(query AnalyticsQuery) ([]models.DurationStats, error)
+	// GetStageDurations summarizes the durations of completed stage attempts per stage name
+	GetStageDurations(query AnalyticsQuery) ([]models.DurationStats, error)
+	// GetTopErrors returns the most frequent error messages of failed runs, most frequent first
+	GetTopErrors(query AnalyticsQuery, limit int) ([]models.ErrorCount, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+type CalendarRepository interface {
+	SaveCalendar(calendar *models.Calendar) error
+	UpdateCalendar(calendar *models.Calendar) error
+	DeleteCalendar(calendarID uuid.UUID) error
+	GetCalendar(calendarID uuid.UUID) (*models.Calendar, error)
+	GetCalendarsByUser(userID string) ([]models.Calendar, error)
+
+	// SetPipelineCalendar attaches a calendar to a pipeline, or detaches it when calendarID is nil
+	SetPipelineCalendar(pipelineID uuid.UUID, calendarID *uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey stores a new key for ttl, or replaces one that
+	// expired; it reports false when a live record of the key exists. Expiry
+	// is measured on the database clock.
+	ReserveIdempotencyKey(scope string, key string, fingerprint string, ttl time.Duration) (bool, error)
+	GetIdempotencyKey(scope string, key string) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKey(scope string, key string, statusCode int, response models.JSONB) error
+	DeleteIdempotencyKey(scope string, key string) error
+	DeleteExpiredIdempotencyKeys() (int64, error)
+}
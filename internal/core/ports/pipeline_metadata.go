@@ -0,0 +1,32 @@
+package ports
+
+// Operators of label requirements
+const (
+	LabelEquals       = "="
+	LabelNotEquals    = "!="
+	LabelIn           = "in"
+	LabelNotIn        = "notin"
+	LabelExists       = "exists"
+	LabelDoesNotExist = "!"
+)
+
+// LabelRequirement is one condition of a label selector: a pipeline matches
+// when its label Key relates to Values by Operator. Pipelines without the
+// label match != and notin, as in Kubernetes.
+type LabelRequirement struct {
+	Key      string
+	Operator string
+	Values   []string // One value for = and !=, none for exists and !
+}
+
+// MetadataPatch sets the keys in Set and removes those in Remove from the
+// labels or annotations of a pipeline, leaving the others as they are
+type MetadataPatch struct {
+	Set    map[string]string
+	Remove []string
+}
+
+// Empty reports whether the patch changes nothing
+func (p MetadataPatch) Empty() bool {
+	return len(p.Set) == 0 && len(p.Remove) == 0
+}
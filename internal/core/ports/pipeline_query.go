@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Columns pipelines can be sorted by
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+)
+
+// PipelineQuery selects a page of the pipelines of a user. Unset filters match
+// every pipeline; ties in the sort column are broken by pipeline ID.
+type PipelineQuery struct {
+	UserID        string
+	Statuses      []string
+	IsParallel    *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Selector      []LabelRequirement // Every requirement must hold
+
+	SortBy     string // SortByCreatedAt or SortByUpdatedAt
+	Descending bool
+	After      *PipelineCursor // Position of the last pipeline of the previous page
+	Limit      int
+}
+
+// PipelineCursor is the position of a pipeline in a sorted listing
+type PipelineCursor struct {
+	SortValue  time.Time
+	PipelineID uuid.UUID
+}
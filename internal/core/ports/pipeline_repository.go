@@ -1,18 +1,67 @@
 package ports
 
-import ( "github.com/google/uuid"
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
 )
 
+// ErrNotFound is matched by the errors of lookups whose record does not exist
+var ErrNotFound = errors.New("record not found")
+
 type PipelineRepository interface {
 	SavePipelineExecution(execution *models.PipelineExecution) error
-	UpdatePipelineExecution(execution *models.PipelineExecution) error
+	// SetPipelineStatus compares status and version and reports false if either changed
+	SetPipelineStatus(pipelineID uuid.UUID, from string, version int, status string) (bool, error)
 	SaveExecutionLog(logEntry *models.ExecutionLog) error
 	GetPipelineStatus(pipelineID string) (string, error)
 
 	GetUserByID(userID uuid.UUID) (*models.User, error)
 	SaveUser(user *models.User) error
 	UpdateUser(userID uuid.UUID, updates map[string]interface{}) error 
-	GetPipelinesByUser(userID string) ([]models.PipelineExecution, error)
-	GetPipelineStages(pipelineID uuid.UUID) ([]models.ExecutionLog, error)
+	// ListPipelines returns up to query.Limit pipelines in query order
+	ListPipelines(query PipelineQuery) ([]models.PipelineExecution, error)
+	UpdatePipelineMetadata(pipelineID uuid.UUID, labels MetadataPatch, annotations MetadataPatch) error
+	// CountPipelinesByStatus counts the pipelines of every user per status
+	CountPipelinesByStatus() (map[string]int, error)
+
+	GetPipelineExecution(pipelineID uuid.UUID) (*models.PipelineExecution, error)
+	SaveExecutionEvents(events []models.ExecutionEvent) error
+	GetExecutionEvents(runID uuid.UUID) ([]models.ExecutionEvent, error)
+
+	// QueuePipelineRun saves a run, numbered after the latest run of its
+	// pipeline, together with the pipeline's move to Queued; it reports false,
+	// saving nothing, if the pipeline's status or version changed
+	QueuePipelineRun(run *models.PipelineRun, from string, version int) (bool, error)
+	// UpdatePipelineRun reports false if the run's status changed; it keeps
+	// the started_at of a run that already started
+	UpdatePipelineRun(runID uuid.UUID, from string, updates map[string]interface{}) (bool, error)
+	RecordPipelineRun(runID uuid.UUID, seed int64, config models.JSONB) error
+	GetPipelineRun(runID uuid.UUID) (*models.PipelineRun, error)
+	// GetPipelineRuns returns the runs of a pipeline, latest first
+	GetPipelineRuns(pipelineID uuid.UUID) ([]models.PipelineRun, error)
+	// GetLatestPipelineRun returns the latest run, or the latest that started when started is set
+	GetLatestPipelineRun(pipelineID uuid.UUID, started bool) (*models.PipelineRun, error)
+	GetRunLogs(runID uuid.UUID) ([]models.ExecutionLog, error)
+	GetRunStatus(runID uuid.UUID) (string, error)
+
+	// Transitions are saved after the state machine allowed them
+	SaveStatusTransition(transition *models.StatusTransition) error
+	// GetStatusTransitions returns the status history of a pipeline and its runs, oldest first
+	GetStatusTransitions(pipelineID uuid.UUID) ([]models.StatusTransition, error)
+
+	// Definitions are saved with their execution by SavePipelineExecution
+	GetPipelineDefinition(pipelineID uuid.UUID) (*models.PipelineDefinition, error)
+
+	SetPipelineOwner(pipelineID uuid.UUID, owner string) error
+	GetOrphanedPipelines(owner string, statuses []string) ([]models.PipelineExecution, error)
+
+	// Leases are compared against the database clock, so replicas need not agree on the time
+	AcquirePipelineLease(pipelineID uuid.UUID, owner string, ttl time.Duration) (bool, error)
+	RenewPipelineLeases(owner string, pipelineIDs []uuid.UUID, ttl time.Duration) ([]models.PipelineLease, error)
+	ReleasePipelineLease(pipelineID uuid.UUID, owner string) error
+	GetPipelineLease(pipelineID uuid.UUID) (*models.PipelineLease, error)
+	RequestPipelineCancel(pipelineID uuid.UUID) (bool, error)
 }
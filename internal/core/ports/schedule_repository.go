@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+type ScheduleRepository interface {
+	SaveSchedule(schedule *models.PipelineSchedule) error
+	UpdateSchedule(schedule *models.PipelineSchedule) error
+	DeleteSchedule(scheduleID uuid.UUID) error
+	GetSchedule(scheduleID uuid.UUID) (*models.PipelineSchedule, error)
+	// GetSchedules returns the schedules of a user, or of a pipeline when pipelineID is set
+	GetSchedules(userID string, pipelineID *uuid.UUID) ([]models.PipelineSchedule, error)
+	// GetDueSchedules returns the enabled schedules whose next fire time is not after now
+	GetDueSchedules(now time.Time) ([]models.PipelineSchedule, error)
+
+	// ClaimScheduleFire moves a schedule from the fire time due to next and
+	// records the missed fires; it reports false when another replica claimed
+	// due first or the schedule changed meanwhile
+	ClaimScheduleFire(scheduleID uuid.UUID, due time.Time, next time.Time, missed int) (bool, error)
+	// RecordScheduleFire stores the outcome of a fire
+	RecordScheduleFire(scheduleID uuid.UUID, firedAt time.Time, runID *uuid.UUID, errorMsg string) error
+}
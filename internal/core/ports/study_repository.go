@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// StudyRepository persists Monte Carlo studies and their aggregated results
+type StudyRepository interface {
+	SaveStudy(study *models.Study) error
+	GetStudy(studyID uuid.UUID) (*models.Study, error)
+	GetStudiesByUser(userID string) ([]models.Study, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+type TemplateRepository interface {
+	SaveTemplate(template *models.PipelineTemplate) error
+	// UpdateTemplate only changes drafts
+	UpdateTemplate(template *models.PipelineTemplate) error
+	SetTemplateStatus(templateID uuid.UUID, status string) error
+	GetTemplate(name string, version int) (*models.PipelineTemplate, error)
+	// GetLatestTemplate returns the highest version of a template in one of the given statuses
+	GetLatestTemplate(name string, statuses []string) (*models.PipelineTemplate, error)
+	// ListTemplates returns every version of every template, or of name when it is set
+	ListTemplates(name string) ([]models.PipelineTemplate, error)
+}
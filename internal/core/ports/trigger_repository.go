@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+type TriggerRepository interface {
+	SaveTrigger(trigger *models.PipelineTrigger) error
+	SetTriggerEnabled(triggerID uuid.UUID, enabled bool) error
+	DeleteTrigger(triggerID uuid.UUID) error
+	GetTrigger(triggerID uuid.UUID) (*models.PipelineTrigger, error)
+	// GetTriggers returns the triggers a pipeline is the source or the target of
+	GetTriggers(pipelineID uuid.UUID) ([]models.PipelineTrigger, error)
+	// GetSourceTriggers returns the enabled triggers a pipeline is the source of
+	GetSourceTriggers(pipelineID uuid.UUID) ([]models.PipelineTrigger, error)
+	// RecordTriggerFire stores the run a fire started or why it started none
+	RecordTriggerFire(triggerID uuid.UUID, firedAt time.Time, runID *uuid.UUID, errorMsg string) error
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// Limits of analytics queries
+const (
+	DefaultAnalyticsWindow = 30 * 24 * time.Hour
+	MaxAnalyticsWindow     = 366 * 24 * time.Hour
+	DefaultTopErrors       = 10
+	MaxTopErrors           = 100
+)
+
+// ErrInvalidAnalyticsQuery is returned for time windows, users or limits an
+// aggregate cannot be computed over
+var ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+
+// AnalyticsService aggregates pipeline runs; every aggregate is computed by
+// the repository, so it never loads the runs themselves
+type AnalyticsService struct {
+	Repository ports.AnalyticsRepository
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+func NewAnalyticsService(repo ports.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{Repository: repo}
+}
+
+// AnalyticsRequest selects the runs queued in [From, To). To defaults to now
+// and From to DefaultAnalyticsWindow before To; UserID limits the runs to
+// those of one user.
+type AnalyticsRequest struct {
+	UserID string
+	From   *time.Time
+	To     *time.Time
+}
+
+// AnalyticsWindow is the time window an aggregate covers
+type AnalyticsWindow struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// DayRuns counts the runs queued on one UTC day
+type DayRuns struct {
+	Day      string         `json:"day"` // YYYY-MM-DD
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"`
+}
+
+// RunsPerDay lists every day of a window, including those without runs
+type RunsPerDay struct {
+	AnalyticsWindow
+	Days []DayRuns `json:"days"`
+}
+
+// RunOutcomes counts the runs of a window by outcome. The rates are fractions
+// of the runs that ended, so queued and running runs do not lower them.
+type RunOutcomes struct {
+	AnalyticsWindow
+	Total       int     `json:"total"`
+	Completed   int     `json:"completed"`
+	Failed      int     `json:"failed"`
+	Cancelled   int     `json:"cancelled"`
+	Interrupted int     `json:"interrupted"`
+	Active      int     `json:"active"`
+	SuccessRate float64 `json:"success_rate"`
+	FailureRate float64 `json:"failure_rate"`
+	CancelRate  float64 `json:"cancel_rate"`
+}
+
+// DurationSummary is the mean and 95th percentile duration of a group of runs
+// or stage attempts
+type DurationSummary struct {
+	Name        string  `json:"name"`
+	Count       int     `json:"count"`
+	MeanSeconds float64 `json:"mean_seconds"`
+	P95Seconds  float64 `json:"p95_seconds"`
+}
+
+// DurationReport summarizes completed runs per pipeline mode and completed
+// stage attempts per stage name
+type DurationReport struct {
+	AnalyticsWindow
+	Modes  []DurationSummary `json:"modes"`
+	Stages []DurationSummary `json:"stages"`
+}
+
+// ErrorFrequency counts the failed runs that ended with one error message
+type ErrorFrequency struct {
+	Message  string    `json:"message"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// TopErrors lists the most frequent error messages, most frequent first
+type TopErrors struct {
+	AnalyticsWindow
+	Errors []ErrorFrequency `json:"errors"`
+}
+
+// GetRunsPerDay counts the runs queued per day and status
+func (as *AnalyticsService) GetRunsPerDay(req AnalyticsRequest) (*RunsPerDay, error) {
+	query, err := analyticsQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := as.Repository.CountRunsPerDay(query)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RunsPerDay{AnalyticsWindow: AnalyticsWindow{From: query.From, To: query.To}, Days: []DayRuns{}}
+	index := make(map[string]int)
+	for day := truncateDay(query.From); day.Before(query.To); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		index[key] = len(report.Days)
+		report.Days = append(report.Days, DayRuns{Day: key, ByStatus: map[string]int{}})
+	}
+	for _, count := range counts {
+		i, ok := index[count.Day.UTC().Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		report.Days[i].Total += count.Runs
+		report.Days[i].ByStatus[count.Status] += count.Runs
+	}
+	return report, nil
+}
+
+// GetRunOutcomes counts the runs by outcome and computes the success, failure
+// and cancel rates
+func (as *AnalyticsService) GetRunOutcomes(req AnalyticsRequest) (*RunOutcomes, error) {
+	query, err := analyticsQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := as.Repository.CountRunsByStatus(query)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := &RunOutcomes{AnalyticsWindow: AnalyticsWindow{From: query.From, To: query.To}}
+	for _, count := range counts {
+		outcomes.Total += count.Runs
+		switch status := domain.Status(count.Status); {
+		case status == domain.StatusCompleted:
+			outcomes.Completed += count.Runs
+		case status == domain.StatusFailed:
+			outcomes.Failed += count.Runs
+		case status == domain.StatusCancelled:
+			outcomes.Cancelled += count.Runs
+		case status == domain.StatusInterrupted:
+			outcomes.Interrupted += count.Runs
+		case status.Active():
+			outcomes.Active += count.Runs
+		}
+	}
+	if ended := outcomes.Completed + outcomes.Failed + outcomes.Cancelled + outcomes.Interrupted; ended > 0 {
+		outcomes.SuccessRate = float64(outcomes.Completed) / float64(ended)
+		outcomes.FailureRate = float64(outcomes.Failed+outcomes.Interrupted) / float64(ended)
+		outcomes.CancelRate = float64(outcomes.Cancelled) / float64(ended)
+	}
+	return outcomes, nil
+}
+
+// GetDurations summarizes the durations of completed runs per pipeline mode
+// and of completed stage attempts per stage
+func (as *AnalyticsService) GetDurations(req AnalyticsRequest) (*DurationReport, error) {
+	query, err := analyticsQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	modes, err := as.Repository.GetModeDurations(query)
+	if err != nil {
+		return nil, err
+	}
+	stages, err := as.Repository.GetStageDurations(query)
+	if err != nil {
+		return nil, err
+	}
+	return &DurationReport{
+		AnalyticsWindow: AnalyticsWindow{From: query.From, To: query.To},
+		Modes:           durationSummaries(modes),
+		Stages:          durationSummaries(stages),
+	}, nil
+}
+
+// GetTopErrors returns the most frequent error messages of failed runs;
+// limit defaults to DefaultTopErrors when 0
+func (as *AnalyticsService) GetTopErrors(req AnalyticsRequest, limit int) (*TopErrors, error) {
+	query, err := analyticsQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case limit == 0:
+		limit = DefaultTopErrors
+	case limit < 0 || limit > MaxTopErrors:
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAnalyticsQuery, MaxTopErrors)
+	}
+	counts, err := as.Repository.GetTopErrors(query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TopErrors{AnalyticsWindow: AnalyticsWindow{From: query.From, To: query.To}, Errors: make([]ErrorFrequency, 0, len(counts))}
+	for _, count := range counts {
+		report.Errors = append(report.Errors, ErrorFrequency{Message: count.Message, Count: count.Count, LastSeen: count.LastSeen})
+	}
+	return report, nil
+}
+
+// analyticsQuery validates an analytics request and fills in its defaults
+func analyticsQuery(req AnalyticsRequest) (ports.AnalyticsQuery, error) {
+	query := ports.AnalyticsQuery{To: time.Now().UTC()}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return query, fmt.Errorf("%w: invalid user ID", ErrInvalidAnalyticsQuery)
+		}
+		query.UserID = &userID
+	}
+	if req.To != nil {
+		query.To = req.To.UTC()
+	}
+	query.From = query.To.Add(-DefaultAnalyticsWindow)
+	if req.From != nil {
+		query.From = req.From.UTC()
+	}
+
+	if !query.From.Before(query.To) {
+		return query, fmt.Errorf("%w: from must be before to", ErrInvalidAnalyticsQuery)
+	}
+	if query.To.Sub(query.From) > MaxAnalyticsWindow {
+		return query, fmt.Errorf("%w: window must not exceed %d days", ErrInvalidAnalyticsQuery, int(MaxAnalyticsWindow.Hours()/24))
+	}
+	return query, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func durationSummaries(stats []models.DurationStats) []DurationSummary {
+	summaries := make([]DurationSummary, 0, len(stats))
+	for _, s := range stats {
+		summaries = append(summaries, DurationSummary{Name: s.Name, Count: s.Count, MeanSeconds: s.MeanSeconds, P95Seconds: s.P95Seconds})
+	}
+	return summaries
+}
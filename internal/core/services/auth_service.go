@@ -163,6 +163,18 @@ func (s *AuthService) GetUserByID(userID uuid.UUID) (*models.User, error) {
 	return s.Repo.GetUserByID(userID)
 }
 
+// operatorRoles may see and run the work-order queue of every user
+var operatorRoles = map[string]bool{"super_admin": true, "admin": true}
+
+// IsOperator reports whether a user holds an operator role
+func (s *AuthService) IsOperator(userID uuid.UUID) (bool, error) {
+	user, err := s.Repo.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+	return operatorRoles[user.Role], nil
+}
+
 // UpdateUser updates user details
 func (s *AuthService) UpdateUser(userID uuid.UUID, updates map[string]interface{}) error {
 	return s.Repo.UpdateUser(userID, updates)
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// Sources a bottleneck report can be built from
+const (
+	BottleneckSourceRun   = "run"
+	BottleneckSourceStudy = "study"
+)
+
+// BottleneckService identifies the stages that limit the throughput of a pipeline
+type BottleneckService struct {
+	Pipelines *PipelineService
+	Studies   ports.StudyRepository
+}
+
+// NewBottleneckService initializes the BottleneckService
+func NewBottleneckService(pipelines *PipelineService, studies ports.StudyRepository) *BottleneckService {
+	return &BottleneckService{
+		Pipelines: pipelines,
+		Studies:   studies,
+	}
+}
+
+// GetBottlenecks builds a ranked bottleneck report for a pipeline from the
+// stage-level data of its last run, or of one of its studies when studyID is set
+func (s *BottleneckService) GetBottlenecks(pipelineID uuid.UUID, studyID *uuid.UUID) (*domain.BottleneckReport, error) {
+	if studyID != nil {
+		return s.studyBottlenecks(pipelineID, *studyID)
+	}
+	return s.runBottlenecks(pipelineID)
+}
+
+func (s *BottleneckService) runBottlenecks(pipelineID uuid.UUID) (*domain.BottleneckReport, error) {
+	run, cfg, err := s.Pipelines.lastExecutedRun(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	def := domain.PipelineDefinition{IsParallel: cfg.IsParallel, Stages: cfg.Stages}.WithDefaults()
+
+	events, err := s.Pipelines.runEvents(run.RunID)
+	if err != nil {
+		return nil, err
+	}
+	stats, makespan, err := domain.StageStatsFromEvents(events, def.Stages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BottleneckReport{
+		PipelineID:      pipelineID.String(),
+		Source:          BottleneckSourceRun,
+		MakespanSeconds: makespan.Seconds(),
+		Stages:          domain.AnalyzeBottlenecks(stats, def.IsParallel),
+	}, nil
+}
+
+func (s *BottleneckService) studyBottlenecks(pipelineID uuid.UUID, studyID uuid.UUID) (*domain.BottleneckReport, error) {
+	study, err := s.Studies.GetStudy(studyID)
+	if err != nil {
+		return nil, errors.New("study not found")
+	}
+	if study.PipelineID == nil || *study.PipelineID != pipelineID {
+		return nil, errors.New("study was not run for this pipeline")
+	}
+	if len(study.Stages) == 0 {
+		return nil, errors.New("study has no stage-level data")
+	}
+
+	var def domain.PipelineDefinition
+	if err := study.Definition.Unmarshal(&def); err != nil {
+		return nil, err
+	}
+
+	report := &domain.BottleneckReport{
+		PipelineID: pipelineID.String(),
+		StudyID:    studyID.String(),
+		Source:     BottleneckSourceStudy,
+		Stages:     domain.AnalyzeBottlenecks(toStageStats(study.Stages), def.IsParallel),
+	}
+	for _, m := range study.Metrics {
+		if m.Metric == domain.MetricMakespan {
+			report.MakespanSeconds = m.Mean
+		}
+	}
+	return report, nil
+}
+
+func toStageStats(stages []models.StudyStage) []domain.StageStats {
+	stats := make([]domain.StageStats, len(stages))
+	for i, st := range stages {
+		stats[i] = domain.StageStats{
+			Index:          st.StageIndex,
+			Name:           st.Name,
+			Processed:      st.Processed,
+			BusySeconds:    st.BusySeconds,
+			StarvedSeconds: st.StarvedSeconds,
+			BlockedSeconds: st.BlockedSeconds,
+			AvgQueueLength: st.AvgQueueLength,
+			MaxQueueLength: st.MaxQueueLength,
+			Utilization:    st.Utilization,
+		}
+	}
+	return stats
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// MaxCalendarPreview bounds the range of a working-window preview
+const MaxCalendarPreview = 31 * 24 * time.Hour
+
+// CalendarService manages shift calendars and their attachment to pipelines
+type CalendarService struct {
+	Repository ports.CalendarRepository
+}
+
+// NewCalendarService initializes the CalendarService
+func NewCalendarService(repo ports.CalendarRepository) *CalendarService {
+	return &CalendarService{Repository: repo}
+}
+
+// CreateCalendar validates and stores a new calendar
+func (s *CalendarService) CreateCalendar(userID uuid.UUID, name string, calendar domain.Calendar) (*models.Calendar, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	record, err := newCalendarRecord(name, calendar)
+	if err != nil {
+		return nil, err
+	}
+	record.CalendarID = uuid.New()
+	record.UserID = userID
+	record.CreatedAt = time.Now()
+
+	if err := s.Repository.SaveCalendar(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UpdateCalendar replaces a calendar; runs already started keep the version they started with
+func (s *CalendarService) UpdateCalendar(calendarID uuid.UUID, name string, calendar domain.Calendar) (*models.Calendar, error) {
+	existing, err := s.Repository.GetCalendar(calendarID)
+	if err != nil {
+		return nil, errors.New("calendar not found")
+	}
+	record, err := newCalendarRecord(name, calendar)
+	if err != nil {
+		return nil, err
+	}
+	record.CalendarID = calendarID
+	record.UserID = existing.UserID
+	record.CreatedAt = existing.CreatedAt
+
+	if err := s.Repository.UpdateCalendar(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// DeleteCalendar removes a calendar and detaches it from its pipelines
+func (s *CalendarService) DeleteCalendar(calendarID uuid.UUID) error {
+	return s.Repository.DeleteCalendar(calendarID)
+}
+
+// GetCalendar fetches a stored calendar
+func (s *CalendarService) GetCalendar(calendarID uuid.UUID) (*models.Calendar, error) {
+	return s.Repository.GetCalendar(calendarID)
+}
+
+// GetCalendarsByUser lists the calendars of a user
+func (s *CalendarService) GetCalendarsByUser(userID string) ([]models.Calendar, error) {
+	return s.Repository.GetCalendarsByUser(userID)
+}
+
+// GetWorkingWindows previews the working time of a calendar between from and to
+func (s *CalendarService) GetWorkingWindows(calendarID uuid.UUID, from, to time.Time) ([]domain.WorkingWindow, error) {
+	if !to.After(from) || to.Sub(from) > MaxCalendarPreview {
+		return nil, errors.New("range must be positive and at most 31 days")
+	}
+	calendar, err := LoadCalendar(s.Repository, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	return calendar.WorkingWindows(from, to)
+}
+
+// AttachToPipeline sets the calendar of a pipeline; a nil calendarID detaches it
+func (s *CalendarService) AttachToPipeline(pipelineID uuid.UUID, calendarID *uuid.UUID) error {
+	if calendarID != nil {
+		if _, err := s.Repository.GetCalendar(*calendarID); err != nil {
+			return errors.New("calendar not found")
+		}
+	}
+	return s.Repository.SetPipelineCalendar(pipelineID, calendarID)
+}
+
+// LoadCalendar fetches a stored calendar as its domain model
+func LoadCalendar(repo ports.CalendarRepository, calendarID uuid.UUID) (*domain.Calendar, error) {
+	record, err := repo.GetCalendar(calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("calendar %s not found", calendarID)
+	}
+	var calendar domain.Calendar
+	if err := record.Definition.Unmarshal(&calendar); err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+func newCalendarRecord(name string, calendar domain.Calendar) (*models.Calendar, error) {
+	if name == "" {
+		return nil, errors.New("calendar name is required")
+	}
+	if calendar.Timezone == "" {
+		calendar.Timezone = "UTC"
+	}
+	if err := calendar.Validate(); err != nil {
+		return nil, err
+	}
+	definition, err := models.NewJSONB(calendar)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Calendar{
+		Name:       name,
+		Timezone:   calendar.Timezone,
+		Definition: definition,
+		UpdatedAt:  time.Now(),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// DefaultIdempotencyTTL is how long a response is kept for retries with the
+// same idempotency key unless IDEMPOTENCY_TTL says otherwise
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// MaxIdempotencyKeyLength bounds the keys clients may send
+const MaxIdempotencyKeyLength = 255
+
+var (
+	// ErrInvalidIdempotencyKey is returned for an empty or overlong key
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 255 characters")
+	// ErrIdempotencyMismatch is returned when a key is reused for a different request
+	ErrIdempotencyMismatch = errors.New("idempotency key was already used with a different request")
+	// ErrIdempotencyInProgress is returned while the first request with a key is still being handled
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IdempotencyTTLFromEnv reads the response retention window from IDEMPOTENCY_TTL, e.g. "24h"
+func IdempotencyTTLFromEnv() time.Duration {
+	if raw := os.Getenv("IDEMPOTENCY_TTL"); raw != "" {
+		if ttl, err := time.ParseDuration(raw); err == nil && ttl > 0 {
+			return ttl
+		}
+		log.Printf("Ignoring invalid IDEMPOTENCY_TTL %q", raw)
+	}
+	return DefaultIdempotencyTTL
+}
+
+// IdempotencyService makes create and start requests safe to retry: the
+// first request with a key is handled and its response stored, and retries
+// with the same key and request get that response back
+type IdempotencyService struct {
+	Repository ports.IdempotencyRepository
+	TTL        time.Duration
+}
+
+// NewIdempotencyService initializes the IdempotencyService
+func NewIdempotencyService(repo ports.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{Repository: repo, TTL: ttl}
+}
+
+// StoredResponse is the response of the first request with a key
+type StoredResponse struct {
+	StatusCode int
+	Body       models.JSONB
+}
+
+// IdempotencyScope scopes keys to an operation and the caller that sent them,
+// so clients choosing the same key never see each other's requests
+func IdempotencyScope(operation string, caller string) string {
+	return operation + " " + caller
+}
+
+// RequestFingerprint hashes an operation and its request body. JSON bodies
+// are compared by content, so formatting and key order do not matter.
+func RequestFingerprint(operation string, body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if canonical, err := json.Marshal(v); err == nil {
+			body = canonical
+		}
+	}
+	h := sha256.New()
+	h.Write([]byte(operation))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Begin reserves key within scope for a request with fingerprint. It returns
+// the stored response when the same request was already handled; otherwise
+// it returns nil and the caller handles the request, then calls Complete or
+// Release.
+func (s *IdempotencyService) Begin(scope string, key string, fingerprint string) (*StoredResponse, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	reserved, err := s.Repository.ReserveIdempotencyKey(scope, key, fingerprint, s.TTL)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	record, err := s.Repository.GetIdempotencyKey(scope, key)
+	if err != nil {
+		// Released meanwhile; the client may retry
+		return nil, ErrIdempotencyInProgress
+	}
+	if record.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyMismatch
+	}
+	if record.StatusCode == 0 {
+		return nil, ErrIdempotencyInProgress
+	}
+	return &StoredResponse{StatusCode: record.StatusCode, Body: record.Response}, nil
+}
+
+// Complete stores the response to a request whose key Begin reserved
+func (s *IdempotencyService) Complete(scope string, key string, statusCode int, body []byte) {
+	if err := s.Repository.CompleteIdempotencyKey(scope, key, statusCode, models.JSONB(body)); err != nil {
+		log.Printf("Failed to store response for idempotency key %q: %v", key, err)
+	}
+}
+
+// Release frees a key whose request failed, so it can be retried
+func (s *IdempotencyService) Release(scope string, key string) {
+	if err := s.Repository.DeleteIdempotencyKey(scope, key); err != nil {
+		log.Printf("Failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+// PurgeExpiredKeys deletes expired idempotency keys every hour. It never returns.
+func (s *IdempotencyService) PurgeExpiredKeys() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if n, err := s.Repository.DeleteExpiredIdempotencyKeys(); err != nil {
+			log.Printf("Failed to purge expired idempotency keys: %v", err)
+		} else if n > 0 {
+			log.Printf("Purged %d expired idempotency keys", n)
+		}
+	}
+}
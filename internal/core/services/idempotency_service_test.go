@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// idempotencyRepository keeps idempotency keys in memory; keys never expire
+type idempotencyRepository struct {
+	keys map[[2]string]*models.IdempotencyKey
+}
+
+func (r *idempotencyRepository) ReserveIdempotencyKey(scope string, key string, fingerprint string, ttl time.Duration) (bool, error) {
+	if _, ok := r.keys[[2]string{scope, key}]; ok {
+		return false, nil
+	}
+	r.keys[[2]string{scope, key}] = &models.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint}
+	return true, nil
+}
+
+func (r *idempotencyRepository) GetIdempotencyKey(scope string, key string) (*models.IdempotencyKey, error) {
+	record, ok := r.keys[[2]string{scope, key}]
+	if !ok {
+		return nil, ports.ErrNotFound
+	}
+	return record, nil
+}
+
+func (r *idempotencyRepository) CompleteIdempotencyKey(scope string, key string, statusCode int, response models.JSONB) error {
+	record, ok := r.keys[[2]string{scope, key}]
+	if !ok {
+		return ports.ErrNotFound
+	}
+	record.StatusCode = statusCode
+	record.Response = response
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteIdempotencyKey(scope string, key string) error {
+	delete(r.keys, [2]string{scope, key})
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpiredIdempotencyKeys() (int64, error) {
+	return 0, nil
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	alice := IdempotencyScope("start", uuid.New().String())
+	bob := IdempotencyScope("start", uuid.New().String())
+	request := RequestFingerprint("start", []byte(`{"user_id":"u","is_parallel":false}`))
+	other := RequestFingerprint("start", []byte(`{"user_id":"u","is_parallel":true}`))
+
+	// Each step runs against the keys the steps before it left behind
+	steps := []struct {
+		name    string
+		run     func(s *IdempotencyService) (*StoredResponse, error)
+		wantErr error
+		want    int // Status code of the replayed response, 0 for none
+	}{
+		{name: "first request reserves the key", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, "k1", request)
+		}},
+		{name: "retry while in progress", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, "k1", request)
+		}, wantErr: ErrIdempotencyInProgress},
+		{name: "other request while in progress", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, "k1", other)
+		}, wantErr: ErrIdempotencyMismatch},
+		{name: "same key from another user", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(bob, "k1", other)
+		}},
+		{name: "retry after completion replays", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			s.Complete(alice, "k1", 202, []byte(`{"run":1}`))
+			return s.Begin(alice, "k1", request)
+		}, want: 202},
+		{name: "other request after completion", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, "k1", other)
+		}, wantErr: ErrIdempotencyMismatch},
+		{name: "other user's key is still in progress", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(bob, "k1", other)
+		}, wantErr: ErrIdempotencyInProgress},
+		{name: "released key can be reused", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			s.Release(bob, "k1")
+			return s.Begin(bob, "k1", request)
+		}},
+		{name: "empty key", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, "", request)
+		}, wantErr: ErrInvalidIdempotencyKey},
+		{name: "overlong key", run: func(s *IdempotencyService) (*StoredResponse, error) {
+			return s.Begin(alice, strings.Repeat("k", MaxIdempotencyKeyLength+1), request)
+		}, wantErr: ErrInvalidIdempotencyKey},
+	}
+
+	s := NewIdempotencyService(&idempotencyRepository{keys: make(map[[2]string]*models.IdempotencyKey)}, time.Hour)
+	for _, step := range steps {
+		stored, err := step.run(s)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: Begin() error = %v, want %v", step.name, err, step.wantErr)
+		}
+		switch {
+		case step.want == 0 && stored != nil:
+			t.Errorf("%s: Begin() replayed %d, want no stored response", step.name, stored.StatusCode)
+		case step.want != 0 && (stored == nil || stored.StatusCode != step.want || string(stored.Body) != `{"run":1}`):
+			t.Errorf("%s: Begin() = %+v, want the stored %d response", step.name, stored, step.want)
+		}
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	base := RequestFingerprint("start", []byte(`{"a":1,"b":[1,2]}`))
+	tests := []struct {
+		name      string
+		operation string
+		body      string
+		same      bool
+	}{
+		{name: "key order and spacing", operation: "start", body: "{ \"b\": [1, 2],\n \"a\": 1 }", same: true},
+		{name: "different value", operation: "start", body: `{"a":2,"b":[1,2]}`},
+		{name: "array order", operation: "start", body: `{"a":1,"b":[2,1]}`},
+		{name: "different operation", operation: "create", body: `{"a":1,"b":[1,2]}`},
+		{name: "not json", operation: "start", body: `a=1&b=1,2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestFingerprint(tt.operation, []byte(tt.body)); (got == base) != tt.same {
+				t.Errorf("RequestFingerprint(%q, %s) matches the base request: %v, want %v", tt.operation, tt.body, got == base, tt.same)
+			}
+		})
+	}
+}
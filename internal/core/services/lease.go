@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Replicas sharing one database coordinate through pipeline leases: only the
+// replica holding the lease of a pipeline queues and executes it
+const (
+	LeaseTTL           = 30 * time.Second
+	LeaseRenewInterval = 10 * time.Second
+)
+
+// ErrLeaseHeld is returned for commands on a pipeline another replica executes
+var ErrLeaseHeld = errors.New("pipeline is owned by another replica")
+
+// ErrCancelForwarded reports that a cancel was handed to the replica that owns
+// the pipeline; it cancels the pipeline on its next lease renewal
+var ErrCancelForwarded = errors.New("cancellation forwarded to the owning replica")
+
+// errLeaseLost stops a run whose lease another replica took over; the new
+// owner records its outcome
+var errLeaseLost = errors.New("lease of the pipeline was lost")
+
+// heldLease is a lease this replica holds. cancel stops the run once it
+// started; cause keeps a stop requested before that.
+type heldLease struct {
+	cancel context.CancelCauseFunc
+	cause  error
+}
+
+// acquireLease takes the lease of a pipeline for this replica. It reports
+// whether this call took it rather than finding it held here already; only
+// a call that took the lease may release it on failure.
+func (ps *PipelineService) acquireLease(pipelineID uuid.UUID) (bool, error) {
+	acquired, err := ps.Repository.AcquirePipelineLease(pipelineID, ps.Instance, LeaseTTL)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		if lease, err := ps.Repository.GetPipelineLease(pipelineID); err == nil {
+			return false, fmt.Errorf("%w (%s)", ErrLeaseHeld, lease.Owner)
+		}
+		return false, ErrLeaseHeld
+	}
+
+	ps.leaseMu.Lock()
+	defer ps.leaseMu.Unlock()
+	if _, held := ps.leases[pipelineID]; held {
+		return false, nil
+	}
+	ps.leases[pipelineID] = &heldLease{}
+	return true, nil
+}
+
+// releaseLease gives up the lease once the pipeline no longer runs here
+func (ps *PipelineService) releaseLease(pipelineID uuid.UUID) {
+	ps.leaseMu.Lock()
+	delete(ps.leases, pipelineID)
+	ps.leaseMu.Unlock()
+
+	if err := ps.Repository.ReleasePipelineLease(pipelineID, ps.Instance); err != nil {
+		log.Printf("Failed to release lease of pipeline %s: %v", pipelineID, err)
+	}
+}
+
+func (ps *PipelineService) holdsLease(pipelineID uuid.UUID) bool {
+	ps.leaseMu.Lock()
+	defer ps.leaseMu.Unlock()
+	_, held := ps.leases[pipelineID]
+	return held
+}
+
+// leaseHolder returns the replica holding a live lease on a pipeline other
+// than this one, or "" when there is none
+func (ps *PipelineService) leaseHolder(pipelineID uuid.UUID) string {
+	if ps.holdsLease(pipelineID) {
+		return ""
+	}
+	lease, err := ps.Repository.GetPipelineLease(pipelineID)
+	if err != nil || lease.Owner == ps.Instance {
+		return ""
+	}
+	return lease.Owner
+}
+
+// leaseContext derives the context a leased pipeline runs with; it is
+// cancelled by stopRun and when the lease is lost, and context.Cause tells why
+func (ps *PipelineService) leaseContext(ctx context.Context, pipelineID uuid.UUID) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	ps.leaseMu.Lock()
+	defer ps.leaseMu.Unlock()
+	if lease, held := ps.leases[pipelineID]; held {
+		lease.cancel = cancel
+		if lease.cause != nil {
+			cancel(lease.cause)
+		}
+	}
+	return ctx, func() { cancel(nil) }
+}
+
+// stopRun cancels the context of the pipeline this replica runs with cause,
+// or of its run as soon as it starts; it reports whether the lease is held here
+func (ps *PipelineService) stopRun(pipelineID uuid.UUID, cause error) bool {
+	ps.leaseMu.Lock()
+	defer ps.leaseMu.Unlock()
+	lease, held := ps.leases[pipelineID]
+	if !held {
+		return false
+	}
+	if lease.cause == nil {
+		lease.cause = cause
+	}
+	if lease.cancel != nil {
+		lease.cancel(lease.cause)
+	}
+	return true
+}
+
+// MaintainLeases renews the leases of this replica every LeaseRenewInterval,
+// carries out cancels forwarded by other replicas, and takes over the runs of
+// replicas whose leases expired with the recovery policy. It never returns.
+func (ps *PipelineService) MaintainLeases(policy string) {
+	ticker := time.NewTicker(LeaseRenewInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ps.renewLeases()
+		if err := ps.RecoverRuns(policy); err != nil {
+			log.Printf("Failed to take over orphaned runs: %v", err)
+		}
+	}
+}
+
+func (ps *PipelineService) renewLeases() {
+	ps.leaseMu.Lock()
+	held := make([]uuid.UUID, 0, len(ps.leases))
+	for pipelineID := range ps.leases {
+		held = append(held, pipelineID)
+	}
+	ps.leaseMu.Unlock()
+
+	if len(held) == 0 {
+		return
+	}
+	renewed, err := ps.Repository.RenewPipelineLeases(ps.Instance, held, LeaseTTL)
+	if err != nil {
+		// The leases stay ours until they expire; the next tick tries again
+		log.Printf("Failed to renew pipeline leases: %v", err)
+		return
+	}
+
+	kept := make(map[uuid.UUID]bool, len(renewed))
+	for _, lease := range renewed {
+		kept[lease.PipelineID] = true
+		if lease.CancelRequested {
+			go ps.cancelForwarded(lease.PipelineID)
+		}
+	}
+	for _, pipelineID := range held {
+		if !kept[pipelineID] {
+			ps.loseLease(pipelineID)
+		}
+	}
+}
+
+// loseLease stops a pipeline whose lease another replica took over, or that
+// was released meanwhile
+func (ps *PipelineService) loseLease(pipelineID uuid.UUID) {
+	ps.leaseMu.Lock()
+	lease, held := ps.leases[pipelineID]
+	delete(ps.leases, pipelineID)
+	ps.leaseMu.Unlock()
+
+	if !held {
+		return
+	}
+	log.Printf("[WARN] Lost the lease of pipeline %s, abandoning it to its new owner", pipelineID)
+	ps.Queue.Remove(pipelineID)
+	if lease.cancel != nil {
+		lease.cancel(errLeaseLost)
+	}
+}
+
+// cancelForwarded cancels a pipeline on behalf of the replica that received the request
+func (ps *PipelineService) cancelForwarded(pipelineID uuid.UUID) {
+	execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+	if err != nil {
+		log.Printf("Failed to load pipeline %s for a forwarded cancel: %v", pipelineID, err)
+		return
+	}
+	definition, err := ps.Repository.GetPipelineDefinition(pipelineID)
+	if err != nil {
+		log.Printf("Failed to load definition of pipeline %s for a forwarded cancel: %v", pipelineID, err)
+		return
+	}
+
+	log.Printf("Cancelling pipeline %s as requested by another replica", pipelineID)
+	if err := ps.CancelPipeline(pipelineID, execution.UserID, definition.IsParallel); err != nil && !errors.Is(err, ErrCancelRequested) {
+		log.Printf("Forwarded cancel of pipeline %s failed: %v", pipelineID, err)
+	}
+}
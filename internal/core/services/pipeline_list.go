@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// Page sizes of pipeline listings
+const (
+	DefaultPipelinePageSize = 50
+	MaxPipelinePageSize     = 200
+)
+
+// ErrInvalidListQuery is returned for filters, sort orders or cursors a
+// listing cannot apply
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// PipelineListRequest filters, sorts and pages the pipelines of a user
+type PipelineListRequest struct {
+	UserID        string
+	Statuses      []string
+	Mode          string // "sequential", "parallel", or empty for both
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Selector      string // Label selector, e.g. "line=a,team in (x,y)"
+	SortBy        string // "created_at" (default) or "updated_at"
+	Order         string // "desc" (default) or "asc"
+	Limit         int    // DefaultPipelinePageSize when 0
+	Cursor        string // NextCursor of the previous page
+}
+
+// PipelinePage is one page of a pipeline listing; NextCursor is empty on the last page
+type PipelinePage struct {
+	Pipelines  []models.PipelineExecution `json:"pipelines"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
+
+// pipelineCursor is encoded into the opaque cursor string. It carries the sort
+// order so a cursor cannot be used with another one.
+type pipelineCursor struct {
+	SortBy     string    `json:"s"`
+	Descending bool      `json:"d"`
+	SortValue  time.Time `json:"v"`
+	PipelineID uuid.UUID `json:"id"`
+}
+
+func (c pipelineCursor) encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ListPipelines returns a page of the pipelines of a user
+func (ps *PipelineService) ListPipelines(req PipelineListRequest) (*PipelinePage, error) {
+	query, err := pipelineQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more than the page tells whether another page follows
+	limit := query.Limit
+	query.Limit++
+	pipelines, err := ps.Repository.ListPipelines(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &PipelinePage{Pipelines: pipelines}
+	if len(pipelines) > limit {
+		page.Pipelines = pipelines[:limit]
+		last := page.Pipelines[limit-1]
+		cursor := pipelineCursor{SortBy: query.SortBy, Descending: query.Descending, SortValue: last.CreatedAt, PipelineID: last.PipelineID}
+		if query.SortBy == ports.SortByUpdatedAt {
+			cursor.SortValue = last.UpdatedAt
+		}
+		if page.NextCursor, err = cursor.encode(); err != nil {
+			return nil, err
+		}
+	}
+	if page.Pipelines == nil {
+		page.Pipelines = []models.PipelineExecution{}
+	}
+	return page, nil
+}
+
+// pipelineQuery validates a list request and fills in its defaults
+func pipelineQuery(req PipelineListRequest) (ports.PipelineQuery, error) {
+	query := ports.PipelineQuery{
+		UserID:        req.UserID,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		SortBy:        req.SortBy,
+		Limit:         req.Limit,
+	}
+	if query.UserID == "" {
+		return query, fmt.Errorf("%w: user ID is required", ErrInvalidListQuery)
+	}
+
+	for _, s := range req.Statuses {
+		if !knownStatus(s) {
+			return query, fmt.Errorf("%w: unknown status %q", ErrInvalidListQuery, s)
+		}
+		query.Statuses = append(query.Statuses, s)
+	}
+
+	switch req.Mode {
+	case "":
+	case "sequential", "parallel":
+		isParallel := req.Mode == "parallel"
+		query.IsParallel = &isParallel
+	default:
+		return query, fmt.Errorf("%w: mode must be sequential or parallel", ErrInvalidListQuery)
+	}
+
+	selector, err := domain.ParseSelector(req.Selector)
+	if err != nil {
+		return query, fmt.Errorf("%w: %v", ErrInvalidListQuery, err)
+	}
+	query.Selector = selector
+
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedAfter.Before(*query.CreatedBefore) {
+		return query, fmt.Errorf("%w: created_after must be before created_before", ErrInvalidListQuery)
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = ports.SortByCreatedAt
+	case ports.SortByCreatedAt, ports.SortByUpdatedAt:
+	default:
+		return query, fmt.Errorf("%w: sort must be created_at or updated_at", ErrInvalidListQuery)
+	}
+	switch req.Order {
+	case "", "desc":
+		query.Descending = true
+	case "asc":
+	default:
+		return query, fmt.Errorf("%w: order must be asc or desc", ErrInvalidListQuery)
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = DefaultPipelinePageSize
+	case query.Limit < 0 || query.Limit > MaxPipelinePageSize:
+		return query, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListQuery, MaxPipelinePageSize)
+	}
+
+	if req.Cursor != "" {
+		var cursor pipelineCursor
+		data, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+		if err == nil {
+			err = json.Unmarshal(data, &cursor)
+		}
+		if err != nil {
+			return query, fmt.Errorf("%w: malformed cursor", ErrInvalidListQuery)
+		}
+		if cursor.SortBy != query.SortBy || cursor.Descending != query.Descending {
+			return query, fmt.Errorf("%w: cursor belongs to another sort order", ErrInvalidListQuery)
+		}
+		query.After = &ports.PipelineCursor{SortValue: cursor.SortValue, PipelineID: cursor.PipelineID}
+	}
+	return query, nil
+}
+
+func knownStatus(s string) bool {
+	for _, status := range domain.Statuses {
+		if string(status) == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+func TestPipelineQuery(t *testing.T) {
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		req     PipelineListRequest
+		check   func(t *testing.T, query ports.PipelineQuery)
+		wantErr bool
+	}{
+		{name: "defaults", req: PipelineListRequest{UserID: "u"}, check: func(t *testing.T, query ports.PipelineQuery) {
+			if query.SortBy != ports.SortByCreatedAt || !query.Descending || query.Limit != DefaultPipelinePageSize {
+				t.Errorf("got sort %q descending %v limit %d, want created_at, descending, %d", query.SortBy, query.Descending, query.Limit, DefaultPipelinePageSize)
+			}
+			if query.IsParallel != nil || query.After != nil || query.Selector != nil {
+				t.Errorf("got filters %+v, want none", query)
+			}
+		}},
+		{name: "filters", req: PipelineListRequest{UserID: "u", Statuses: []string{"Failed", "Cancelled"}, Mode: "parallel", Selector: "line=a",
+			SortBy: ports.SortByUpdatedAt, Order: "asc", Limit: MaxPipelinePageSize, CreatedAfter: &after, CreatedBefore: &before},
+			check: func(t *testing.T, query ports.PipelineQuery) {
+				if len(query.Statuses) != 2 || query.IsParallel == nil || !*query.IsParallel || len(query.Selector) != 1 {
+					t.Errorf("got filters %+v", query)
+				}
+				if query.SortBy != ports.SortByUpdatedAt || query.Descending || query.Limit != MaxPipelinePageSize {
+					t.Errorf("got sort %q descending %v limit %d", query.SortBy, query.Descending, query.Limit)
+				}
+			}},
+		{name: "sequential", req: PipelineListRequest{UserID: "u", Mode: "sequential"}, check: func(t *testing.T, query ports.PipelineQuery) {
+			if query.IsParallel == nil || *query.IsParallel {
+				t.Errorf("got IsParallel %v, want false", query.IsParallel)
+			}
+		}},
+		{name: "no user", req: PipelineListRequest{}, wantErr: true},
+		{name: "unknown status", req: PipelineListRequest{UserID: "u", Statuses: []string{"Failed to Cancel"}}, wantErr: true},
+		{name: "unknown mode", req: PipelineListRequest{UserID: "u", Mode: "batch"}, wantErr: true},
+		{name: "invalid selector", req: PipelineListRequest{UserID: "u", Selector: "line in ()"}, wantErr: true},
+		{name: "empty time range", req: PipelineListRequest{UserID: "u", CreatedAfter: &before, CreatedBefore: &after}, wantErr: true},
+		{name: "unknown sort", req: PipelineListRequest{UserID: "u", SortBy: "name"}, wantErr: true},
+		{name: "unknown order", req: PipelineListRequest{UserID: "u", Order: "up"}, wantErr: true},
+		{name: "negative limit", req: PipelineListRequest{UserID: "u", Limit: -1}, wantErr: true},
+		{name: "limit too large", req: PipelineListRequest{UserID: "u", Limit: MaxPipelinePageSize + 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := pipelineQuery(tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidListQuery) {
+					t.Fatalf("pipelineQuery() error = %v, want ErrInvalidListQuery", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pipelineQuery() failed: %v", err)
+			}
+			tt.check(t, query)
+		})
+	}
+}
+
+func TestPipelineCursor(t *testing.T) {
+	position := pipelineCursor{
+		SortBy:     ports.SortByUpdatedAt,
+		Descending: true,
+		SortValue:  time.Date(2025, 3, 4, 5, 6, 7, 8, time.UTC),
+		PipelineID: uuid.New(),
+	}
+	cursor, err := position.encode()
+	if err != nil {
+		t.Fatalf("encode() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		req     PipelineListRequest
+		wantErr bool
+	}{
+		{name: "same sort order", req: PipelineListRequest{UserID: "u", SortBy: ports.SortByUpdatedAt, Order: "desc", Cursor: cursor}},
+		{name: "other sort column", req: PipelineListRequest{UserID: "u", SortBy: ports.SortByCreatedAt, Cursor: cursor}, wantErr: true},
+		{name: "other direction", req: PipelineListRequest{UserID: "u", SortBy: ports.SortByUpdatedAt, Order: "asc", Cursor: cursor}, wantErr: true},
+		{name: "not base64", req: PipelineListRequest{UserID: "u", Cursor: "not a cursor!"}, wantErr: true},
+		{name: "not json", req: PipelineListRequest{UserID: "u", Cursor: base64.RawURLEncoding.EncodeToString([]byte("page=2"))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := pipelineQuery(tt.req)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidListQuery) {
+					t.Fatalf("pipelineQuery() error = %v, want ErrInvalidListQuery", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pipelineQuery() failed: %v", err)
+			}
+			if query.After == nil || !query.After.SortValue.Equal(position.SortValue) || query.After.PipelineID != position.PipelineID {
+				t.Errorf("After = %+v, want %v / %v", query.After, position.SortValue, position.PipelineID)
+			}
+		})
+	}
+}
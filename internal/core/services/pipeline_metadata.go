@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// GetPipelineMetadata returns the labels and annotations of a pipeline
+func (ps *PipelineService) GetPipelineMetadata(pipelineID uuid.UUID) (*domain.PipelineMetadata, error) {
+	execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+	if err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	return pipelineMetadata(execution)
+}
+
+// UpdatePipelineMetadata sets and removes labels and annotations of a pipeline;
+// keys the patches leave out keep their values
+func (ps *PipelineService) UpdatePipelineMetadata(pipelineID uuid.UUID, labels ports.MetadataPatch, annotations ports.MetadataPatch) (*domain.PipelineMetadata, error) {
+	meta, err := ps.GetPipelineMetadata(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	patched := domain.PipelineMetadata{
+		Labels:      domain.ApplyMetadataPatch(meta.Labels, labels),
+		Annotations: domain.ApplyMetadataPatch(meta.Annotations, annotations),
+	}
+	if err := patched.Validate(); err != nil {
+		return nil, err
+	}
+	if labels.Empty() && annotations.Empty() {
+		return meta, nil
+	}
+
+	if err := ps.Repository.UpdatePipelineMetadata(pipelineID, labels, annotations); err != nil {
+		return nil, err
+	}
+	return ps.GetPipelineMetadata(pipelineID)
+}
+
+func pipelineMetadata(execution *models.PipelineExecution) (*domain.PipelineMetadata, error) {
+	meta := &domain.PipelineMetadata{Labels: map[string]string{}, Annotations: map[string]string{}}
+	if err := execution.Labels.Unmarshal(&meta.Labels); err != nil {
+		return nil, err
+	}
+	if err := execution.Annotations.Unmarshal(&meta.Annotations); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// metadataJSONB stores labels or annotations, as an empty object when there are none
+func metadataJSONB(values map[string]string) (models.JSONB, error) {
+	if values == nil {
+		values = map[string]string{}
+	}
+	return models.NewJSONB(values)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/google/uuid"
@@ -14,40 +15,110 @@ import (
 	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils" // ✅ Import SSEManager
 )
 
+// ErrPipelineNotFound is returned for commands on a pipeline that does not
+// exist, or not in the requested mode
+var ErrPipelineNotFound = errors.New("pipeline not found")
+
+// ErrPipelineCancelled ends a run that CancelPipeline stopped
+var ErrPipelineCancelled = errors.New("pipeline cancelled")
+
+// ErrCancelRequested reports that CancelPipeline stopped a running pipeline;
+// its run ends Cancelled once the stages return, or Completed if the last one
+// had finished already
+var ErrCancelRequested = errors.New("cancellation requested, the run stops once its stages return")
+
+// cancellation is the cause a cancelled run is stopped with, naming who
+// cancelled it
+type cancellation struct {
+	actor  string
+	reason string
+}
+
+func (c *cancellation) Error() string { return c.reason }
+func (c *cancellation) Unwrap() error { return ErrPipelineCancelled }
+
 type PipelineService struct {
 	SequentialOrchestrators map[uuid.UUID]*domain.SequentialPipelineOrchestrator
 	ParallelOrchestrators   map[uuid.UUID]*domain.ParallelPipelineOrchestrator
 	Repository             ports.PipelineRepository
+	Calendars              ports.CalendarRepository
+	Queue                  *WorkQueue
+	Executor               domain.StageExecutor // Runs the stages of new orchestrators; in-process by default
+	Instance               string               // Owner recorded on the runs and leases of this replica, see RecoverRuns
 	mu                     sync.RWMutex
+	leases                 map[uuid.UUID]*heldLease // Pipelines this replica holds the lease of, see MaintainLeases
+	leaseMu                sync.Mutex
+	OnRunEnded             func(runID uuid.UUID, status domain.Status) // Called once a run completed, failed or was cancelled, see TriggerService
 	SSE                    *utils.SSEManager // ✅ Add SSEManager
 }
 
 // func NewPipelineService(repo ports.PipelineRepository) *PipelineService {
-func NewPipelineService(repo ports.PipelineRepository, sse *utils.SSEManager) *PipelineService {
-	return &PipelineService{
+func NewPipelineService(repo ports.PipelineRepository, calendars ports.CalendarRepository, sse *utils.SSEManager) *PipelineService {
+	ps := &PipelineService{
 		SequentialOrchestrators: make(map[uuid.UUID]*domain.SequentialPipelineOrchestrator),
 		ParallelOrchestrators:   make(map[uuid.UUID]*domain.ParallelPipelineOrchestrator),
 		Repository:             repo,
+		Calendars:              calendars,
+		Executor:               domain.LocalExecutor{},
 		SSE:                    sse,
+		leases:                 make(map[uuid.UUID]*heldLease),
 	}
+	ps.Queue = NewWorkQueue(MaxConcurrencyFromEnv(), ps.runWorkOrder)
+	return ps
 }
 
-func (ps *PipelineService) CreatePipeline(userID uuid.UUID, stageCount int, isParallel bool) (uuid.UUID, error) {
-	pipelineID := uuid.New()
-
-	ps.mu.Lock()
-	var orchestrator domain.PipelineOrchestrator
-	if isParallel {
-		orchestrator = domain.NewParallelPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
-		ps.ParallelOrchestrators[pipelineID] = orchestrator.(*domain.ParallelPipelineOrchestrator)
+// CreatePipeline creates a pipeline of stageCount default stages, or of one
+// simulated stage per spec when specs are given, with its labels and annotations
+func (ps *PipelineService) CreatePipeline(userID uuid.UUID, stageCount int, isParallel bool, specs []domain.StageSpec, meta domain.PipelineMetadata) (uuid.UUID, error) {
+	var stages []domain.Stage
+	if len(specs) > 0 {
+		def := domain.PipelineDefinition{IsParallel: isParallel, Stages: specs}.WithDefaults()
+		if err := def.Validate(); err != nil {
+			return uuid.Nil, err
+		}
+		for _, spec := range def.Stages {
+			stages = append(stages, domain.NewSimulatedStage(spec))
+		}
 	} else {
-		orchestrator = domain.NewSequentialPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
-		ps.SequentialOrchestrators[pipelineID] = orchestrator.(*domain.SequentialPipelineOrchestrator)
+		for i := 0; i < stageCount; i++ {
+			stage := domain.NewBaseStage()
+			stage.Spec.Name = domain.DefaultStageName(i)
+			stages = append(stages, stage)
+		}
+	}
+	return ps.createPipeline(userID, stages, isParallel, nil, meta)
+}
+
+// CreatePipelineFromTemplate creates a pipeline from a template definition,
+// with overrides already applied, and records the template version it came from
+func (ps *PipelineService) CreatePipelineFromTemplate(userID uuid.UUID, def domain.PipelineDefinition, templateID uuid.UUID) (uuid.UUID, error) {
+	if err := def.Validate(); err != nil {
+		return uuid.Nil, err
+	}
+	var stages []domain.Stage
+	for _, spec := range def.Stages {
+		stages = append(stages, domain.NewSimulatedStage(spec))
+	}
+	return ps.createPipeline(userID, stages, def.IsParallel, &templateID, domain.PipelineMetadata{})
+}
+
+func (ps *PipelineService) createPipeline(userID uuid.UUID, stages []domain.Stage, isParallel bool, templateID *uuid.UUID, meta domain.PipelineMetadata) (uuid.UUID, error) {
+	if err := meta.Validate(); err != nil {
+		return uuid.Nil, err
+	}
+	labels, err := metadataJSONB(meta.Labels)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	annotations, err := metadataJSONB(meta.Annotations)
+	if err != nil {
+		return uuid.Nil, err
 	}
-	ps.mu.Unlock()
 
-	for i := 0; i < stageCount; i++ {
-		stage := domain.NewBaseStage()
+	pipelineID := uuid.New()
+	orchestrator := ps.newOrchestrator(pipelineID, isParallel)
+
+	for _, stage := range stages {
 		if err := orchestrator.AddStage(stage); err != nil {
 			return uuid.Nil, err
 		}
@@ -57,82 +128,397 @@ func (ps *PipelineService) CreatePipeline(userID uuid.UUID, stageCount int, isPa
 	ps.SSE.BroadcastUpdate(map[string]interface{}{
 		"type":        "pipeline",
 		"pipeline_id": pipelineID.String(),
-		"status":      "Created",
+		"status":      domain.StatusCreated,
 	})
 
 
-	err := ps.Repository.SavePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		UserID:     userID,
-		Status:     "Created",
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+	err = ps.Repository.SavePipelineExecution(&models.PipelineExecution{
+		PipelineID:  pipelineID,
+		UserID:      userID,
+		Status:      string(domain.StatusCreated),
+		TemplateID:  templateID,
+		Labels:      labels,
+		Annotations: annotations,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		Definition:  newDefinitionRecord(pipelineID, isParallel, stages),
 	})
 	if err != nil {
 		return uuid.Nil, err
 	}
+	ps.recordTransition(pipelineID, nil, "", domain.StatusCreated, domain.UserActor(userID), "")
 
 	return pipelineID, nil
 }
 
-// ✅ Start pipeline execution based on pipeline ID
-func (ps *PipelineService) StartPipeline(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, input interface{}, isParallel bool) error {
+// newDefinitionRecord captures the mode and ordered stages of a pipeline
+func newDefinitionRecord(pipelineID uuid.UUID, isParallel bool, stages []domain.Stage) *models.PipelineDefinition {
+	definition := &models.PipelineDefinition{PipelineID: pipelineID, IsParallel: isParallel, CreatedAt: time.Now()}
+	for i, stage := range stages {
+		spec := stage.GetSpec()
+		definition.Stages = append(definition.Stages, models.PipelineStage{
+			PipelineID:    pipelineID,
+			Position:      i,
+			StageID:       stage.GetID(),
+			Name:          spec.Name,
+			Type:          spec.Type,
+			MeanSeconds:   spec.MeanSeconds,
+			StdDevSeconds: spec.StdDevSeconds,
+			Yield:         spec.Yield,
+			CalendarID:    spec.CalendarID,
+		})
+	}
+	return definition
+}
+
+// orchestrator returns the orchestrator of a pipeline in the given mode. After
+// a restart the maps are empty, so a missing orchestrator is rebuilt from the
+// stored definition; nil means the pipeline does not exist in that mode.
+func (ps *PipelineService) orchestrator(pipelineID uuid.UUID, isParallel bool) domain.PipelineOrchestrator {
+	if orchestrator := ps.cachedOrchestrator(pipelineID, isParallel); orchestrator != nil {
+		return orchestrator
+	}
+	if err := ps.rehydrate(pipelineID); err != nil {
+		log.Printf("Failed to rebuild orchestrator for pipeline %s: %v", pipelineID, err)
+		return nil
+	}
+	return ps.cachedOrchestrator(pipelineID, isParallel)
+}
+
+func (ps *PipelineService) cachedOrchestrator(pipelineID uuid.UUID, isParallel bool) domain.PipelineOrchestrator {
 	ps.mu.RLock()
-	var orchestrator domain.PipelineOrchestrator
+	defer ps.mu.RUnlock()
+
 	if isParallel {
-		orchestrator = ps.ParallelOrchestrators[pipelineID]
+		if o, ok := ps.ParallelOrchestrators[pipelineID]; ok {
+			return o
+		}
+	} else if o, ok := ps.SequentialOrchestrators[pipelineID]; ok {
+		return o
+	}
+	return nil
+}
+
+// rehydrate rebuilds the orchestrator of a pipeline from its stored definition,
+// keeping the stage IDs so logs and events still match
+func (ps *PipelineService) rehydrate(pipelineID uuid.UUID) error {
+	definition, err := ps.Repository.GetPipelineDefinition(pipelineID)
+	if err != nil {
+		return errors.New("pipeline definition not found")
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	// Another request may have rebuilt it meanwhile
+	if _, ok := ps.SequentialOrchestrators[pipelineID]; ok {
+		return nil
+	}
+	if _, ok := ps.ParallelOrchestrators[pipelineID]; ok {
+		return nil
+	}
+
+	var orchestrator domain.PipelineOrchestrator
+	if definition.IsParallel {
+		o := domain.NewParallelPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
+		o.Executor = ps.Executor
+		ps.ParallelOrchestrators[pipelineID] = o
+		orchestrator = o
 	} else {
-		orchestrator = ps.SequentialOrchestrators[pipelineID]
+		o := domain.NewSequentialPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
+		o.Executor = ps.Executor
+		ps.SequentialOrchestrators[pipelineID] = o
+		orchestrator = o
 	}
-	ps.mu.RUnlock()
+	for _, stage := range definition.Stages {
+		if err := orchestrator.AddStage(&domain.BaseStage{
+			ID: stage.StageID,
+			Spec: domain.StageSpec{
+				Name:          stage.Name,
+				MeanSeconds:   stage.MeanSeconds,
+				StdDevSeconds: stage.StdDevSeconds,
+				Yield:         stage.Yield,
+				Type:          stage.Type,
+				CalendarID:    stage.CalendarID,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Rebuilt %d-stage orchestrator for pipeline %s from its stored definition", len(definition.Stages), pipelineID)
+	return nil
+}
+
+// newOrchestrator creates an empty orchestrator and registers it under pipelineID
+func (ps *PipelineService) newOrchestrator(pipelineID uuid.UUID, isParallel bool) domain.PipelineOrchestrator {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if isParallel {
+		orchestrator := domain.NewParallelPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
+		orchestrator.Executor = ps.Executor
+		ps.ParallelOrchestrators[pipelineID] = orchestrator
+		return orchestrator
+	}
+	orchestrator := domain.NewSequentialPipelineOrchestrator(pipelineID, ps.Repository, ps.SSE)
+	orchestrator.Executor = ps.Executor
+	ps.SequentialOrchestrators[pipelineID] = orchestrator
+	return orchestrator
+}
+
+// ResolveSeed returns the requested seed, or a fresh one when none was given
+func ResolveSeed(seed *int64) int64 {
+	if seed != nil {
+		return *seed
+	}
+	return time.Now().UnixNano()
+}
+
+// startPipeline executes a run of a pipeline, skipping the stages in skip that
+// an interrupted attempt already completed. A run that cannot start fails.
+func (ps *PipelineService) startPipeline(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, runID uuid.UUID, input interface{}, isParallel bool, seed int64, skip []int) error {
+	orchestrator, run, err := ps.prepareRun(pipelineID, runID, input, isParallel, seed, skip)
+	if err != nil {
+		_ = ps.updateRun(pipelineID, runID, domain.StatusFailed, ps.actor(), err.Error(), nil)
+		_ = ps.setPipelineStatus(pipelineID, domain.StatusFailed, ps.actor(), err.Error())
+		return err
+	}
+	return ps.execute(ctx, userID, pipelineID, orchestrator, input, run)
+}
 
+// prepareRun checks a run can start and records its configuration
+func (ps *PipelineService) prepareRun(pipelineID uuid.UUID, runID uuid.UUID, input interface{}, isParallel bool, seed int64, skip []int) (domain.PipelineOrchestrator, *domain.RunContext, error) {
+	orchestrator := ps.orchestrator(pipelineID, isParallel)
 	if orchestrator == nil {
-		return errors.New("orchestrator not initialized for this pipeline")
+		return nil, nil, errors.New("orchestrator not initialized for this pipeline")
 	}
 
 	status, err := ps.Repository.GetPipelineStatus(pipelineID.String())
 	if err != nil {
 		log.Printf("Failed to get pipeline status: %v", err)
-		return err
+		return nil, nil, err
 	}
-	if status != "Created" && status != "Paused" {
-		return errors.New("invalid pipeline status: " + status)
+	// Queued runs were admitted by EnqueuePipeline
+	if err := domain.PipelineStates.Validate(domain.Status(status), domain.StatusRunning); err != nil {
+		return nil, nil, err
 	}
 	// 🚀 **Fix: Ensure stages are present before execution**
 	switch o := orchestrator.(type) {
 	case *domain.SequentialPipelineOrchestrator:
 		if len(o.Stages) == 0 {
-			return errors.New("no stages found for this pipeline execution")
+			return nil, nil, errors.New("no stages found for this pipeline execution")
 		}
 	case *domain.ParallelPipelineOrchestrator:
 		if len(o.Stages) == 0 {
-			return errors.New("no stages found for this pipeline execution")
+			return nil, nil, errors.New("no stages found for this pipeline execution")
 		}
 	default:
-		return errors.New("unknown orchestrator type")
+		return nil, nil, errors.New("unknown orchestrator type")
 	}
 
+	run := domain.NewRunContext(seed, nil)
+	run.RunID = runID
+	if len(skip) > 0 {
+		outputs, err := ps.completedOutputs(runID, orchestratorStages(orchestrator))
+		if err != nil {
+			log.Printf("Failed to load outputs of completed stages: %v", err)
+			return nil, nil, err
+		}
+		run.Completed = make(map[int]bool, len(skip))
+		run.Outputs = make(map[int]interface{}, len(skip))
+		for _, i := range skip {
+			output, ok := outputs[i]
+			if !ok {
+				return nil, nil, fmt.Errorf("output of completed stage %d was not stored", i)
+			}
+			run.Completed[i] = true
+			run.Outputs[i] = output
+		}
+	}
+	if run.Attempts, err = ps.stageAttempts(runID, orchestratorStages(orchestrator)); err != nil {
+		log.Printf("Failed to load earlier attempts: %v", err)
+		return nil, nil, err
+	}
+	if err := ps.applyCalendars(pipelineID, orchestratorStages(orchestrator), run); err != nil {
+		log.Printf("Failed to load calendars: %v", err)
+		return nil, nil, err
+	}
+	if err := ps.recordRun(pipelineID, isParallel, orchestratorStages(orchestrator), input, run); err != nil {
+		log.Printf("Failed to record run configuration: %v", err)
+		return nil, nil, err
+	}
+	return orchestrator, run, nil
+}
+
+// EnqueuePipeline validates a start request and queues it as a work order for
+// a new run, whose ID it sets on the order; it returns the order's position, or
+// 0 if it started right away
+func (ps *PipelineService) EnqueuePipeline(order *domain.WorkOrder) (int, error) {
+	if _, err := ps.Repository.GetPipelineExecution(order.PipelineID); err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return 0, ErrPipelineNotFound
+		}
+		return 0, err
+	}
+	orchestrator := ps.orchestrator(order.PipelineID, order.IsParallel)
+	if orchestrator == nil {
+		mode := "sequential"
+		if order.IsParallel {
+			mode = "parallel"
+		}
+		return 0, fmt.Errorf("%w as a %s pipeline", ErrPipelineNotFound, mode)
+	}
+	stages := orchestratorStages(orchestrator)
+	if len(stages) == 0 {
+		return 0, errors.New("no stages found for this pipeline execution")
+	}
+
+	// Only the replica holding the lease queues and runs the pipeline
+	taken, err := ps.acquireLease(order.PipelineID)
+	if err != nil {
+		return 0, err
+	}
+
+	actor := order.Actor
+	if actor == "" {
+		actor = domain.UserActor(order.UserID)
+	}
+	run, err := ps.newRun(order.PipelineID, order.UserID, order.Input, order.Trigger, actor)
+	if err != nil {
+		// Only the call that took the lease gives it up, and not when a
+		// concurrent start on this replica queued a run in the meantime
+		if taken && !ps.hasActiveRun(order.PipelineID) {
+			ps.releaseLease(order.PipelineID)
+		}
+		return 0, err
+	}
+	order.RunID = run.RunID
+
+	order.ProcessingSeconds = domain.PipelineDefinition{IsParallel: order.IsParallel, Stages: stageSpecs(stages)}.ExpectedSeconds()
+	if err := ps.Repository.SetPipelineOwner(order.PipelineID, ps.Instance); err != nil {
+		log.Printf("Failed to record owner of pipeline %s: %v", order.PipelineID, err)
+	}
+
+	// 🔹 Broadcast queueing via SSE
+	ps.SSE.BroadcastUpdate(map[string]interface{}{
+		"type":        "pipeline",
+		"pipeline_id": order.PipelineID.String(),
+		"run_id":      run.RunID.String(),
+		"status":      domain.StatusQueued,
+	})
+
+	position, err := ps.Queue.Enqueue(order)
+	if err != nil {
+		_ = ps.updateRun(order.PipelineID, run.RunID, domain.StatusFailed, actor, err.Error(), nil)
+		_ = ps.setPipelineStatus(order.PipelineID, domain.StatusFailed, actor, err.Error())
+		ps.releaseLease(order.PipelineID)
+		return 0, err
+	}
+	log.Printf("Queued run %d of pipeline %s at position %d", run.Number, order.PipelineID, position)
+	return position, nil
+}
+
+// runWorkOrder executes a dispatched work order and then gives up its lease
+func (ps *PipelineService) runWorkOrder(order *domain.WorkOrder) {
+	defer ps.releaseLease(order.PipelineID)
+	ctx, cancel := ps.leaseContext(context.Background(), order.PipelineID)
+	defer cancel()
+
+	err := ps.startPipeline(ctx, order.UserID, order.PipelineID, order.RunID, order.Input, order.IsParallel, order.Seed, order.SkipStages)
+	if order.OnDone != nil {
+		order.OnDone(err)
+	}
+}
+
+// GetQueue returns the work-order queue in dispatch order
+func (ps *PipelineService) GetQueue() QueueSnapshot {
+	return ps.Queue.Snapshot()
+}
+
+// GetUserQueue returns the orders of one user in the work-order queue, at
+// their positions in the whole queue
+func (ps *PipelineService) GetUserQueue(userID uuid.UUID) QueueSnapshot {
+	queue := ps.Queue.Snapshot()
+	orders := make([]QueuedOrder, 0, len(queue.Orders))
+	for _, order := range queue.Orders {
+		if order.UserID == userID {
+			orders = append(orders, order)
+		}
+	}
+	queue.Orders = orders
+	return queue
+}
+
+// SetDispatchRule switches the rule that orders the work-order queue
+func (ps *PipelineService) SetDispatchRule(name string) error {
+	return ps.Queue.SetRule(name)
+}
+
+// UpdateWorkOrder reprioritizes or moves a queued pipeline; pipelines queued
+// by another replica can only be changed there
+func (ps *PipelineService) UpdateWorkOrder(pipelineID uuid.UUID, update WorkOrderUpdate) (*QueuedOrder, error) {
+	order, err := ps.Queue.Update(pipelineID, update)
+	if err != nil {
+		if owner := ps.leaseHolder(pipelineID); owner != "" {
+			return nil, fmt.Errorf("%w (%s)", ErrLeaseHeld, owner)
+		}
+	}
+	return order, err
+}
+
+// execute runs the orchestrator and persists the outcome of the run, its
+// output and the recorded event sequence
+func (ps *PipelineService) execute(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID, orchestrator domain.PipelineOrchestrator, input interface{}, run *domain.RunContext) error {
+	defer ps.saveEvents(pipelineID, run)
+
 	// 🔹 Broadcast pipeline start via SSE
 	ps.SSE.BroadcastUpdate(map[string]interface{}{
 		"type":        "pipeline",
 		"pipeline_id": pipelineID.String(),
-		"status":      "Running",
+		"run_id":      run.RunID.String(),
+		"status":      domain.StatusRunning,
 	})
 
-	if err := ps.updatePipelineStatus(pipelineID, "Running"); err != nil {
+	if err := ps.updateRun(pipelineID, run.RunID, domain.StatusRunning, ps.actor(), "", nil); err != nil {
+		return err
+	}
+	if err := ps.setPipelineStatus(pipelineID, domain.StatusRunning, ps.actor(), ""); err != nil {
 		return err
 	}
 
-	stageID, _, err := orchestrator.Execute(ctx, userID, pipelineID, input)
+	stageID, output, err := orchestrator.Execute(ctx, userID, pipelineID, input, run)
 	if err != nil {
-		_ = ps.updatePipelineStatus(pipelineID, "Failed")
-		ps.logExecutionError(pipelineID, stageID, err.Error())
+		// A run stopped by CancelPipeline ends as cancelled, and one whose
+		// lease was lost is left to the replica that took it over
+		var cancelled *cancellation
+		switch cause := context.Cause(ctx); {
+		case errors.Is(cause, errLeaseLost):
+			return cause
+		case errors.As(cause, &cancelled):
+			_ = ps.updateRun(pipelineID, run.RunID, domain.StatusCancelled, cancelled.actor, cancelled.reason, nil)
+			_ = ps.setPipelineStatus(pipelineID, domain.StatusCancelled, cancelled.actor, cancelled.reason)
+
+			// 🔹 Broadcast pipeline cancellation via SSE
+			ps.SSE.BroadcastUpdate(map[string]interface{}{
+				"type":        "pipeline",
+				"pipeline_id": pipelineID.String(),
+				"run_id":      run.RunID.String(),
+				"status":      domain.StatusCancelled,
+			})
+			return cause
+		}
+
+		_ = ps.updateRun(pipelineID, run.RunID, domain.StatusFailed, ps.actor(), err.Error(), nil)
+		_ = ps.setPipelineStatus(pipelineID, domain.StatusFailed, ps.actor(), err.Error())
+		ps.logExecutionError(pipelineID, run.RunID, stageID, err.Error())
 
 		// 🔹 Broadcast pipeline failure via SSE
 		ps.SSE.BroadcastUpdate(map[string]interface{}{
 			"type":        "pipeline",
 			"pipeline_id": pipelineID.String(),
-			"status":      "Failed",
+			"run_id":      run.RunID.String(),
+			"status":      domain.StatusFailed,
 		})
 		return err
 	}
@@ -141,23 +527,25 @@ func (ps *PipelineService) StartPipeline(ctx context.Context, userID uuid.UUID,
 	ps.SSE.BroadcastUpdate(map[string]interface{}{
 		"type":        "pipeline",
 		"pipeline_id": pipelineID.String(),
-		"status":      "Completed",
+		"run_id":      run.RunID.String(),
+		"status":      domain.StatusCompleted,
 	})
 
-	return ps.updatePipelineStatus(pipelineID, "Completed")
+	updates := make(map[string]interface{})
+	if outputJSON, err := models.NewJSONB(output); err == nil {
+		updates["output"] = outputJSON
+	} else {
+		log.Printf("Failed to store output of run %s: %v", run.RunID, err)
+	}
+	if err := ps.updateRun(pipelineID, run.RunID, domain.StatusCompleted, ps.actor(), "", updates); err != nil {
+		return err
+	}
+	return ps.setPipelineStatus(pipelineID, domain.StatusCompleted, ps.actor(), "")
 }
 
 // ✅ Retrieve pipeline status
 func (ps *PipelineService) GetPipelineStatus(pipelineID uuid.UUID, isParallel bool) (string, error) {
-	ps.mu.RLock()
-	var orchestrator domain.PipelineOrchestrator
-	if isParallel {
-		orchestrator = ps.ParallelOrchestrators[pipelineID]
-	} else {
-		orchestrator = ps.SequentialOrchestrators[pipelineID]
-	}
-	ps.mu.RUnlock()
-
+	orchestrator := ps.orchestrator(pipelineID, isParallel)
 	if orchestrator == nil {
 		return "", errors.New("orchestrator not found for pipeline")
 	}
@@ -167,52 +555,62 @@ func (ps *PipelineService) GetPipelineStatus(pipelineID uuid.UUID, isParallel bo
 
 // ✅ Cancel pipeline execution
 func (ps *PipelineService) CancelPipeline(pipelineID uuid.UUID, userID uuid.UUID, isParallel bool) error {
-	ps.mu.RLock()
-	var orchestrator domain.PipelineOrchestrator
-	if isParallel {
-		orchestrator = ps.ParallelOrchestrators[pipelineID]
-	} else {
-		orchestrator = ps.SequentialOrchestrators[pipelineID]
-	}
-	ps.mu.RUnlock()
-
+	orchestrator := ps.orchestrator(pipelineID, isParallel)
 	if orchestrator == nil {
 		log.Printf("Orchestrator not found for pipeline: %s", pipelineID)
 		return errors.New("orchestrator not initialized for this pipeline")
 	}
 
+	// The replica executing the pipeline cancels it on its next lease renewal
+	if owner := ps.leaseHolder(pipelineID); owner != "" {
+		forwarded, err := ps.Repository.RequestPipelineCancel(pipelineID)
+		if err != nil {
+			return err
+		}
+		if forwarded {
+			log.Printf("Forwarded cancel of pipeline %s to replica %s", pipelineID, owner)
+			return fmt.Errorf("%w (%s)", ErrCancelForwarded, owner)
+		}
+	}
+
 	log.Printf("Cancelling pipeline: %s by user: %s", pipelineID, userID)
 
 	err := orchestrator.Cancel(pipelineID, userID)
 	if err != nil {
 		log.Printf("Failed to cancel pipeline: %v", err)
-		_ = ps.updatePipelineStatus(pipelineID, "Failed to Cancel")
 		return err
 	}
+	actor := domain.UserActor(userID)
+	reason := "Cancelled by user " + userID.String()
+
+	// A queued pipeline never gets to run, so its run ends here; a running one
+	// is stopped and execute records the cancellation once its stages return
+	if ps.Queue.Remove(pipelineID) {
+		log.Printf("Removed pipeline %s from the work-order queue", pipelineID)
+		ps.releaseLease(pipelineID)
+	} else if ps.stopRun(pipelineID, &cancellation{actor: actor, reason: reason}) {
+		log.Printf("Stopping the run of pipeline %s", pipelineID)
+		return ErrCancelRequested
+	}
+	ps.endActiveRun(pipelineID, domain.StatusCancelled, actor, reason)
 
 	// 🔹 Broadcast pipeline cancellation via SSE
 	ps.SSE.BroadcastUpdate(map[string]interface{}{
 		"type":        "pipeline",
 		"pipeline_id": pipelineID.String(),
-		"status":      "Cancelled",
+		"status":      domain.StatusCancelled,
 	})
 
-	return ps.updatePipelineStatus(pipelineID, "Cancelled")
+	return ps.setPipelineStatus(pipelineID, domain.StatusCancelled, actor, reason)
 }
 
-func (ps *PipelineService) updatePipelineStatus(pipelineID uuid.UUID, status string) error {
-	return ps.Repository.UpdatePipelineExecution(&models.PipelineExecution{
-		PipelineID: pipelineID,
-		Status:     status,
-		UpdatedAt:  time.Now(),
-	})
-}
-
-func (ps *PipelineService) logExecutionError(pipelineID uuid.UUID, stageID uuid.UUID, errorMsg string) {
+func (ps *PipelineService) logExecutionError(pipelineID uuid.UUID, runID uuid.UUID, stageID uuid.UUID, errorMsg string) {
 	logErr := ps.Repository.SaveExecutionLog(&models.ExecutionLog{
 		StageID:    stageID,
 		PipelineID: pipelineID,
-		Status:     "Error",
+		RunID:      runID,
+		StageIndex: -1,
+		Status:     domain.LogError,
 		ErrorMsg:   errorMsg,
 		Timestamp:  time.Now(),
 	})
@@ -221,11 +619,229 @@ func (ps *PipelineService) logExecutionError(pipelineID uuid.UUID, stageID uuid.
 	}
 }
 
-func (ps *PipelineService) GetPipelinesByUser(userID string) ([]models.PipelineExecution, error) {
-	return ps.Repository.GetPipelinesByUser(userID)
+// GetPipelineDefinition derives the simulation model of an existing pipeline
+func (ps *PipelineService) GetPipelineDefinition(pipelineID uuid.UUID) (domain.PipelineDefinition, error) {
+	for _, isParallel := range []bool{false, true} {
+		if o := ps.orchestrator(pipelineID, isParallel); o != nil {
+			return domain.PipelineDefinition{IsParallel: isParallel, Stages: stageSpecs(orchestratorStages(o))}, nil
+		}
+	}
+	return domain.PipelineDefinition{}, errors.New("orchestrator not found for pipeline")
 }
 
-// GetPipelineStages fetches all stages for a given pipeline
-func (ps *PipelineService) GetPipelineStages(pipelineID uuid.UUID) ([]models.ExecutionLog, error) {
-	return ps.Repository.GetPipelineStages(pipelineID)
+func orchestratorStages(orchestrator domain.PipelineOrchestrator) []domain.Stage {
+	switch o := orchestrator.(type) {
+	case *domain.SequentialPipelineOrchestrator:
+		return o.Stages
+	case *domain.ParallelPipelineOrchestrator:
+		return o.Stages
+	}
+	return nil
+}
+
+func stageSpecs(stages []domain.Stage) []domain.StageSpec {
+	specs := make([]domain.StageSpec, len(stages))
+	for i, stage := range stages {
+		specs[i] = stage.GetSpec()
+	}
+	return specs
+}
+
+// applyCalendars loads the calendar of the pipeline and of every stage that has
+// its own into the run
+func (ps *PipelineService) applyCalendars(pipelineID uuid.UUID, stages []domain.Stage, run *domain.RunContext) error {
+	execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+	if err != nil {
+		return err
+	}
+	if execution.CalendarID != nil {
+		if run.Calendar, err = LoadCalendar(ps.Calendars, *execution.CalendarID); err != nil {
+			return err
+		}
+	}
+	for i, spec := range stageSpecs(stages) {
+		if spec.CalendarID == nil {
+			continue
+		}
+		calendar, err := LoadCalendar(ps.Calendars, *spec.CalendarID)
+		if err != nil {
+			return fmt.Errorf("stage %d: %v", i+1, err)
+		}
+		if run.StageCalendars == nil {
+			run.StageCalendars = make(map[int]*domain.Calendar)
+		}
+		run.StageCalendars[i] = calendar
+	}
+	return nil
+}
+
+// recordRun stores the seed and full configuration of a run, including its
+// start time and calendars, before it starts
+func (ps *PipelineService) recordRun(pipelineID uuid.UUID, isParallel bool, stages []domain.Stage, input interface{}, run *domain.RunContext) error {
+	config, err := models.NewJSONB(domain.RunConfig{
+		IsParallel:     isParallel,
+		Stages:         stageSpecs(stages),
+		Input:          input,
+		StartedAt:      run.Start,
+		Calendar:       run.Calendar,
+		StageCalendars: run.StageCalendars,
+	})
+	if err != nil {
+		return err
+	}
+	if err := ps.Repository.SetPipelineOwner(pipelineID, ps.Instance); err != nil {
+		return err
+	}
+	return ps.Repository.RecordPipelineRun(run.RunID, run.Seed, config)
+}
+
+// saveEvents persists the canonical event sequence recorded during a run
+func (ps *PipelineService) saveEvents(pipelineID uuid.UUID, run *domain.RunContext) {
+	events := run.Recorder.Events()
+	records := make([]models.ExecutionEvent, len(events))
+	for i, e := range events {
+		records[i] = models.ExecutionEvent{
+			RunID:       run.RunID,
+			Seq:         i,
+			PipelineID:  pipelineID,
+			Type:        e.Type,
+			StageIndex:  e.StageIndex,
+			StageID:     e.StageID,
+			Status:      e.Status,
+			OffsetNanos: int64(e.Offset),
+			CreatedAt:   time.Now(),
+		}
+	}
+	if err := ps.Repository.SaveExecutionEvents(records); err != nil {
+		log.Printf("Failed to save execution events: %v", err)
+	}
+}
+
+// GetExecutionEvents returns the recorded event sequence of the latest run of a
+// pipeline that started
+func (ps *PipelineService) GetExecutionEvents(pipelineID uuid.UUID) ([]domain.ExecutionEvent, error) {
+	run, err := ps.Repository.GetLatestPipelineRun(pipelineID, true)
+	if err != nil {
+		return []domain.ExecutionEvent{}, nil
+	}
+	return ps.runEvents(run.RunID)
+}
+
+// runEvents returns the recorded event sequence of a run
+func (ps *PipelineService) runEvents(runID uuid.UUID) ([]domain.ExecutionEvent, error) {
+	records, err := ps.Repository.GetExecutionEvents(runID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.ExecutionEvent, len(records))
+	for i, r := range records {
+		events[i] = domain.ExecutionEvent{
+			Type:       r.Type,
+			StageIndex: r.StageIndex,
+			StageID:    r.StageID,
+			Status:     r.Status,
+			Offset:     time.Duration(r.OffsetNanos),
+		}
+	}
+	return events, nil
+}
+
+// ReplayResult pairs the event sequences of a run and its replay
+type ReplayResult struct {
+	OriginalPipelineID uuid.UUID               `json:"original_pipeline_id"`
+	OriginalRunID      uuid.UUID               `json:"original_run_id"`
+	ReplayPipelineID   uuid.UUID               `json:"replay_pipeline_id"`
+	ReplayRunID        uuid.UUID               `json:"replay_run_id"`
+	Seed               int64                   `json:"seed"`
+	Status             string                  `json:"status"`
+	Identical          bool                    `json:"identical"`
+	OriginalEvents     []domain.ExecutionEvent `json:"original_events"`
+	ReplayEvents       []domain.ExecutionEvent `json:"replay_events"`
+	Diffs              []domain.EventDiff      `json:"diffs"`
+}
+
+// ReplayPipeline re-executes the latest run of a pipeline with its recorded seed
+// and configuration on a virtual clock and diffs the resulting event sequence
+// against the original. The replay is stored as a new pipeline pointing back to the original.
+func (ps *PipelineService) ReplayPipeline(ctx context.Context, userID uuid.UUID, pipelineID uuid.UUID) (*ReplayResult, error) {
+	latest, cfg, err := ps.lastExecutedRun(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := ps.runEvents(latest.RunID)
+	if err != nil {
+		return nil, err
+	}
+
+	replayID := uuid.New()
+	orchestrator := ps.newOrchestrator(replayID, cfg.IsParallel)
+	for _, spec := range cfg.Stages {
+		if err := orchestrator.AddStage(domain.NewSimulatedStage(spec)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ps.Repository.SavePipelineExecution(&models.PipelineExecution{
+		PipelineID: replayID,
+		UserID:     userID,
+		Status:     string(domain.StatusCreated),
+		ReplayOf:   &pipelineID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+		Definition: newDefinitionRecord(replayID, cfg.IsParallel, orchestratorStages(orchestrator)),
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := ps.acquireLease(replayID); err != nil {
+		return nil, err
+	}
+	defer ps.releaseLease(replayID)
+
+	ps.recordTransition(replayID, nil, "", domain.StatusCreated, domain.UserActor(userID), "")
+
+	replayRun, err := ps.newRun(replayID, userID, cfg.Input, nil, domain.UserActor(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	// The virtual clock starts where the original run did so calendars pause it identically
+	start := cfg.StartedAt
+	if start.IsZero() {
+		start = time.Unix(0, 0).UTC()
+	}
+	run := domain.NewRunContext(latest.Seed, domain.NewVirtualClock(start))
+	run.RunID = replayRun.RunID
+	run.Calendar = cfg.Calendar
+	run.StageCalendars = cfg.StageCalendars
+	if err := ps.recordRun(replayID, cfg.IsParallel, orchestratorStages(orchestrator), cfg.Input, run); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Replaying run %d of pipeline %s as %s with seed %d", latest.Number, pipelineID, replayID, latest.Seed)
+
+	if err := ps.execute(ctx, userID, replayID, orchestrator, cfg.Input, run); err != nil {
+		// A failing original run is expected to fail again on replay
+		log.Printf("Replay %s finished with error: %v", replayID, err)
+	}
+
+	status, err := ps.Repository.GetPipelineStatus(replayID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	replay := run.Recorder.Events()
+	diffs := domain.DiffEvents(original, replay)
+	return &ReplayResult{
+		OriginalPipelineID: pipelineID,
+		OriginalRunID:      latest.RunID,
+		ReplayPipelineID:   replayID,
+		ReplayRunID:        replayRun.RunID,
+		Seed:               latest.Seed,
+		Status:             status,
+		Identical:          len(diffs) == 0,
+		OriginalEvents:     original,
+		ReplayEvents:       replay,
+		Diffs:              diffs,
+	}, nil
 }
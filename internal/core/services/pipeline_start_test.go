@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// startRepository keeps one pipeline, its lease and its latest run in memory
+// and compares status and version like the database does; calling any other
+// repository method panics
+type startRepository struct {
+	ports.PipelineRepository
+
+	mu          sync.Mutex
+	execution   *models.PipelineExecution // nil when the pipeline does not exist
+	leaseOwner  string
+	latestRun   *models.PipelineRun
+	conflicts   int // Status writes that lose to another request first
+	queueCalls  int
+	transitions []models.StatusTransition
+}
+
+func (r *startRepository) GetPipelineExecution(pipelineID uuid.UUID) (*models.PipelineExecution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.execution == nil {
+		return nil, ports.ErrNotFound
+	}
+	execution := *r.execution
+	return &execution, nil
+}
+
+// write applies status when from and version still match, after bumping the
+// version once for every pending conflict
+func (r *startRepository) write(from string, version int, status string) bool {
+	if r.conflicts > 0 {
+		r.conflicts--
+		r.execution.Version++
+		return false
+	}
+	if r.execution.Status != from || r.execution.Version != version {
+		return false
+	}
+	r.execution.Status = status
+	r.execution.Version++
+	return true
+}
+
+func (r *startRepository) SetPipelineStatus(pipelineID uuid.UUID, from string, version int, status string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.write(from, version, status), nil
+}
+
+func (r *startRepository) QueuePipelineRun(run *models.PipelineRun, from string, version int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queueCalls++
+	if !r.write(from, version, string(domain.StatusQueued)) {
+		return false, nil
+	}
+	r.latestRun = run
+	return true, nil
+}
+
+func (r *startRepository) GetLatestPipelineRun(pipelineID uuid.UUID, started bool) (*models.PipelineRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latestRun == nil {
+		return nil, ports.ErrNotFound
+	}
+	return r.latestRun, nil
+}
+
+func (r *startRepository) SaveStatusTransition(transition *models.StatusTransition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transitions = append(r.transitions, *transition)
+	return nil
+}
+
+func (r *startRepository) SetPipelineOwner(pipelineID uuid.UUID, owner string) error {
+	return nil
+}
+
+func (r *startRepository) AcquirePipelineLease(pipelineID uuid.UUID, owner string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.leaseOwner != "" && r.leaseOwner != owner {
+		return false, nil
+	}
+	r.leaseOwner = owner
+	return true, nil
+}
+
+func (r *startRepository) GetPipelineLease(pipelineID uuid.UUID) (*models.PipelineLease, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.leaseOwner == "" {
+		return nil, ports.ErrNotFound
+	}
+	return &models.PipelineLease{PipelineID: pipelineID, Owner: r.leaseOwner}, nil
+}
+
+func (r *startRepository) ReleasePipelineLease(pipelineID uuid.UUID, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.leaseOwner == owner {
+		r.leaseOwner = ""
+	}
+	return nil
+}
+
+// newStartService returns a service with a one-stage sequential pipeline
+// whose queue never dispatches, so started runs stay queued
+func newStartService(repo *startRepository, pipelineID uuid.UUID) *PipelineService {
+	sse := utils.NewSSEManager()
+	ps := NewPipelineService(repo, nil, sse)
+	ps.Instance = "replica-a"
+	ps.Queue = NewWorkQueue(0, func(*domain.WorkOrder) {})
+
+	orchestrator := domain.NewSequentialPipelineOrchestrator(pipelineID, repo, sse)
+	_ = orchestrator.AddStage(domain.NewBaseStage())
+	ps.SequentialOrchestrators[pipelineID] = orchestrator
+	return ps
+}
+
+func TestEnqueuePipeline(t *testing.T) {
+	pipelineID := uuid.New()
+	order := &domain.WorkOrder{PipelineID: pipelineID, UserID: uuid.New()}
+
+	ps := newStartService(&startRepository{}, pipelineID)
+	repo := ps.Repository.(*startRepository)
+	repo.execution = &models.PipelineExecution{PipelineID: pipelineID, Status: string(domain.StatusCreated), Version: 4}
+
+	position, err := ps.EnqueuePipeline(order)
+	if err != nil {
+		t.Fatalf("EnqueuePipeline() failed: %v", err)
+	}
+	if position != 1 {
+		t.Errorf("position = %d, want 1", position)
+	}
+	if repo.execution.Status != string(domain.StatusQueued) || repo.execution.Version != 5 {
+		t.Errorf("pipeline is %s at version %d, want Queued at version 5", repo.execution.Status, repo.execution.Version)
+	}
+	if repo.latestRun == nil || order.RunID != repo.latestRun.RunID {
+		t.Errorf("order.RunID = %v, want the queued run", order.RunID)
+	}
+	if !ps.holdsLease(pipelineID) || repo.leaseOwner != ps.Instance {
+		t.Errorf("lease owner = %q, held here %v, want it held by %s", repo.leaseOwner, ps.holdsLease(pipelineID), ps.Instance)
+	}
+	if len(repo.transitions) != 2 {
+		t.Errorf("recorded %d transitions, want one for the run and one for the pipeline", len(repo.transitions))
+	}
+}
+
+func TestEnqueuePipelineRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.Status
+		leaseOwner string // Replica holding the lease before the start
+		heldHere   bool   // Whether this replica already runs the pipeline
+		latestRun  domain.Status
+		conflicts  int
+		missing    bool
+		wantErr    error
+		wantLease  string // Lease owner after the start
+		wantWrites int    // Compare-and-set attempts to queue a run
+	}{
+		{name: "pipeline not found", missing: true, wantErr: ErrPipelineNotFound},
+		{name: "lease held by another replica", status: domain.StatusCreated, leaseOwner: "replica-b",
+			wantErr: ErrLeaseHeld, wantLease: "replica-b"},
+		{name: "already queued", status: domain.StatusQueued,
+			wantErr: domain.ErrInvalidTransition},
+		{name: "started by another request", status: domain.StatusCreated, conflicts: 1,
+			wantErr: domain.ErrStatusConflict, wantWrites: 1},
+		{name: "queued by a concurrent start here", status: domain.StatusQueued, leaseOwner: "replica-a", heldHere: true,
+			latestRun: domain.StatusQueued, wantErr: domain.ErrInvalidTransition, wantLease: "replica-a"},
+		{name: "lost to a start whose run ended", status: domain.StatusCreated, conflicts: 1, leaseOwner: "replica-a",
+			latestRun: domain.StatusFailed, wantErr: domain.ErrStatusConflict, wantWrites: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineID := uuid.New()
+			repo := &startRepository{leaseOwner: tt.leaseOwner, conflicts: tt.conflicts}
+			if !tt.missing {
+				repo.execution = &models.PipelineExecution{PipelineID: pipelineID, Status: string(tt.status), Version: 2}
+			}
+			if tt.latestRun != "" {
+				repo.latestRun = &models.PipelineRun{PipelineID: pipelineID, Status: string(tt.latestRun)}
+			}
+			ps := newStartService(repo, pipelineID)
+			if tt.heldHere {
+				ps.leases[pipelineID] = &heldLease{}
+			}
+
+			_, err := ps.EnqueuePipeline(&domain.WorkOrder{PipelineID: pipelineID, UserID: uuid.New()})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EnqueuePipeline() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.queueCalls != tt.wantWrites {
+				t.Errorf("QueuePipelineRun called %d times, want %d", repo.queueCalls, tt.wantWrites)
+			}
+			if repo.leaseOwner != tt.wantLease {
+				t.Errorf("lease owner = %q, want %q", repo.leaseOwner, tt.wantLease)
+			}
+			if ps.holdsLease(pipelineID) != tt.heldHere {
+				t.Errorf("holdsLease() = %v, want %v", ps.holdsLease(pipelineID), tt.heldHere)
+			}
+			if len(repo.transitions) != 0 {
+				t.Errorf("recorded transitions %+v for a rejected start", repo.transitions)
+			}
+			if snapshot := ps.Queue.Snapshot(); len(snapshot.Orders) != 0 {
+				t.Errorf("queued %d orders for a rejected start", len(snapshot.Orders))
+			}
+		})
+	}
+}
+
+func TestSetPipelineStatusRetriesConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		conflicts int
+		wantErr   error
+	}{
+		{name: "no conflict"},
+		{name: "applies after a conflict", conflicts: statusWriteAttempts - 1},
+		{name: "gives up", conflicts: statusWriteAttempts, wantErr: domain.ErrStatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineID := uuid.New()
+			repo := &startRepository{
+				execution: &models.PipelineExecution{PipelineID: pipelineID, Status: string(domain.StatusRunning)},
+				conflicts: tt.conflicts,
+			}
+			ps := newStartService(repo, pipelineID)
+
+			err := ps.setPipelineStatus(pipelineID, domain.StatusCompleted, "test", "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("setPipelineStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			wantStatus, wantTransitions := domain.StatusCompleted, 1
+			if tt.wantErr != nil {
+				wantStatus, wantTransitions = domain.StatusRunning, 0
+			}
+			if repo.execution.Status != string(wantStatus) {
+				t.Errorf("status = %s, want %s", repo.execution.Status, wantStatus)
+			}
+			if len(repo.transitions) != wantTransitions {
+				t.Errorf("recorded %d transitions, want %d", len(repo.transitions), wantTransitions)
+			}
+		})
+	}
+}
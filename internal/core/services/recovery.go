@@ -0,0 +1,315 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// Recovery policies for runs a crashed server left behind
+const (
+	RecoveryInterrupt = "interrupt" // Mark the run "Interrupted"
+	RecoveryResume    = "resume"    // Run the stages that had not completed
+	RecoveryRerun     = "rerun"     // Run every stage again with the recorded seed and input
+
+	DefaultRecoveryPolicy = RecoveryInterrupt
+)
+
+// InstanceName returns INSTANCE_NAME, or fallback@hostname when it is not set.
+// Every replica needs its own name, since leases are held by name; a replica
+// restarted under the same name reclaims its runs without waiting for them to expire.
+func InstanceName(fallback string) string {
+	if name := os.Getenv("INSTANCE_NAME"); name != "" {
+		return name
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return fallback + "@" + hostname
+	}
+	return fallback
+}
+
+// RecoveryPolicyFromEnv reads RECOVERY_POLICY
+func RecoveryPolicyFromEnv() string {
+	switch policy := os.Getenv("RECOVERY_POLICY"); policy {
+	case "":
+		return DefaultRecoveryPolicy
+	case RecoveryInterrupt, RecoveryResume, RecoveryRerun:
+		return policy
+	default:
+		log.Printf("Ignoring unknown RECOVERY_POLICY %q, using %q", policy, DefaultRecoveryPolicy)
+		return DefaultRecoveryPolicy
+	}
+}
+
+// RecoverRuns handles the runs left "Running" or "Queued" by a replica that
+// stopped: this one before a restart, or any replica whose leases expired. The
+// lease of each pipeline is taken first, so only one replica recovers it.
+// Running runs are interrupted, resumed or re-run per policy; queued work
+// orders only lived in memory, so those runs are interrupted and their
+// pipelines go back to "Created". Every decision is written to the execution
+// log of the run.
+func (ps *PipelineService) RecoverRuns(policy string) error {
+	candidates, err := ps.Repository.GetOrphanedPipelines(ps.Instance, []string{string(domain.StatusRunning), string(domain.StatusQueued)})
+	if err != nil {
+		return err
+	}
+
+	var orphans []*models.PipelineExecution
+	for i := range candidates {
+		execution := &candidates[i]
+		taken, err := ps.acquireLease(execution.PipelineID)
+		if err != nil {
+			if !errors.Is(err, ErrLeaseHeld) {
+				log.Printf("Failed to take the lease of pipeline %s: %v", execution.PipelineID, err)
+			}
+			continue
+		}
+		if !taken {
+			continue // Queued or running here
+		}
+		orphans = append(orphans, execution)
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+	log.Printf("Recovering %d orphaned pipeline(s) as %q with policy %q", len(orphans), ps.Instance, policy)
+
+	for _, execution := range orphans {
+		run, _ := ps.Repository.GetLatestPipelineRun(execution.PipelineID, false) // nil if it never ran
+		if domain.Status(execution.Status) == domain.StatusQueued {
+			ps.recoveryDecision(execution.PipelineID, run, domain.StatusCreated, "Work order was lost when the server stopped; pipeline can be started again", nil)
+			ps.releaseLease(execution.PipelineID)
+			continue
+		}
+		// A re-queued run keeps the lease until it finishes
+		if err := ps.recoverRun(execution, run, policy); err != nil {
+			ps.recoveryDecision(execution.PipelineID, run, domain.StatusInterrupted, fmt.Sprintf("Server stopped during the run; %s failed: %v", policy, err), nil)
+			ps.releaseLease(execution.PipelineID)
+		}
+	}
+	return nil
+}
+
+// recoverRun applies the policy to the latest run of a pipeline that was running
+func (ps *PipelineService) recoverRun(execution *models.PipelineExecution, run *models.PipelineRun, policy string) error {
+	if policy == RecoveryInterrupt {
+		ps.recoveryDecision(execution.PipelineID, run, domain.StatusInterrupted, "Server stopped during the run", nil)
+		ps.releaseLease(execution.PipelineID)
+		return nil
+	}
+
+	if run == nil || len(run.Config) == 0 {
+		return fmt.Errorf("run configuration was not recorded")
+	}
+	var cfg domain.RunConfig
+	if err := run.Config.Unmarshal(&cfg); err != nil {
+		return err
+	}
+	orchestrator := ps.orchestrator(execution.PipelineID, cfg.IsParallel)
+	if orchestrator == nil {
+		return fmt.Errorf("pipeline definition not found")
+	}
+	stages := orchestratorStages(orchestrator)
+
+	var skip []int
+	if policy == RecoveryResume {
+		var err error
+		if skip, err = ps.completedStages(run.RunID, stages, cfg.IsParallel); err != nil {
+			return err
+		}
+		if len(skip) == len(stages) {
+			// Nothing is left to run: the run completes with the output its
+			// orchestrator would have returned, and updateRun hands it to
+			// OnRunEnded like any completed run
+			outputs, err := ps.completedOutputs(run.RunID, stages)
+			if err != nil {
+				return err
+			}
+			updates := make(map[string]interface{})
+			if outputJSON, err := models.NewJSONB(runOutput(outputs, len(stages), cfg.IsParallel)); err == nil {
+				updates["output"] = outputJSON
+			} else {
+				log.Printf("Failed to store output of run %s: %v", run.RunID, err)
+			}
+			ps.recoveryDecision(execution.PipelineID, run, domain.StatusCompleted, "Server stopped after the last stage completed", updates)
+			ps.releaseLease(execution.PipelineID)
+			return nil
+		}
+	}
+
+	// Stages that run again log a new attempt next to their earlier ones
+	skipped := make(map[int]bool, len(skip))
+	for _, i := range skip {
+		skipped[i] = true
+	}
+	rerun := 0
+	for i := range stages {
+		if !skipped[i] {
+			rerun++
+		}
+	}
+
+	message := fmt.Sprintf("Server stopped during the run; re-running all %d stages with seed %d", len(stages), run.Seed)
+	if policy == RecoveryResume {
+		message = fmt.Sprintf("Server stopped during the run; resuming with %d of %d stages left (seed %d)", rerun, len(stages), run.Seed)
+	}
+	ps.recoveryDecision(execution.PipelineID, run, domain.StatusQueued, message, nil)
+	if err := ps.Repository.SetPipelineOwner(execution.PipelineID, ps.Instance); err != nil {
+		log.Printf("Failed to record owner of pipeline %s: %v", execution.PipelineID, err)
+	}
+
+	// The order keeps the input the run was recorded with; the stages it
+	// skips hand their stored outputs on to the remaining ones, so the first
+	// of those runs on the output of the last completed stage
+	_, err := ps.Queue.Enqueue(&domain.WorkOrder{
+		PipelineID:        execution.PipelineID,
+		RunID:             run.RunID,
+		UserID:            execution.UserID,
+		IsParallel:        cfg.IsParallel,
+		Input:             cfg.Input,
+		Seed:              run.Seed,
+		SkipStages:        skip,
+		ProcessingSeconds: domain.PipelineDefinition{IsParallel: cfg.IsParallel, Stages: stageSpecs(stages)}.ExpectedSeconds(),
+		OnDone: func(err error) {
+			if err != nil {
+				log.Printf("Recovered pipeline %s failed: %v", execution.PipelineID, err)
+			}
+		},
+	})
+	return err
+}
+
+// completedStages returns the indexes of the stages a run logged as completed,
+// with a stored output, in any attempt. A sequential run only completed the
+// stages before the first unfinished one.
+func (ps *PipelineService) completedStages(runID uuid.UUID, stages []domain.Stage, isParallel bool) ([]int, error) {
+	outputs, err := ps.completedOutputs(runID, stages)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for i := range stages {
+		if _, ok := outputs[i]; ok {
+			done = append(done, i)
+		} else if !isParallel {
+			break
+		}
+	}
+	return done, nil
+}
+
+// completedOutputs decodes the output of the latest completed attempt of each
+// stage of a run. Stages whose output was not stored, such as those logged
+// before outputs were, are left out and run again on resume.
+func (ps *PipelineService) completedOutputs(runID uuid.UUID, stages []domain.Stage) (map[int]interface{}, error) {
+	logs, err := ps.Repository.GetRunLogs(runID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[uuid.UUID]int, len(stages))
+	for i, stage := range stages {
+		index[stage.GetID()] = i
+	}
+
+	outputs := make(map[int]interface{})
+	latest := make(map[int]int)
+	for _, entry := range logs {
+		i, ok := index[entry.StageID]
+		if !ok || domain.Status(entry.Status) != domain.StatusCompleted || len(entry.Output) == 0 {
+			continue
+		}
+		if attempt, seen := latest[i]; seen && attempt > entry.Attempt {
+			continue
+		}
+		var output interface{}
+		if err := entry.Output.Unmarshal(&output); err != nil {
+			return nil, fmt.Errorf("output of stage %d: %w", i, err)
+		}
+		outputs[i] = output
+		latest[i] = entry.Attempt
+	}
+	return outputs, nil
+}
+
+// runOutput is the output of a run whose stages produced outputs: that of the
+// last stage, or in parallel mode those of all stages in stage order
+func runOutput(outputs map[int]interface{}, stageCount int, isParallel bool) interface{} {
+	if !isParallel {
+		return outputs[stageCount-1]
+	}
+	all := make([]interface{}, 0, stageCount)
+	for i := 0; i < stageCount; i++ {
+		all = append(all, outputs[i])
+	}
+	return all
+}
+
+// stageAttempts counts the attempts each stage already made in a run, so a
+// resumed or re-run stage logs its next attempt
+func (ps *PipelineService) stageAttempts(runID uuid.UUID, stages []domain.Stage) (map[int]int, error) {
+	logs, err := ps.Repository.GetRunLogs(runID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[uuid.UUID]int, len(stages))
+	for i, stage := range stages {
+		index[stage.GetID()] = i
+	}
+	attempts := make(map[int]int)
+	for _, entry := range logs {
+		status := domain.Status(entry.Status)
+		if status != domain.StatusCompleted && status != domain.StatusFailed {
+			continue
+		}
+		if i, ok := index[entry.StageID]; ok && entry.Attempt > attempts[i] {
+			attempts[i] = entry.Attempt
+		}
+	}
+	return attempts, nil
+}
+
+// recoveryDecision sets the status of the pipeline and its run, if it has one,
+// along with any other run columns in updates, and logs why. Recovery entries
+// belong to no stage.
+func (ps *PipelineService) recoveryDecision(pipelineID uuid.UUID, run *models.PipelineRun, status domain.Status, message string, updates map[string]interface{}) {
+	log.Printf("Recovery of pipeline %s: %s -> %s", pipelineID, message, status)
+
+	var runID uuid.UUID
+	if run != nil {
+		runID = run.RunID
+		// A run never goes back to "Created"; losing its work order ends it
+		runStatus := status
+		if status == domain.StatusCreated {
+			runStatus = domain.StatusInterrupted
+		}
+		_ = ps.updateRun(pipelineID, runID, runStatus, ps.actor(), message, updates)
+	}
+	if err := ps.setPipelineStatus(pipelineID, status, ps.actor(), message); err != nil {
+		log.Printf("Failed to update status of recovered pipeline %s: %v", pipelineID, err)
+	}
+	if err := ps.Repository.SaveExecutionLog(&models.ExecutionLog{
+		StageID:    uuid.Nil,
+		PipelineID: pipelineID,
+		RunID:      runID,
+		StageIndex: -1,
+		Status:     domain.LogRecovery,
+		ErrorMsg:   message,
+		Timestamp:  time.Now(),
+	}); err != nil {
+		log.Printf("Failed to log recovery of pipeline %s: %v", pipelineID, err)
+	}
+
+	// 🔹 Broadcast recovery outcome via SSE
+	ps.SSE.BroadcastUpdate(map[string]interface{}{
+		"type":        "pipeline",
+		"pipeline_id": pipelineID.String(),
+		"status":      status,
+	})
+}
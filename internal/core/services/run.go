@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// newRun queues a run of a pipeline with its input, and the trigger that
+// queued it if cause is set. The run is saved together with the pipeline's
+// move to Queued, so of two concurrent starts only one gets a run; the other
+// fails with ErrStatusConflict.
+func (ps *PipelineService) newRun(pipelineID uuid.UUID, userID uuid.UUID, input interface{}, cause *domain.TriggerCause, actor string) (*models.PipelineRun, error) {
+	execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+	if err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	from := domain.Status(execution.Status)
+	if err := domain.PipelineStates.Validate(from, domain.StatusQueued); err != nil {
+		return nil, err
+	}
+
+	inputJSON, err := models.NewJSONB(input)
+	if err != nil {
+		return nil, err
+	}
+	run := &models.PipelineRun{
+		RunID:      uuid.New(),
+		PipelineID: pipelineID,
+		UserID:     userID,
+		Status:     string(domain.StatusQueued),
+		Input:      inputJSON,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if cause != nil {
+		chain, err := models.NewJSONB(cause.Chain)
+		if err != nil {
+			return nil, err
+		}
+		run.TriggerID = &cause.TriggerID
+		run.TriggeredByRunID = &cause.RunID
+		run.TriggerChain = chain
+	}
+
+	queued, err := ps.Repository.QueuePipelineRun(run, string(from), execution.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !queued {
+		return nil, fmt.Errorf("%w: pipeline %s was started by another request", domain.ErrStatusConflict, pipelineID)
+	}
+	ps.recordTransition(pipelineID, &run.RunID, "", domain.StatusQueued, actor, "")
+	ps.recordTransition(pipelineID, nil, from, domain.StatusQueued, actor, "")
+	return run, nil
+}
+
+// updateRun moves a run to status, along with any other columns in updates,
+// once the run state machine allows it. A run keeps the time it first
+// started; one that ended gets its finish time and, unless it completed, the
+// reason as its error, and is handed to OnRunEnded. Like setPipelineStatus,
+// it validates again when another writer changed the run first.
+func (ps *PipelineService) updateRun(pipelineID uuid.UUID, runID uuid.UUID, status domain.Status, actor string, reason string, updates map[string]interface{}) error {
+	if updates == nil {
+		updates = make(map[string]interface{})
+	}
+	updates["status"] = string(status)
+	updates["updated_at"] = time.Now()
+	switch {
+	case status == domain.StatusRunning:
+		updates["started_at"] = time.Now()
+		updates["finished_at"] = nil
+	case status.Ended():
+		updates["finished_at"] = time.Now()
+		if status != domain.StatusCompleted && reason != "" {
+			updates["error_msg"] = reason
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		current, err := ps.Repository.GetRunStatus(runID)
+		if err != nil {
+			log.Printf("Failed to update run %s to %s: %v", runID, status, err)
+			return err
+		}
+		from := domain.Status(current)
+		if err := domain.RunStates.Validate(from, status); err != nil {
+			log.Printf("Rejected update of run %s: %v", runID, err)
+			return err
+		}
+
+		applied, err := ps.Repository.UpdatePipelineRun(runID, string(from), updates)
+		if err != nil {
+			log.Printf("Failed to update run %s to %s: %v", runID, status, err)
+			return err
+		}
+		if applied {
+			ps.recordTransition(pipelineID, &runID, from, status, actor, reason)
+			break
+		}
+		if attempt == statusWriteAttempts {
+			return fmt.Errorf("%w: run %s kept changing while moving to %s", domain.ErrStatusConflict, runID, status)
+		}
+	}
+
+	// Runs interrupted by a server stop fire no triggers
+	if ps.OnRunEnded != nil && status.Ended() && status != domain.StatusInterrupted {
+		go ps.OnRunEnded(runID, status)
+	}
+	return nil
+}
+
+// endActiveRun ends the latest run of a pipeline if it is still queued or running
+func (ps *PipelineService) endActiveRun(pipelineID uuid.UUID, status domain.Status, actor string, reason string) {
+	run, err := ps.Repository.GetLatestPipelineRun(pipelineID, false)
+	if err != nil || !domain.Status(run.Status).Active() {
+		return
+	}
+	_ = ps.updateRun(pipelineID, run.RunID, status, actor, reason, nil)
+}
+
+// hasActiveRun reports whether the latest run of a pipeline is queued or running
+func (ps *PipelineService) hasActiveRun(pipelineID uuid.UUID) bool {
+	run, err := ps.Repository.GetLatestPipelineRun(pipelineID, false)
+	return err == nil && domain.Status(run.Status).Active()
+}
+
+// lastExecutedRun returns the latest run of a pipeline that started, with the
+// configuration it ran with
+func (ps *PipelineService) lastExecutedRun(pipelineID uuid.UUID) (*models.PipelineRun, domain.RunConfig, error) {
+	var cfg domain.RunConfig
+	if _, err := ps.Repository.GetPipelineExecution(pipelineID); err != nil {
+		return nil, cfg, errors.New("pipeline not found")
+	}
+	run, err := ps.Repository.GetLatestPipelineRun(pipelineID, true)
+	if err != nil {
+		return nil, cfg, errors.New("pipeline has not been executed yet")
+	}
+	if err := run.Config.Unmarshal(&cfg); err != nil {
+		return nil, cfg, err
+	}
+	return run, cfg, nil
+}
+
+// GetPipelineRuns lists the runs of a pipeline, latest first
+func (ps *PipelineService) GetPipelineRuns(pipelineID uuid.UUID) ([]models.PipelineRun, error) {
+	if _, err := ps.Repository.GetPipelineExecution(pipelineID); err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	return ps.Repository.GetPipelineRuns(pipelineID)
+}
+
+// GetPipelineRun returns a run of a pipeline with its execution logs
+func (ps *PipelineService) GetPipelineRun(pipelineID uuid.UUID, runID uuid.UUID) (*models.PipelineRun, error) {
+	run, err := ps.Repository.GetPipelineRun(runID)
+	if err != nil || run.PipelineID != pipelineID {
+		return nil, errors.New("run not found")
+	}
+	return run, nil
+}
+
+// PipelineResult is the input and outcome of the latest run of a pipeline
+type PipelineResult struct {
+	PipelineID uuid.UUID    `json:"pipeline_id"`
+	RunID      uuid.UUID    `json:"run_id"`
+	RunNumber  int          `json:"run_number"`
+	Status     string       `json:"status"`
+	Input      models.JSONB `json:"input"`
+	Output     models.JSONB `json:"output"` // Null unless the run completed
+	Error      string       `json:"error,omitempty"`
+	FinishedAt *time.Time   `json:"finished_at"`
+}
+
+// GetPipelineResult returns what the latest run of a pipeline was given and,
+// once it completed, what its last stage produced
+func (ps *PipelineService) GetPipelineResult(pipelineID uuid.UUID) (*PipelineResult, error) {
+	if _, err := ps.Repository.GetPipelineExecution(pipelineID); err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	run, err := ps.Repository.GetLatestPipelineRun(pipelineID, false)
+	if err != nil {
+		return nil, errors.New("pipeline has not been started yet")
+	}
+	return &PipelineResult{
+		PipelineID: pipelineID,
+		RunID:      run.RunID,
+		RunNumber:  run.Number,
+		Status:     run.Status,
+		Input:      run.Input,
+		Output:     run.Output,
+		Error:      run.ErrorMsg,
+		FinishedAt: run.FinishedAt,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// ScheduleTickInterval is how often the scheduler looks for due schedules
+const ScheduleTickInterval = 15 * time.Second
+
+// ScheduleService manages cron schedules and starts the runs they fire
+type ScheduleService struct {
+	Repository ports.ScheduleRepository
+	Pipelines  *PipelineService
+}
+
+// NewScheduleService initializes the ScheduleService
+func NewScheduleService(repo ports.ScheduleRepository, pipelines *PipelineService) *ScheduleService {
+	return &ScheduleService{Repository: repo, Pipelines: pipelines}
+}
+
+// CreateSchedule schedules recurring runs of a pipeline; the first one fires
+// at the next fire time after now
+func (s *ScheduleService) CreateSchedule(userID uuid.UUID, pipelineID uuid.UUID, spec domain.ScheduleSpec) (*models.PipelineSchedule, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	if _, err := s.Pipelines.GetPipelineDefinition(pipelineID); err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+
+	schedule := &models.PipelineSchedule{
+		ScheduleID: uuid.New(),
+		PipelineID: pipelineID,
+		UserID:     userID,
+		Enabled:    true,
+	}
+	if err := applyScheduleSpec(schedule, spec); err != nil {
+		return nil, err
+	}
+	if err := s.Repository.SaveSchedule(schedule); err != nil {
+		return nil, err
+	}
+	log.Printf("Scheduled pipeline %s with %q (%s), next run at %s", pipelineID, schedule.Cron, schedule.Timezone, schedule.NextFireAt)
+	return schedule, nil
+}
+
+// UpdateSchedule replaces the settings of a schedule; fire times are counted
+// afresh from now
+func (s *ScheduleService) UpdateSchedule(scheduleID uuid.UUID, spec domain.ScheduleSpec) (*models.PipelineSchedule, error) {
+	schedule, err := s.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyScheduleSpec(schedule, spec); err != nil {
+		return nil, err
+	}
+	if err := s.Repository.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// PauseSchedule stops a schedule from firing
+func (s *ScheduleService) PauseSchedule(scheduleID uuid.UUID) (*models.PipelineSchedule, error) {
+	schedule, err := s.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Enabled = false
+	schedule.NextFireAt = nil
+	if err := s.Repository.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// ResumeSchedule lets a paused schedule fire again from its next fire time;
+// fire times that passed while it was paused are not missed fires
+func (s *ScheduleService) ResumeSchedule(scheduleID uuid.UUID) (*models.PipelineSchedule, error) {
+	schedule, err := s.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.Enabled {
+		return schedule, nil
+	}
+	schedule.Enabled = true
+	if err := applyScheduleSpec(schedule, scheduleSpec(schedule)); err != nil {
+		return nil, err
+	}
+	if err := s.Repository.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule removes a schedule; runs it already started are kept
+func (s *ScheduleService) DeleteSchedule(scheduleID uuid.UUID) error {
+	return s.Repository.DeleteSchedule(scheduleID)
+}
+
+// GetSchedule fetches a schedule by ID
+func (s *ScheduleService) GetSchedule(scheduleID uuid.UUID) (*models.PipelineSchedule, error) {
+	schedule, err := s.Repository.GetSchedule(scheduleID)
+	if err != nil {
+		return nil, errors.New("schedule not found")
+	}
+	return schedule, nil
+}
+
+// ListSchedules lists the schedules of a user, or of a pipeline when pipelineID is set
+func (s *ScheduleService) ListSchedules(userID string, pipelineID *uuid.UUID) ([]models.PipelineSchedule, error) {
+	return s.Repository.GetSchedules(userID, pipelineID)
+}
+
+// applyScheduleSpec validates spec, copies it onto schedule and sets the
+// next fire time of an enabled schedule
+func applyScheduleSpec(schedule *models.PipelineSchedule, spec domain.ScheduleSpec) error {
+	spec = spec.WithDefaults()
+	cron, loc, err := spec.Compile()
+	if err != nil {
+		return err
+	}
+	input, err := models.NewJSONB(spec.Input)
+	if err != nil {
+		return err
+	}
+
+	schedule.Name = spec.Name
+	schedule.Cron = cron.Expr
+	schedule.Timezone = spec.Timezone
+	schedule.MissedPolicy = spec.MissedPolicy
+	schedule.Input = input
+	schedule.Priority = spec.Priority
+	schedule.NextFireAt = nil
+	if schedule.Enabled {
+		next := cron.Next(time.Now(), loc)
+		schedule.NextFireAt = &next
+	}
+	return nil
+}
+
+func scheduleSpec(schedule *models.PipelineSchedule) domain.ScheduleSpec {
+	var input interface{}
+	_ = schedule.Input.Unmarshal(&input)
+	return domain.ScheduleSpec{
+		Name:         schedule.Name,
+		Cron:         schedule.Cron,
+		Timezone:     schedule.Timezone,
+		MissedPolicy: schedule.MissedPolicy,
+		Input:        input,
+		Priority:     schedule.Priority,
+	}
+}
+
+// RunScheduler fires due schedules every ScheduleTickInterval. Every replica
+// runs it; a fire time is claimed in the database before it starts a run, so
+// only one replica fires it. It never returns.
+func (s *ScheduleService) RunScheduler() {
+	ticker := time.NewTicker(ScheduleTickInterval)
+	defer ticker.Stop()
+
+	s.tick(time.Now())
+	for now := range ticker.C {
+		s.tick(now)
+	}
+}
+
+func (s *ScheduleService) tick(now time.Time) {
+	due, err := s.Repository.GetDueSchedules(now)
+	if err != nil {
+		log.Printf("Failed to load due schedules: %v", err)
+		return
+	}
+	for i := range due {
+		if err := s.fire(&due[i], now); err != nil {
+			log.Printf("Schedule %s of pipeline %s failed to fire: %v", due[i].ScheduleID, due[i].PipelineID, err)
+		}
+	}
+}
+
+// fire applies the missed-fire policy of a due schedule, claims the fire time
+// and queues a run of its pipeline. A pipeline still busy with an earlier run
+// misses the fire, except under catch_up, which waits for the run to end.
+func (s *ScheduleService) fire(schedule *models.PipelineSchedule, now time.Time) error {
+	spec := scheduleSpec(schedule)
+	cron, loc, err := spec.Compile()
+	if err != nil {
+		return err
+	}
+	plan := cron.PlanFire(*schedule.NextFireAt, now, loc, schedule.MissedPolicy)
+
+	definition, err := s.Pipelines.GetPipelineDefinition(schedule.PipelineID)
+	if err != nil {
+		return err
+	}
+	status, err := s.Pipelines.Repository.GetPipelineStatus(schedule.PipelineID.String())
+	if err != nil {
+		return err
+	}
+	busy := plan.Fire != nil && domain.Status(status).Active()
+	if busy && schedule.MissedPolicy == domain.MissedCatchUp {
+		return nil
+	}
+	if busy {
+		plan.Missed++
+	}
+
+	claimed, err := s.Repository.ClaimScheduleFire(schedule.ScheduleID, *schedule.NextFireAt, plan.NextFire, plan.Missed)
+	if err != nil || !claimed {
+		return err
+	}
+	if plan.Missed > 0 {
+		log.Printf("Schedule %s of pipeline %s missed %d fire(s) (%s)", schedule.ScheduleID, schedule.PipelineID, plan.Missed, schedule.MissedPolicy)
+	}
+	if plan.Fire == nil {
+		return nil
+	}
+	if busy {
+		return s.Repository.RecordScheduleFire(schedule.ScheduleID, *plan.Fire, nil, fmt.Sprintf("skipped: pipeline is %s", status))
+	}
+
+	order := &domain.WorkOrder{
+		PipelineID: schedule.PipelineID,
+		UserID:     schedule.UserID,
+		IsParallel: definition.IsParallel,
+		Input:      spec.Input,
+		Seed:       ResolveSeed(nil),
+		Priority:   schedule.Priority,
+		Actor:      domain.ScheduleActor(schedule.ScheduleID),
+	}
+	position, err := s.Pipelines.EnqueuePipeline(order)
+	if err != nil {
+		if recordErr := s.Repository.RecordScheduleFire(schedule.ScheduleID, *plan.Fire, nil, err.Error()); recordErr != nil {
+			log.Printf("Failed to record fire of schedule %s: %v", schedule.ScheduleID, recordErr)
+		}
+		return err
+	}
+	log.Printf("Schedule %s fired for %s, queued run %s of pipeline %s at position %d", schedule.ScheduleID, plan.Fire.In(loc), order.RunID, schedule.PipelineID, position)
+	return s.Repository.RecordScheduleFire(schedule.ScheduleID, *plan.Fire, &order.RunID, "")
+}
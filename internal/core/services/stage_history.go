@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+)
+
+// StageHistory lists the stages of a pipeline in order, each with what the
+// latest run of the pipeline did with it
+type StageHistory struct {
+	PipelineID uuid.UUID             `json:"pipeline_id"`
+	IsParallel bool                  `json:"is_parallel"`
+	RunID      *uuid.UUID            `json:"run_id"` // Unset before the first run
+	RunNumber  int                   `json:"run_number"`
+	Status     string                `json:"status"`
+	Stages     []StageState          `json:"stages"`
+	Entries    []models.ExecutionLog `json:"entries"` // Rollback, recovery and error entries
+}
+
+// StageState is a stage definition merged with its attempts in the latest
+// run, oldest first
+type StageState struct {
+	StageID  uuid.UUID             `json:"stage_id"`
+	Index    int                   `json:"index"`
+	Name     string                `json:"name"`
+	Type     string                `json:"type"`
+	Config   domain.StageSpec      `json:"config"`
+	Status   string                `json:"status"` // Status of the latest attempt, RolledBack, or Pending
+	Attempts []models.ExecutionLog `json:"attempts"`
+}
+
+// GetPipelineStages returns the stages of a pipeline with their state in its
+// latest run; stages the run has not reached, or every stage of a pipeline
+// that never ran, are Pending
+func (ps *PipelineService) GetPipelineStages(pipelineID uuid.UUID) (*StageHistory, error) {
+	execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+	if err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	history := &StageHistory{PipelineID: pipelineID, Status: execution.Status}
+
+	var logs []models.ExecutionLog
+	if run, err := ps.Repository.GetLatestPipelineRun(pipelineID, false); err == nil {
+		if logs, err = ps.Repository.GetRunLogs(run.RunID); err != nil {
+			return nil, err
+		}
+		history.RunID = &run.RunID
+		history.RunNumber = run.Number
+		history.Status = run.Status
+	}
+
+	// Pipelines created before definitions were stored only show the stages they logged
+	var defined []models.PipelineStage
+	if definition, err := ps.Repository.GetPipelineDefinition(pipelineID); err == nil {
+		history.IsParallel = definition.IsParallel
+		defined = definition.Stages
+	}
+	history.Stages, history.Entries = stageStates(defined, logs)
+	return history, nil
+}
+
+// stageStates merges the defined stages with the logs of a run, ordered by
+// stage index, and returns the entries that are no stage attempt apart
+func stageStates(defined []models.PipelineStage, logs []models.ExecutionLog) ([]StageState, []models.ExecutionLog) {
+	stages := make([]StageState, 0, len(defined))
+	position := make(map[uuid.UUID]int, len(defined))
+	for _, stage := range defined {
+		spec := domain.StageSpec{
+			Name:          stage.Name,
+			MeanSeconds:   stage.MeanSeconds,
+			StdDevSeconds: stage.StdDevSeconds,
+			Yield:         stage.Yield,
+			Type:          stage.Type,
+			CalendarID:    stage.CalendarID,
+		}
+		if spec.Name == "" {
+			spec.Name = domain.DefaultStageName(stage.Position)
+		}
+		position[stage.StageID] = len(stages)
+		stages = append(stages, StageState{
+			StageID:  stage.StageID,
+			Index:    stage.Position,
+			Name:     spec.Name,
+			Type:     spec.StageType(),
+			Config:   spec,
+			Attempts: []models.ExecutionLog{},
+		})
+	}
+
+	entries := []models.ExecutionLog{}
+	rolledBack := make(map[uuid.UUID]int)
+	for _, entry := range logs {
+		status := domain.Status(entry.Status)
+		if entry.StageIndex < 0 || (status != domain.StatusCompleted && status != domain.StatusFailed) {
+			if entry.Status == domain.LogRolledBack {
+				rolledBack[entry.StageID] = entry.Attempt
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		i, ok := position[entry.StageID]
+		if !ok {
+			i = len(stages)
+			position[entry.StageID] = i
+			stages = append(stages, StageState{StageID: entry.StageID, Index: entry.StageIndex, Name: entry.StageName})
+		}
+		stages[i].Attempts = append(stages[i].Attempts, entry)
+	}
+
+	for i := range stages {
+		attempts := stages[i].Attempts
+		if len(attempts) == 0 {
+			stages[i].Status = domain.StagePending
+			continue
+		}
+		sort.SliceStable(attempts, func(a, b int) bool { return attempts[a].Attempt < attempts[b].Attempt })
+		latest := attempts[len(attempts)-1]
+		stages[i].Status = latest.Status
+		if attempt, ok := rolledBack[latest.StageID]; ok && attempt == latest.Attempt {
+			stages[i].Status = domain.LogRolledBack
+		}
+	}
+	sort.SliceStable(stages, func(a, b int) bool { return stages[a].Index < stages[b].Index })
+	return stages, entries
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// statusWriteAttempts bounds how often a status change is validated again
+// after losing a race with another writer
+const statusWriteAttempts = 3
+
+// setPipelineStatus moves a pipeline to status once the pipeline state
+// machine allows it; every status change of a pipeline goes through here. The
+// write only applies to the status and version it was validated against; when
+// another writer got there first, the change is validated again against what
+// that writer left.
+func (ps *PipelineService) setPipelineStatus(pipelineID uuid.UUID, status domain.Status, actor string, reason string) error {
+	for attempt := 1; ; attempt++ {
+		execution, err := ps.Repository.GetPipelineExecution(pipelineID)
+		if err != nil {
+			return err
+		}
+		from := domain.Status(execution.Status)
+		if err := domain.PipelineStates.Validate(from, status); err != nil {
+			log.Printf("Rejected update of pipeline %s: %v", pipelineID, err)
+			return err
+		}
+
+		applied, err := ps.Repository.SetPipelineStatus(pipelineID, string(from), execution.Version, string(status))
+		if err != nil {
+			return err
+		}
+		if applied {
+			ps.recordTransition(pipelineID, nil, from, status, actor, reason)
+			return nil
+		}
+		if attempt == statusWriteAttempts {
+			return fmt.Errorf("%w: pipeline %s kept changing while moving to %s", domain.ErrStatusConflict, pipelineID, status)
+		}
+	}
+}
+
+// recordTransition adds a status change of a pipeline, or of its run when
+// runID is set, to its history; from is empty when the pipeline or run was
+// created in status
+func (ps *PipelineService) recordTransition(pipelineID uuid.UUID, runID *uuid.UUID, from domain.Status, status domain.Status, actor string, reason string) {
+	if err := ps.Repository.SaveStatusTransition(&models.StatusTransition{
+		TransitionID: uuid.New(),
+		PipelineID:   pipelineID,
+		RunID:        runID,
+		FromStatus:   string(from),
+		ToStatus:     string(status),
+		Actor:        actor,
+		Reason:       reason,
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		log.Printf("Failed to record transition of pipeline %s to %s: %v", pipelineID, status, err)
+	}
+	entity := "pipeline"
+	if runID != nil {
+		entity = "run"
+	}
+	utils.StatusTransitions.WithLabelValues(entity, string(status)).Inc()
+}
+
+// CountPipelinesByStatus counts the pipelines in every status, including
+// those no pipeline is in
+func (ps *PipelineService) CountPipelinesByStatus() (map[string]int, error) {
+	counts, err := ps.Repository.CountPipelinesByStatus()
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range domain.Statuses {
+		if _, ok := counts[string(status)]; !ok {
+			counts[string(status)] = 0
+		}
+	}
+	return counts, nil
+}
+
+// actor names this server as the maker of the status changes it executes
+func (ps *PipelineService) actor() string {
+	return domain.InstanceActor(ps.Instance)
+}
+
+// GetPipelineTransitions returns the status history of a pipeline and its runs, oldest first
+func (ps *PipelineService) GetPipelineTransitions(pipelineID uuid.UUID) ([]models.StatusTransition, error) {
+	if _, err := ps.Repository.GetPipelineExecution(pipelineID); err != nil {
+		return nil, errors.New("pipeline not found")
+	}
+	return ps.Repository.GetStatusTransitions(pipelineID)
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// StudyService runs Monte Carlo studies over pipeline definitions
+type StudyService struct {
+	Repository ports.StudyRepository
+	Pipelines  *PipelineService
+}
+
+// NewStudyService initializes the StudyService
+func NewStudyService(repo ports.StudyRepository, pipelines *PipelineService) *StudyService {
+	return &StudyService{
+		Repository: repo,
+		Pipelines:  pipelines,
+	}
+}
+
+// StudyRequest describes a study to run. The definition is taken from
+// PipelineID when set, otherwise from Definition; a definition without stage
+// specs gets StageCount default stages.
+type StudyRequest struct {
+	UserID       uuid.UUID
+	PipelineID   *uuid.UUID
+	Name         string
+	Definition   domain.PipelineDefinition
+	StageCount   int
+	Replications int
+	Units        int
+	Seed         *int64
+}
+
+// RunStudy executes all replications on a virtual clock and stores the aggregated results
+func (s *StudyService) RunStudy(ctx context.Context, req StudyRequest) (*models.Study, error) {
+	if req.UserID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+
+	def := req.Definition
+	if len(def.Stages) == 0 {
+		def = domain.DefaultPipelineDefinition(req.StageCount, def.IsParallel)
+	}
+	if req.PipelineID != nil {
+		var err error
+		def, err = s.Pipelines.GetPipelineDefinition(*req.PipelineID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	def = def.WithDefaults()
+
+	units := req.Units
+	if units == 0 {
+		units = domain.DefaultStudyUnits
+	}
+	seed := ResolveSeed(req.Seed)
+
+	log.Printf("[INFO] Running study with %d replications of %d units (seed %d)", req.Replications, units, seed)
+
+	outcome, err := domain.RunStudy(ctx, def, domain.StudyConfig{
+		Replications: req.Replications,
+		Units:        units,
+		Seed:         seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := models.NewJSONB(def)
+	if err != nil {
+		return nil, err
+	}
+
+	study := &models.Study{
+		StudyID:      uuid.New(),
+		UserID:       req.UserID,
+		PipelineID:   req.PipelineID,
+		Name:         req.Name,
+		Replications: req.Replications,
+		Units:        units,
+		Seed:         seed,
+		Definition:   definition,
+		Status:       "Completed",
+		CreatedAt:    time.Now(),
+	}
+	for _, metric := range []string{domain.MetricMakespan, domain.MetricThroughput, domain.MetricYield} {
+		summary := outcome.Metrics[metric]
+		study.Metrics = append(study.Metrics, models.StudyMetric{
+			StudyID: study.StudyID,
+			Metric:  metric,
+			N:       summary.N,
+			Mean:    summary.Mean,
+			StdDev:  summary.StdDev,
+			Min:     summary.Min,
+			Max:     summary.Max,
+			P5:      summary.P5,
+			P50:     summary.P50,
+			P95:     summary.P95,
+			CILower: summary.CILower,
+			CIUpper: summary.CIUpper,
+		})
+	}
+	for i, r := range outcome.Replications {
+		study.Samples = append(study.Samples, models.StudyReplication{
+			StudyID:         study.StudyID,
+			Replication:     i,
+			Seed:            r.Seed,
+			MakespanSeconds: r.Makespan.Seconds(),
+			Throughput:      r.Throughput(),
+			Yield:           r.Yield(),
+			GoodUnits:       r.GoodUnits,
+			ScrappedUnits:   r.ScrappedUnits,
+		})
+	}
+
+	for _, st := range outcome.Stages {
+		study.Stages = append(study.Stages, models.StudyStage{
+			StudyID:        study.StudyID,
+			StageIndex:     st.Index,
+			Name:           st.Name,
+			Processed:      st.Processed,
+			BusySeconds:    st.BusySeconds,
+			StarvedSeconds: st.StarvedSeconds,
+			BlockedSeconds: st.BlockedSeconds,
+			AvgQueueLength: st.AvgQueueLength,
+			MaxQueueLength: st.MaxQueueLength,
+			Utilization:    st.Utilization,
+		})
+	}
+
+	if err := s.Repository.SaveStudy(study); err != nil {
+		log.Printf("[ERROR] Failed to save study: %v", err)
+		return nil, err
+	}
+	return study, nil
+}
+
+// GetStudy fetches a stored study with its results
+func (s *StudyService) GetStudy(studyID uuid.UUID) (*models.Study, error) {
+	return s.Repository.GetStudy(studyID)
+}
+
+// GetStudiesByUser lists the studies of a user
+func (s *StudyService) GetStudiesByUser(userID string) ([]models.Study, error) {
+	return s.Repository.GetStudiesByUser(userID)
+}
+
+// ScenarioInput is one scenario of a comparison: an existing study, or a
+// definition (inline or taken from a pipeline) that is run as a new study
+type ScenarioInput struct {
+	Name       string
+	StudyID    *uuid.UUID
+	PipelineID *uuid.UUID
+	Definition domain.PipelineDefinition
+	StageCount int
+}
+
+// CompareRequest lists the scenarios to compare; the first one is the baseline.
+// Replications, Units and Seed apply to scenarios that have to be run.
+type CompareRequest struct {
+	UserID       uuid.UUID
+	Scenarios    []ScenarioInput
+	Replications int
+	Units        int
+	Seed         *int64
+}
+
+// CompareScenarios returns the KPIs of every scenario side by side with their
+// deltas against the baseline and the significance of each difference.
+// Scenarios that are run share one seed so they see common random numbers.
+func (s *StudyService) CompareScenarios(ctx context.Context, req CompareRequest) (*domain.Comparison, error) {
+	if len(req.Scenarios) < 2 {
+		return nil, errors.New("at least two scenarios are required")
+	}
+	if len(req.Scenarios) > domain.MaxScenarios {
+		return nil, fmt.Errorf("at most %d scenarios can be compared", domain.MaxScenarios)
+	}
+
+	seed := ResolveSeed(req.Seed)
+	scenarios := make([]domain.Scenario, 0, len(req.Scenarios))
+	for i, input := range req.Scenarios {
+		var study *models.Study
+		var err error
+		if input.StudyID != nil {
+			study, err = s.Repository.GetStudy(*input.StudyID)
+			if err != nil {
+				return nil, fmt.Errorf("scenario %d: study not found", i+1)
+			}
+		} else {
+			study, err = s.RunStudy(ctx, StudyRequest{
+				UserID:       req.UserID,
+				PipelineID:   input.PipelineID,
+				Name:         input.Name,
+				Definition:   input.Definition,
+				StageCount:   input.StageCount,
+				Replications: req.Replications,
+				Units:        req.Units,
+				Seed:         &seed,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("scenario %d: %v", i+1, err)
+			}
+		}
+
+		name := input.Name
+		if name == "" {
+			name = study.Name
+		}
+		if name == "" {
+			name = fmt.Sprintf("scenario-%d", i+1)
+		}
+		scenarios = append(scenarios, toScenario(name, study))
+	}
+
+	return domain.CompareScenarios(scenarios)
+}
+
+func toScenario(name string, study *models.Study) domain.Scenario {
+	scenario := domain.Scenario{
+		Name:    name,
+		StudyID: study.StudyID.String(),
+		Metrics: make(map[string]domain.MetricSummary, len(study.Metrics)),
+	}
+	for _, m := range study.Metrics {
+		scenario.Metrics[m.Metric] = domain.MetricSummary{
+			N:       m.N,
+			Mean:    m.Mean,
+			StdDev:  m.StdDev,
+			Min:     m.Min,
+			Max:     m.Max,
+			P5:      m.P5,
+			P50:     m.P50,
+			P95:     m.P95,
+			CILower: m.CILower,
+			CIUpper: m.CIUpper,
+		}
+	}
+	return scenario
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// TemplateService manages versioned pipeline templates and instantiates pipelines from them
+type TemplateService struct {
+	Repository ports.TemplateRepository
+	Pipelines  *PipelineService
+}
+
+// NewTemplateService initializes the TemplateService
+func NewTemplateService(repo ports.TemplateRepository, pipelines *PipelineService) *TemplateService {
+	return &TemplateService{Repository: repo, Pipelines: pipelines}
+}
+
+// Instantiation is a pipeline created from a template version
+type Instantiation struct {
+	PipelineID uuid.UUID `json:"pipeline_id"`
+	Template   string    `json:"template"`
+	Deprecated bool      `json:"deprecated"`
+}
+
+// CreateTemplate stores the next version of a template, as a draft unless publish is set
+func (s *TemplateService) CreateTemplate(userID uuid.UUID, name string, description string, def domain.PipelineDefinition, publish bool) (*models.PipelineTemplate, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	if name == "" || strings.ContainsAny(name, "@/") || len(name) > 100 {
+		return nil, errors.New("template name is required, at most 100 characters and without '@' or '/'")
+	}
+	record, err := newTemplateRecord(description, def)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if latest, err := s.Repository.GetLatestTemplate(name, []string{domain.TemplateDraft, domain.TemplatePublished, domain.TemplateDeprecated}); err == nil {
+		version = latest.Version + 1
+	}
+	record.TemplateID = uuid.New()
+	record.Name = name
+	record.Version = version
+	record.UserID = userID
+	record.Status = domain.TemplateDraft
+	record.CreatedAt = time.Now()
+	if publish {
+		now := time.Now()
+		record.Status = domain.TemplatePublished
+		record.PublishedAt = &now
+	}
+
+	if err := s.Repository.SaveTemplate(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UpdateTemplate replaces the description and definition of a draft
+func (s *TemplateService) UpdateTemplate(ref string, description string, def domain.PipelineDefinition) (*models.PipelineTemplate, error) {
+	existing, err := s.GetTemplate(ref)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != domain.TemplateDraft {
+		return nil, fmt.Errorf("template %s@%d is %s and can no longer be changed", existing.Name, existing.Version, existing.Status)
+	}
+	record, err := newTemplateRecord(description, def)
+	if err != nil {
+		return nil, err
+	}
+	existing.Description = record.Description
+	existing.Definition = record.Definition
+	existing.UpdatedAt = record.UpdatedAt
+
+	if err := s.Repository.UpdateTemplate(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// PublishTemplate freezes a draft so pipelines can be instantiated from it
+func (s *TemplateService) PublishTemplate(ref string) (*models.PipelineTemplate, error) {
+	return s.setStatus(ref, domain.TemplateDraft, domain.TemplatePublished)
+}
+
+// DeprecateTemplate hides a published version from name@latest; pipelines can
+// still be instantiated from it by version
+func (s *TemplateService) DeprecateTemplate(ref string) (*models.PipelineTemplate, error) {
+	return s.setStatus(ref, domain.TemplatePublished, domain.TemplateDeprecated)
+}
+
+func (s *TemplateService) setStatus(ref string, from string, to string) (*models.PipelineTemplate, error) {
+	template, err := s.GetTemplate(ref)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status != from {
+		return nil, fmt.Errorf("template %s@%d is %s, only %s templates can become %s", template.Name, template.Version, template.Status, from, to)
+	}
+	if err := s.Repository.SetTemplateStatus(template.TemplateID, to); err != nil {
+		return nil, err
+	}
+	return s.Repository.GetTemplate(template.Name, template.Version)
+}
+
+// GetTemplate resolves name@version, or name for the latest published version
+func (s *TemplateService) GetTemplate(ref string) (*models.PipelineTemplate, error) {
+	parsed, err := domain.ParseTemplateRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	var template *models.PipelineTemplate
+	if parsed.Version == 0 {
+		template, err = s.Repository.GetLatestTemplate(parsed.Name, []string{domain.TemplatePublished})
+	} else {
+		template, err = s.Repository.GetTemplate(parsed.Name, parsed.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template %s not found", parsed)
+	}
+	return template, nil
+}
+
+// ListTemplates lists every template version, or the versions of name
+func (s *TemplateService) ListTemplates(name string) ([]models.PipelineTemplate, error) {
+	return s.Repository.ListTemplates(name)
+}
+
+// InstantiateTemplate creates a pipeline from a published or deprecated
+// template version with the given overrides
+func (s *TemplateService) InstantiateTemplate(userID uuid.UUID, ref string, overrides domain.TemplateOverrides) (*Instantiation, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	template, err := s.GetTemplate(ref)
+	if err != nil {
+		return nil, err
+	}
+	if template.Status == domain.TemplateDraft {
+		return nil, fmt.Errorf("template %s@%d is a draft, publish it first", template.Name, template.Version)
+	}
+
+	def, err := TemplateDefinition(template)
+	if err != nil {
+		return nil, err
+	}
+	def, err = def.Override(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineID, err := s.Pipelines.CreatePipelineFromTemplate(userID, def, template.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	instantiation := &Instantiation{
+		PipelineID: pipelineID,
+		Template:   fmt.Sprintf("%s@%d", template.Name, template.Version),
+		Deprecated: template.Status == domain.TemplateDeprecated,
+	}
+	log.Printf("Instantiated pipeline %s from template %s", pipelineID, instantiation.Template)
+	return instantiation, nil
+}
+
+// TemplateDefinition decodes the stored definition of a template
+func TemplateDefinition(template *models.PipelineTemplate) (domain.PipelineDefinition, error) {
+	var def domain.PipelineDefinition
+	err := template.Definition.Unmarshal(&def)
+	return def, err
+}
+
+func newTemplateRecord(description string, def domain.PipelineDefinition) (*models.PipelineTemplate, error) {
+	def = def.WithDefaults()
+	if err := def.ValidateTemplate(); err != nil {
+		return nil, err
+	}
+	definition, err := models.NewJSONB(def)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PipelineTemplate{
+		Description: description,
+		Definition:  definition,
+		UpdatedAt:   time.Now(),
+	}, nil
+}
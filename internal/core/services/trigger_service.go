@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/models"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/ports"
+)
+
+// TriggerService chains pipelines: when a run ends, the triggers of its
+// pipeline queue runs of their target pipelines
+type TriggerService struct {
+	Repository ports.TriggerRepository
+	Pipelines  *PipelineService
+}
+
+// NewTriggerService initializes the TriggerService; set HandleRunEnded as the
+// OnRunEnded hook of the PipelineService to fire triggers
+func NewTriggerService(repo ports.TriggerRepository, pipelines *PipelineService) *TriggerService {
+	return &TriggerService{Repository: repo, Pipelines: pipelines}
+}
+
+// CreateTrigger starts spec.TargetPipelineID whenever a run of sourceID ends
+// with the event spec.On
+func (s *TriggerService) CreateTrigger(userID uuid.UUID, sourceID uuid.UUID, spec domain.TriggerSpec) (*models.PipelineTrigger, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID is required")
+	}
+	if !domain.ValidTriggerEvent(spec.On) {
+		return nil, fmt.Errorf("unknown trigger event %q, expected %s, %s, %s or %s", spec.On,
+			domain.TriggerOnCompleted, domain.TriggerOnFailed, domain.TriggerOnCancelled, domain.TriggerOnFinished)
+	}
+	// Only completed runs have an output to pass on
+	if spec.PassOutput && spec.On != domain.TriggerOnCompleted {
+		return nil, fmt.Errorf("pass_output needs a trigger on %s, not %s", domain.TriggerOnCompleted, spec.On)
+	}
+	if sourceID == spec.TargetPipelineID {
+		return nil, errors.New("a pipeline cannot trigger itself")
+	}
+	if _, err := s.Pipelines.GetPipelineDefinition(sourceID); err != nil {
+		return nil, errors.New("source pipeline not found")
+	}
+	if _, err := s.Pipelines.GetPipelineDefinition(spec.TargetPipelineID); err != nil {
+		return nil, errors.New("target pipeline not found")
+	}
+	input, err := models.NewJSONB(spec.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := &models.PipelineTrigger{
+		TriggerID:        uuid.New(),
+		SourcePipelineID: sourceID,
+		TargetPipelineID: spec.TargetPipelineID,
+		UserID:           userID,
+		On:               spec.On,
+		PassOutput:       spec.PassOutput,
+		Input:            input,
+		Enabled:          true,
+	}
+	if err := s.Repository.SaveTrigger(trigger); err != nil {
+		return nil, err
+	}
+	log.Printf("Pipeline %s now triggers pipeline %s when %s", sourceID, spec.TargetPipelineID, spec.On)
+	return trigger, nil
+}
+
+// SetTriggerEnabled enables or disables a trigger
+func (s *TriggerService) SetTriggerEnabled(triggerID uuid.UUID, enabled bool) (*models.PipelineTrigger, error) {
+	if err := s.Repository.SetTriggerEnabled(triggerID, enabled); err != nil {
+		return nil, err
+	}
+	return s.GetTrigger(triggerID)
+}
+
+// DeleteTrigger removes a trigger; runs it already started are kept
+func (s *TriggerService) DeleteTrigger(triggerID uuid.UUID) error {
+	return s.Repository.DeleteTrigger(triggerID)
+}
+
+// GetTrigger fetches a trigger by ID
+func (s *TriggerService) GetTrigger(triggerID uuid.UUID) (*models.PipelineTrigger, error) {
+	trigger, err := s.Repository.GetTrigger(triggerID)
+	if err != nil {
+		return nil, errors.New("trigger not found")
+	}
+	return trigger, nil
+}
+
+// ListTriggers lists the triggers a pipeline is the source or the target of
+func (s *TriggerService) ListTriggers(pipelineID uuid.UUID) ([]models.PipelineTrigger, error) {
+	return s.Repository.GetTriggers(pipelineID)
+}
+
+// HandleRunEnded fires the triggers of the pipeline a run belongs to that
+// match the status the run ended with
+func (s *TriggerService) HandleRunEnded(runID uuid.UUID, status domain.Status) {
+	run, err := s.Pipelines.Repository.GetPipelineRun(runID)
+	if err != nil {
+		log.Printf("Failed to load run %s for its triggers: %v", runID, err)
+		return
+	}
+	triggers, err := s.Repository.GetSourceTriggers(run.PipelineID)
+	if err != nil {
+		log.Printf("Failed to load triggers of pipeline %s: %v", run.PipelineID, err)
+		return
+	}
+	for i := range triggers {
+		if !domain.TriggerMatches(triggers[i].On, status) {
+			continue
+		}
+		if err := s.fire(&triggers[i], run); err != nil {
+			log.Printf("Trigger %s from run %s of pipeline %s did not fire: %v", triggers[i].TriggerID, runID, run.PipelineID, err)
+		}
+	}
+}
+
+// fire queues a run of the target pipeline of a trigger, unless that run
+// would close a loop in the chain of runs that led here
+func (s *TriggerService) fire(trigger *models.PipelineTrigger, run *models.PipelineRun) error {
+	var chain domain.TriggerChain
+	if err := run.TriggerChain.Unmarshal(&chain); err != nil {
+		return err
+	}
+	source := domain.TriggerLink{PipelineID: run.PipelineID, RunID: run.RunID, TriggerID: run.TriggerID}
+	chain, err := chain.Extend(source, trigger.TargetPipelineID)
+	if err != nil {
+		s.recordFire(trigger.TriggerID, nil, err)
+		return err
+	}
+
+	var input interface{}
+	if trigger.PassOutput {
+		if len(run.Output) == 0 {
+			err = fmt.Errorf("run %s ended %s without an output to pass on", run.RunID, run.Status)
+			s.recordFire(trigger.TriggerID, nil, err)
+			return err
+		}
+		err = run.Output.Unmarshal(&input)
+	} else {
+		err = trigger.Input.Unmarshal(&input)
+	}
+	if err != nil {
+		s.recordFire(trigger.TriggerID, nil, err)
+		return err
+	}
+
+	definition, err := s.Pipelines.GetPipelineDefinition(trigger.TargetPipelineID)
+	if err != nil {
+		s.recordFire(trigger.TriggerID, nil, err)
+		return err
+	}
+	order := &domain.WorkOrder{
+		PipelineID: trigger.TargetPipelineID,
+		UserID:     trigger.UserID,
+		IsParallel: definition.IsParallel,
+		Input:      input,
+		Seed:       ResolveSeed(nil),
+		Trigger:    &domain.TriggerCause{TriggerID: trigger.TriggerID, RunID: run.RunID, Chain: chain},
+		Actor:      domain.TriggerActor(trigger.TriggerID),
+	}
+	position, err := s.Pipelines.EnqueuePipeline(order)
+	if err != nil {
+		s.recordFire(trigger.TriggerID, nil, err)
+		return err
+	}
+	log.Printf("Trigger %s queued run %s of pipeline %s at position %d after run %s of pipeline %s",
+		trigger.TriggerID, order.RunID, trigger.TargetPipelineID, position, run.RunID, run.PipelineID)
+	s.recordFire(trigger.TriggerID, &order.RunID, nil)
+	return nil
+}
+
+func (s *TriggerService) recordFire(triggerID uuid.UUID, runID *uuid.UUID, fireErr error) {
+	message := ""
+	if fireErr != nil {
+		message = fireErr.Error()
+	}
+	if err := s.Repository.RecordTriggerFire(triggerID, time.Now(), runID, message); err != nil {
+		log.Printf("Failed to record fire of trigger %s: %v", triggerID, err)
+	}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+)
+
+// DefaultMaxConcurrency is the number of pipelines that run at once when
+// PIPELINE_MAX_CONCURRENCY is not set
+const DefaultMaxConcurrency = 4
+
+// MaxConcurrencyFromEnv reads the server-wide limit on concurrently running pipelines
+func MaxConcurrencyFromEnv() int {
+	if raw := os.Getenv("PIPELINE_MAX_CONCURRENCY"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			return n
+		}
+		log.Printf("Ignoring invalid PIPELINE_MAX_CONCURRENCY %q", raw)
+	}
+	return DefaultMaxConcurrency
+}
+
+// QueuedOrder is a work order with its 1-based position in the queue
+type QueuedOrder struct {
+	Position int `json:"position"`
+	*domain.WorkOrder
+}
+
+// QueueSnapshot is the state of the work-order queue at one point in time
+type QueueSnapshot struct {
+	Rule           string        `json:"rule"`
+	MaxConcurrency int           `json:"max_concurrency"`
+	Running        int           `json:"running"`
+	Orders         []QueuedOrder `json:"orders"`
+}
+
+// WorkOrderUpdate changes a queued order; nil fields are left as they are. A
+// Position moves the order explicitly; otherwise it is re-placed by the rule.
+type WorkOrderUpdate struct {
+	Priority *int
+	DueDate  *time.Time
+	Position *int
+}
+
+// WorkQueue holds work orders until a run slot frees up. Orders are kept in
+// dispatch order: new and updated orders are placed by the active rule, and
+// explicit moves hold until the rule changes.
+type WorkQueue struct {
+	mu      sync.Mutex
+	rule    domain.DispatchRule
+	orders  []*domain.WorkOrder
+	running int
+	limit   int
+	seq     int64
+	run     func(*domain.WorkOrder)
+}
+
+// NewWorkQueue creates a FIFO queue that runs at most limit orders at once with run
+func NewWorkQueue(limit int, run func(*domain.WorkOrder)) *WorkQueue {
+	rule, _ := domain.DispatchRuleByName(domain.DefaultDispatchRule)
+	return &WorkQueue{rule: rule, limit: limit, run: run}
+}
+
+// Enqueue adds an order and returns its position, or 0 if it was dispatched at once
+func (q *WorkQueue) Enqueue(order *domain.WorkOrder) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.indexOf(order.PipelineID) >= 0 {
+		return 0, errors.New("pipeline is already queued")
+	}
+
+	q.seq++
+	order.Seq = q.seq
+	order.EnqueuedAt = time.Now()
+	q.insert(order)
+	q.dispatch()
+	return q.indexOf(order.PipelineID) + 1, nil
+}
+
+// Remove drops a queued order and reports whether it was queued
+func (q *WorkQueue) Remove(pipelineID uuid.UUID) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i := q.indexOf(pipelineID)
+	if i < 0 {
+		return false
+	}
+	q.orders = append(q.orders[:i], q.orders[i+1:]...)
+	return true
+}
+
+// SetRule switches the dispatching rule and re-sorts the queue
+func (q *WorkQueue) SetRule(name string) error {
+	rule, err := domain.DispatchRuleByName(name)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rule = rule
+	sort.SliceStable(q.orders, func(i, j int) bool { return rule.Less(q.orders[i], q.orders[j]) })
+	return nil
+}
+
+// Update changes the priority or due date of a queued order, or moves it
+func (q *WorkQueue) Update(pipelineID uuid.UUID, update WorkOrderUpdate) (*QueuedOrder, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i := q.indexOf(pipelineID)
+	if i < 0 {
+		return nil, errors.New("pipeline is not queued")
+	}
+	order := q.orders[i]
+	q.orders = append(q.orders[:i], q.orders[i+1:]...)
+
+	if update.Priority != nil {
+		order.Priority = *update.Priority
+	}
+	if update.DueDate != nil {
+		order.DueDate = update.DueDate
+	}
+
+	if update.Position != nil {
+		pos := *update.Position - 1
+		if pos < 0 {
+			pos = 0
+		}
+		if pos > len(q.orders) {
+			pos = len(q.orders)
+		}
+		q.orders = append(q.orders[:pos], append([]*domain.WorkOrder{order}, q.orders[pos:]...)...)
+	} else {
+		q.insert(order)
+	}
+
+	return &QueuedOrder{Position: q.indexOf(pipelineID) + 1, WorkOrder: order}, nil
+}
+
+// Snapshot returns the rule, load and queued orders in dispatch order
+func (q *WorkQueue) Snapshot() QueueSnapshot {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	snapshot := QueueSnapshot{
+		Rule:           q.rule.Name(),
+		MaxConcurrency: q.limit,
+		Running:        q.running,
+		Orders:         make([]QueuedOrder, len(q.orders)),
+	}
+	for i, order := range q.orders {
+		copied := *order
+		snapshot.Orders[i] = QueuedOrder{Position: i + 1, WorkOrder: &copied}
+	}
+	return snapshot
+}
+
+// insert places the order in front of the first order the rule puts behind it
+func (q *WorkQueue) insert(order *domain.WorkOrder) {
+	i := 0
+	for i < len(q.orders) && !q.rule.Less(order, q.orders[i]) {
+		i++
+	}
+	q.orders = append(q.orders[:i], append([]*domain.WorkOrder{order}, q.orders[i:]...)...)
+}
+
+func (q *WorkQueue) indexOf(pipelineID uuid.UUID) int {
+	for i, order := range q.orders {
+		if order.PipelineID == pipelineID {
+			return i
+		}
+	}
+	return -1
+}
+
+// dispatch starts orders from the head of the queue while run slots are free;
+// the caller holds the lock
+func (q *WorkQueue) dispatch() {
+	for q.running < q.limit && len(q.orders) > 0 {
+		order := q.orders[0]
+		q.orders = q.orders[1:]
+		q.running++
+
+		go func(order *domain.WorkOrder) {
+			q.run(order)
+
+			q.mu.Lock()
+			q.running--
+			q.dispatch()
+			q.mu.Unlock()
+		}(order)
+	}
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/core/domain"
+	"github.com/hsri-pf9/distributed-manufacturing-pipeline-simulation-system/internal/utils"
+)
+
+// Workers heartbeat every HeartbeatInterval and are dropped after WorkerTimeout
+// without one; their tasks go back to the queue
+const (
+	HeartbeatInterval = 5 * time.Second
+	WorkerTimeout     = 3 * HeartbeatInterval
+)
+
+// ErrWorkerNotRegistered is returned to workers that are unknown or expired;
+// they have to register again
+var ErrWorkerNotRegistered = errors.New("worker is not registered")
+
+// RemoteExecutionFromEnv reports whether STAGE_EXECUTOR selects remote workers
+// instead of the in-process default
+func RemoteExecutionFromEnv() bool {
+	switch os.Getenv("STAGE_EXECUTOR") {
+	case "", "local":
+		return false
+	case "remote":
+		return true
+	default:
+		log.Printf("Ignoring unknown STAGE_EXECUTOR %q, running stages in-process", os.Getenv("STAGE_EXECUTOR"))
+		return false
+	}
+}
+
+// RemoteWorker is a registered worker process
+type RemoteWorker struct {
+	WorkerID      uuid.UUID `json:"worker_id"`
+	Name          string    `json:"name"`
+	StageTypes    []string  `json:"stage_types"`
+	Capacity      int       `json:"capacity"`
+	Running       int       `json:"running"`
+	RegisteredAt  time.Time `json:"registered_at"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+}
+
+func (w *RemoteWorker) runs(stageType string) bool {
+	for _, t := range w.StageTypes {
+		if t == stageType {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskResult is what a worker reports for a finished task
+type TaskResult struct {
+	Output interface{}
+	Offset time.Duration
+	Err    error
+
+	// Orphaned is set when the task lost its worker and no other worker runs its type
+	Orphaned bool
+}
+
+type remoteTask struct {
+	task      domain.StageTask
+	workerID  uuid.UUID
+	cancelled bool
+	done      chan TaskResult
+}
+
+// WorkerPool dispatches stages to remote workers. Workers pull tasks of the
+// stage types they advertised; tasks wait in FIFO order until one does.
+type WorkerPool struct {
+	mu       sync.Mutex
+	workers  map[uuid.UUID]*RemoteWorker
+	pending  []*remoteTask
+	assigned map[uuid.UUID]*remoteTask
+	notify   chan struct{}
+
+	// Fallback runs stages no registered worker can take; nil fails them instead
+	Fallback domain.StageExecutor
+	SSE      *utils.SSEManager
+}
+
+// NewWorkerPool creates an empty pool and starts expiring silent workers
+func NewWorkerPool(fallback domain.StageExecutor, sse *utils.SSEManager) *WorkerPool {
+	p := &WorkerPool{
+		workers:  make(map[uuid.UUID]*RemoteWorker),
+		assigned: make(map[uuid.UUID]*remoteTask),
+		notify:   make(chan struct{}),
+		Fallback: fallback,
+		SSE:      sse,
+	}
+	go p.expireWorkers()
+	return p
+}
+
+// ExecuteStage sends the stage to a worker and waits for its result. Runs on a
+// virtual clock (replays) always execute in-process.
+func (p *WorkerPool) ExecuteStage(ctx context.Context, stage domain.Stage, input interface{}, run *domain.StageRun) (interface{}, error) {
+	if _, ok := run.Clock.(domain.RealClock); !ok {
+		return domain.LocalExecutor{}.ExecuteStage(ctx, stage, input, run)
+	}
+
+	stageType := stage.GetSpec().StageType()
+	rt := &remoteTask{task: domain.NewStageTask(stage, input, run), done: make(chan TaskResult, 1)}
+
+	p.mu.Lock()
+	if !p.hasWorkerFor(stageType) {
+		p.mu.Unlock()
+		return p.fallback(ctx, stage, input, run, stageType)
+	}
+	p.pending = append(p.pending, rt)
+	p.wake()
+	p.mu.Unlock()
+
+	select {
+	case result := <-rt.done:
+		if result.Orphaned {
+			return p.fallback(ctx, stage, input, run, stageType)
+		}
+		run.Offset = result.Offset
+		return result.Output, result.Err
+	case <-ctx.Done():
+		p.cancel(rt)
+		return nil, ctx.Err()
+	}
+}
+
+func (p *WorkerPool) fallback(ctx context.Context, stage domain.Stage, input interface{}, run *domain.StageRun, stageType string) (interface{}, error) {
+	if p.Fallback == nil {
+		return nil, fmt.Errorf("no worker registered for stage type %q", stageType)
+	}
+	// Each replica has its own pool: workers that reach only some replicas
+	// leave the others running every stage in-process
+	log.Printf("[WARN] No worker registered with this server for stage type %q, running stage %s in-process", stageType, stage.GetID())
+	return p.Fallback.ExecuteStage(ctx, stage, input, run)
+}
+
+// Register adds a worker that runs the given stage types, capacity at a time
+func (p *WorkerPool) Register(name string, stageTypes []string, capacity int) (*RemoteWorker, error) {
+	if len(stageTypes) == 0 {
+		return nil, errors.New("worker must advertise at least one stage type")
+	}
+	if capacity <= 0 {
+		capacity = 1
+	}
+
+	now := time.Now()
+	worker := &RemoteWorker{
+		WorkerID:      uuid.New(),
+		Name:          name,
+		StageTypes:    stageTypes,
+		Capacity:      capacity,
+		RegisteredAt:  now,
+		LastHeartbeat: now,
+	}
+
+	p.mu.Lock()
+	p.workers[worker.WorkerID] = worker
+	p.mu.Unlock()
+
+	log.Printf("Registered worker %s (%s) for stage types %v", worker.WorkerID, name, stageTypes)
+	copied := *worker
+	return &copied, nil
+}
+
+// PullTask hands the worker the oldest pending task it can run, waiting up to
+// wait for one; it returns nil when none arrived in time
+func (p *WorkerPool) PullTask(ctx context.Context, workerID uuid.UUID, wait time.Duration) (*domain.StageTask, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		p.mu.Lock()
+		worker, ok := p.workers[workerID]
+		if !ok {
+			p.mu.Unlock()
+			return nil, ErrWorkerNotRegistered
+		}
+		worker.LastHeartbeat = time.Now()
+
+		if worker.Running < worker.Capacity {
+			for i, rt := range p.pending {
+				if !worker.runs(rt.task.Type) {
+					continue
+				}
+				p.pending = append(p.pending[:i], p.pending[i+1:]...)
+				rt.workerID = workerID
+				p.assigned[rt.task.TaskID] = rt
+				worker.Running++
+				p.mu.Unlock()
+
+				log.Printf("Assigned stage %s of pipeline %s to worker %s", rt.task.StageID, rt.task.PipelineID, workerID)
+				task := rt.task
+				return &task, nil
+			}
+		}
+		notify := p.notify
+		p.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Heartbeat marks the worker alive, publishes the progress of its tasks and
+// returns the tasks it should abandon because their pipeline was cancelled
+func (p *WorkerPool) Heartbeat(workerID uuid.UUID, progress map[uuid.UUID]float64) ([]uuid.UUID, error) {
+	p.mu.Lock()
+	worker, ok := p.workers[workerID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, ErrWorkerNotRegistered
+	}
+	worker.LastHeartbeat = time.Now()
+
+	var cancelled []uuid.UUID
+	var updates []map[string]interface{}
+	for taskID, rt := range p.assigned {
+		if rt.workerID != workerID {
+			continue
+		}
+		if rt.cancelled {
+			cancelled = append(cancelled, taskID)
+			continue
+		}
+		if fraction, ok := progress[taskID]; ok {
+			updates = append(updates, map[string]interface{}{
+				"type":        "progress",
+				"stage_id":    rt.task.StageID.String(),
+				"pipeline_id": rt.task.PipelineID.String(),
+				"worker_id":   workerID.String(),
+				"progress":    fraction,
+			})
+		}
+	}
+	p.mu.Unlock()
+
+	// 🔹 Broadcast stage progress via SSE
+	for _, update := range updates {
+		p.SSE.BroadcastUpdate(update)
+	}
+	return cancelled, nil
+}
+
+// ReportResult completes a task; results of tasks the worker no longer holds
+// (cancelled or reassigned after a timeout) are ignored
+func (p *WorkerPool) ReportResult(workerID uuid.UUID, taskID uuid.UUID, result TaskResult) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	worker, ok := p.workers[workerID]
+	if !ok {
+		return false, ErrWorkerNotRegistered
+	}
+	worker.LastHeartbeat = time.Now()
+
+	rt, ok := p.assigned[taskID]
+	if !ok || rt.workerID != workerID {
+		return false, nil
+	}
+	delete(p.assigned, taskID)
+	worker.Running--
+	p.wake()
+
+	if !rt.cancelled {
+		rt.done <- result
+	}
+	return true, nil
+}
+
+// ListWorkers returns the registered workers
+func (p *WorkerPool) ListWorkers() []RemoteWorker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	workers := make([]RemoteWorker, 0, len(p.workers))
+	for _, w := range p.workers {
+		workers = append(workers, *w)
+	}
+	return workers
+}
+
+// cancel withdraws a task whose stage was cancelled; an assigned task stays
+// assigned until its worker learns of it on the next heartbeat
+func (p *WorkerPool) cancel(rt *remoteTask) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rt.cancelled = true
+	for i, pending := range p.pending {
+		if pending == rt {
+			p.pending = append(p.pending[:i], p.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// expireWorkers drops workers that stopped heartbeating and requeues their tasks
+func (p *WorkerPool) expireWorkers() {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.mu.Lock()
+		now := time.Now()
+		for id, w := range p.workers {
+			if now.Sub(w.LastHeartbeat) <= WorkerTimeout {
+				continue
+			}
+			log.Printf("Worker %s (%s) missed its heartbeats, dropping it", id, w.Name)
+			delete(p.workers, id)
+
+			for taskID, rt := range p.assigned {
+				if rt.workerID != id {
+					continue
+				}
+				delete(p.assigned, taskID)
+				if !rt.cancelled {
+					rt.workerID = uuid.Nil
+					p.pending = append([]*remoteTask{rt}, p.pending...)
+				}
+			}
+		}
+
+		// Tasks nobody can run any more are handed back to their stage
+		kept := p.pending[:0]
+		for _, rt := range p.pending {
+			if p.hasWorkerFor(rt.task.Type) {
+				kept = append(kept, rt)
+			} else {
+				rt.done <- TaskResult{Orphaned: true}
+			}
+		}
+		p.pending = kept
+		p.wake()
+		p.mu.Unlock()
+	}
+}
+
+// hasWorkerFor reports whether a registered worker runs stageType; the caller
+// holds the lock
+func (p *WorkerPool) hasWorkerFor(stageType string) bool {
+	for _, w := range p.workers {
+		if w.runs(stageType) {
+			return true
+		}
+	}
+	return false
+}
+
+// wake releases every PullTask waiting for new work; the caller holds the lock
+func (p *WorkerPool) wake() {
+	close(p.notify)
+	p.notify = make(chan struct{})
+}
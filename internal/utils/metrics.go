@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus metrics of the servers, registered on the default registry next
+// to the Go runtime and process metrics
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "REST requests handled, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle REST requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	GRPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_requests_total",
+		Help: "gRPC calls handled, by full method name and status code.",
+	}, []string{"method", "code"})
+	GRPCRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_request_duration_seconds",
+		Help:    "Time taken to handle unary gRPC calls, by full method name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	StatusTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_status_transitions_total",
+		Help: "Status changes of pipelines and runs made by this server, by entity and new status.",
+	}, []string{"entity", "status"})
+	ActiveExecutions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pipeline_active_executions",
+		Help: "Pipeline runs an orchestrator of this server is executing, by mode.",
+	}, []string{"mode"})
+	StageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pipeline_stage_duration_seconds",
+		Help:    "Simulated duration of stage attempts, by pipeline mode and outcome.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"mode", "status"})
+
+	SSEClients = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sse_clients",
+		Help: "Connected SSE clients.",
+	})
+	SSEMessagesSent = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sse_messages_sent_total",
+		Help: "SSE messages queued to clients.",
+	})
+	SSEMessagesDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sse_messages_dropped_total",
+		Help: "SSE messages dropped because the channel of a client was full.",
+	})
+
+	DBCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_call_duration_seconds",
+		Help:    "Latency of database calls, by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+)
+
+// MetricsHandler serves the registered metrics in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// StatusCounter counts the pipelines in each status
+type StatusCounter func() (map[string]int, error)
+
+// RegisterPipelineStatus reports the pipelines by status as the pipelines
+// gauge. They are counted on every scrape, so the gauge covers the pipelines
+// of every replica rather than those this server changed.
+func RegisterPipelineStatus(count StatusCounter) {
+	prometheus.MustRegister(&pipelineStatusCollector{count: count})
+}
+
+var pipelinesDesc = prometheus.NewDesc("pipelines", "Pipelines by status.", []string{"status"}, nil)
+
+type pipelineStatusCollector struct {
+	count StatusCounter
+}
+
+func (c *pipelineStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pipelinesDesc
+}
+
+// Collect leaves the gauge out of a scrape the database fails, so the other
+// metrics are still served
+func (c *pipelineStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.count()
+	if err != nil {
+		log.Printf("[Metrics] Failed to count pipelines by status: %v", err)
+		return
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		ch <- prometheus.MustNewConstMetric(pipelinesDesc, prometheus.GaugeValue, float64(counts[status]), status)
+	}
+}
@@ -31,6 +31,7 @@ func (s *SSEManager) RegisterClient(c *gin.Context) {
 	s.mu.Lock()
 	s.clients[clientChan] = true
 	s.mu.Unlock()
+	SSEClients.Inc()
 
 	log.Println("[SSE] New client connected")
 
@@ -73,6 +74,7 @@ func (s *SSEManager) RegisterClient(c *gin.Context) {
 	delete(s.clients, clientChan)
 	s.mu.Unlock()
 	close(clientChan)
+	SSEClients.Dec()
 
 	log.Println("[SSE] Client disconnected")
 }
@@ -94,7 +96,9 @@ func (s *SSEManager) BroadcastUpdate(data interface{}) {
 	for clientChan := range s.clients {
 		select {
 		case clientChan <- string(message):
+			SSEMessagesSent.Inc()
 		default:
+			SSEMessagesDropped.Inc()
 			log.Println("[SSE] Client channel full, dropping message")
 		}
 	}